@@ -2,18 +2,30 @@ package add
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"time"
 
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
-	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
 )
 
+var (
+	// ttl is the duration of the temporary routes, zero means permanent
+	ttl time.Duration
+	// accumulate keeps the IPs that are resolved over time routed instead of only the latest ones
+	accumulate bool
+)
+
+func init() {
+	AddCmd.Flags().DurationVarP(&ttl, "for", "", 0, "add the routes temporarily, they are removed automatically after the given duration (e.g. 2h)")
+	AddCmd.Flags().BoolVarP(&accumulate, "accumulate", "", false, "keep the IPs that are resolved over time routed instead of only the latest ones, for the domains behind DNS round-robin")
+}
+
 // AddCmd represents the add command
 var AddCmd = &cobra.Command{
 	Use:   "add",
@@ -29,52 +41,48 @@ to quickly create a Cobra application.`,
 			return utils.ErrNoArgs
 		}
 
+		if ttl < 0 {
+			return utils.ErrNegativeTTL
+		}
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Set up a connection to the server.
-		cl, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Any("args", args).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
 		if err != nil {
-			log.Fatalf("did not connect: %v", err)
+			return err
 		}
 		defer cl.Close()
-		c := pb.NewRouteManagerClient(cl)
 
-		// Contact the server and print out its response.
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+		items := make([]*ipc.ItemResult, 0, len(args))
+		for _, arg := range args {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			ips, err := cl.Add(ctx, arg, client.AddOptions{TTL: ttl, Accumulate: accumulate})
+			cancel()
 
-		r, err := c.AddRoute(ctx, &pb.AddRouteRequest{Destination: "example.com"})
-		if err != nil {
-			log.Fatalf("could not add route: %v", err)
-		}
-		fmt.Printf("AddRoute Response: %v\n", r)
+			// business errors are reported per destination, the others mean that the daemon cannot be reached
+			rpcErr := utils.DecodeError(err)
+			if rpcErr != nil && !rpcErr.Business() {
+				logger.Error().Str("domain", arg).Err(err).Msg(constants.FailedToProcessCommand)
 
-		// Handle the business error
-		if r.GetError() != nil {
-			fmt.Printf("Business Error: %v\n", r.GetError().String())
+				return &utils.CommandError{Err: errors.Wrap(rpcErr, constants.FailedToConnectToDaemon), Code: utils.ConnectionFailedCode}
+			}
+
+			items = append(items, utils.NewItemResult(arg, ipc.StatusAdded, ips, rpcErr))
 		}
 
-		return nil
-		//logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
-		//socketPath := cmd.Context().Value(constants.SocketPathKey{}).(string)
-		//
-		//logger.Info().
-		//	Str("operation", cmd.Name()).
-		//	Any("args", args).
-		//	Msg(constants.ProcessCommand)
-		//
-		//for _, arg := range args {
-		//	req := fmt.Sprintf("%s %s", cmd.Name(), arg)
-		//	res, err := utils.SendCommandToDaemon(socketPath, req)
-		//	if err != nil {
-		//		logger.Error().Str("command", req).Err(err).Msg(constants.FailedToProcessCommand)
-		//		continue
-		//	}
-		//
-		//	logger.Info().Str("command", req).Str("response", res).Msg(constants.SuccessfullyProcessed)
-		//}
-		//
-		//return nil
+		res := ipc.NewItemsResponse(items)
+		logger.Info().
+			Str("response", res.Response).
+			Msg(constants.SuccessfullyProcessed)
+
+		return utils.RenderResults(cmd, res)
 	},
 }
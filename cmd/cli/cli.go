@@ -9,27 +9,47 @@ import (
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/paths"
 	"github.com/pkg/errors"
 
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/add"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/doctor"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/export"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/get"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/group"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/imports"
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/list"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/preset"
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/remove"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/status"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/trace"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/update"
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/watch"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	verbose   bool
-	workspace string
-	ver       = version.Get()
-	cliCmd    = &cobra.Command{
+	verbose bool
+	// output is the name of the output format of the results
+	output string
+	ver    = version.Get()
+	cliCmd = &cobra.Command{
 		Use:     "stt-cli",
 		Short:   "",
 		Long:    ``,
 		Version: ver.GitVersion,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			format, err := utils.ParseFormat(output)
+			if err != nil {
+				return err
+			}
+
+			// results are written to stdout, so that they can be piped without the logs
+			logging.SetOutput(os.Stderr)
+
 			logger := logging.GetLogger()
 			logger.Info().Str("appVersion", ver.GitVersion).Str("goVersion", ver.GoVersion).Str("goOS", ver.GoOs).
 				Str("goArch", ver.GoArch).Str("gitCommit", ver.GitCommit).Str("buildDate", ver.BuildDate).
@@ -40,8 +60,26 @@ var (
 				logger.Debug().Str("foo", "bar").Msg("this is a dummy log")
 			}
 
+			// sockets are resolved the same way with the daemon, so that both find each other without any flags
+			defaults := paths.Resolve(paths.Setting(cmd.Flags(), "workspace"))
+			socketPath, err := filepath.Abs(firstNonEmpty(paths.Setting(cmd.Flags(), "socket-path"), defaults.SocketPath))
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve socket path")
+			}
+
+			grpcSocketPath, err := filepath.Abs(firstNonEmpty(paths.Setting(cmd.Flags(), "grpc-socket-path"), defaults.GrpcSocketPath))
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve grpc socket path")
+			}
+
+			logger.Debug().Str("socket", socketPath).Str("grpcSocket", grpcSocketPath).Msg("resolved daemon sockets")
+
 			cmd.SetContext(context.WithValue(cmd.Context(), constants.LoggerKey{}, logger))
-			cmd.SetContext(context.WithValue(cmd.Context(), constants.SocketPathKey{}, filepath.Join(workspace, constants.SocketFileName)))
+			cmd.SetContext(context.WithValue(cmd.Context(), constants.SocketPathKey{}, socketPath))
+			cmd.SetContext(context.WithValue(cmd.Context(), constants.GrpcSocketPathKey{}, grpcSocketPath))
+			cmd.SetContext(context.WithValue(cmd.Context(), constants.OutputFormatKey{}, format))
+
+			return nil
 		},
 	}
 )
@@ -57,16 +95,36 @@ func main() {
 }
 
 func init() {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		panic(errors.Wrap(err, "failed to get user home directory"))
-	}
-
-	cliCmd.PersistentFlags().StringVarP(&workspace, "workspace", "w", filepath.Join(homeDir, ".split-the-tunnel"), "workspace directory path")
+	cliCmd.PersistentFlags().StringP("workspace", "w", "", "workspace directory path of the daemon, system-wide locations are used if not set")
+	cliCmd.PersistentFlags().String("socket-path", "", "IPC socket path of the daemon, defaults to "+filepath.Join(paths.RuntimeDir, constants.SocketFileName))
+	cliCmd.PersistentFlags().String("grpc-socket-path", "", "gRPC socket path of the daemon, defaults to "+filepath.Join(paths.RuntimeDir, constants.GrpcSocketFileName))
 	cliCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose mode")
+	cliCmd.PersistentFlags().StringVarP(&output, "output", "o", string(utils.FormatTable), "output format of the results, one of table, json, yaml, wide and plain")
+	_ = cliCmd.PersistentFlags().MarkDeprecated("socket-path", "every command talks to the daemon over gRPC, use --grpc-socket-path instead")
 
 	cliCmd.AddCommand(add.AddCmd)
 	cliCmd.AddCommand(list.ListCmd)
+	cliCmd.AddCommand(get.GetCmd)
+	cliCmd.AddCommand(update.UpdateCmd)
 	cliCmd.AddCommand(remove.RemoveCmd)
 	cliCmd.AddCommand(purge.PurgeCmd)
+	cliCmd.AddCommand(group.GroupCmd)
+	cliCmd.AddCommand(preset.PresetCmd)
+	cliCmd.AddCommand(watch.WatchCmd)
+	cliCmd.AddCommand(status.StatusCmd)
+	cliCmd.AddCommand(doctor.DoctorCmd)
+	cliCmd.AddCommand(trace.TraceCmd)
+	cliCmd.AddCommand(export.ExportCmd)
+	cliCmd.AddCommand(imports.ImportCmd)
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
 }
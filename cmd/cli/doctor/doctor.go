@@ -0,0 +1,113 @@
+package doctor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/doctor"
+	internalutils "github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// FindingsFailedCode is the exit code of the doctor command if any of the checks failed
+const FindingsFailedCode = 17
+
+// DoctorOutput is the stable schema of the doctor command in the structured output formats
+type DoctorOutput struct {
+	Healthy  bool             `json:"healthy"`
+	Findings []doctor.Finding `json:"findings"`
+}
+
+// DoctorCmd represents the doctor command
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "check the socket, the daemon, the gateway, the kernel routes and the DNS servers, and print what to fix",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Msg(constants.ProcessCommand)
+
+		findings := diagnose(cmd)
+
+		doc := DoctorOutput{Healthy: doctor.Healthy(findings), Findings: findings}
+		table := &utils.Table{Columns: []utils.Column{
+			{Key: "check", Header: "Check"},
+			{Key: "severity", Header: "Result"},
+			{Key: "message", Header: "Finding"},
+			{Key: "hint", Header: "Hint"},
+		}}
+
+		for _, f := range findings {
+			table.Rows = append(table.Rows, []string{f.Check, string(f.Severity), f.Message, f.Hint})
+		}
+
+		if err := utils.Render(cmd, doc, table); err != nil {
+			return err
+		}
+
+		if !doc.Healthy {
+			logger.Error().Str("command", cmd.Name()).Msg(constants.FailedToProcessCommand)
+
+			return &utils.CommandError{Err: errors.New("some of the checks failed"), Code: FindingsFailedCode}
+		}
+
+		logger.Info().Str("command", cmd.Name()).Msg(constants.SuccessfullyProcessed)
+
+		return nil
+	},
+}
+
+// diagnose runs the checks in order, the ones that need the daemon are skipped if it cannot be reached
+func diagnose(cmd *cobra.Command) []doctor.Finding {
+	socketPath := cmd.Context().Value(constants.GrpcSocketPathKey{}).(string)
+	findings := []doctor.Finding{doctor.CheckSocketAccess(socketPath)}
+
+	cl, err := utils.DialDaemon(cmd)
+	if err != nil {
+		return append(findings, doctor.CheckDaemonStatus(nil, err))
+	}
+	defer cl.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	status, err := cl.Status(ctx)
+	var routes []*client.Route
+	if err == nil {
+		routes, err = cl.List(ctx)
+	}
+
+	if err != nil {
+		return append(findings, doctor.CheckDaemonStatus(nil, utils.DecodeError(err)), doctor.Finding{
+			Check:    doctor.CheckRoutes,
+			Severity: doctor.SeveritySkipped,
+			Message:  "gateway, routes, shadowing and dns checks need the daemon",
+		})
+	}
+
+	findings = append(findings, doctor.CheckDaemonStatus(status, nil))
+
+	table, err := internalutils.GetRoutingTable()
+	if err != nil {
+		findings = append(findings, doctor.Finding{
+			Check:    doctor.CheckRoutes,
+			Severity: doctor.SeveritySkipped,
+			Message:  "routing table of the kernel cannot be read: " + err.Error(),
+		})
+	} else {
+		findings = append(findings, doctor.CheckGatewayReachable(status, table, internalutils.IsNeighborResolved))
+		findings = append(findings, doctor.CheckKernelRoutes(routes, table)...)
+		findings = append(findings, doctor.CheckShadowedRoutes(routes, table)...)
+	}
+
+	return append(findings, doctor.CheckDNSServers(status.DNSServers, routes, internalutils.QueryDNSServer)...)
+}
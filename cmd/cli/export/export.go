@@ -0,0 +1,81 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+var (
+	// format is the format of the exported list
+	format string
+	// file is the path of the file that the list is written to, stdout if it is empty
+	file string
+)
+
+func init() {
+	ExportCmd.Flags().StringVarP(&format, "format", "", "json", "format of the list, one of json, text and csv")
+	ExportCmd.Flags().StringVarP(&file, "file", "", "", "write the list to the given file instead of stdout")
+}
+
+// ExportCmd represents the export command
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "write the routed destinations as a list that stt-cli import reads, the output format flag does not apply",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Str("format", format).
+			Msg(constants.ProcessCommand)
+
+		listFormat, err := utils.ListFormat(format)
+		if err != nil {
+			return err
+		}
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		content, err := cl.Export(ctx, listFormat)
+		if err != nil {
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			if rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 21}
+			}
+
+			return &utils.CommandError{Err: rpcErr, Code: 20}
+		}
+
+		logger.Info().Int("bytes", len(content)).Msg(constants.SuccessfullyProcessed)
+
+		if file == "" {
+			_, err = fmt.Fprint(cmd.OutOrStdout(), content)
+			return err
+		}
+
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			return errors.Wrapf(err, "failed to write list to %s", file)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,102 @@
+package get
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// GetCmd represents the get command
+var GetCmd = &cobra.Command{
+	Use:   "get <destination>",
+	Short: "show a single destination with the statuses, the errors and the resolvers of the routes of its IPs",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Any("args", args).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		route, err := cl.Get(ctx, args[0])
+		if err != nil {
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("domain", args[0]).Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			if rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 15}
+			}
+
+			return &utils.CommandError{Err: rpcErr, Code: 14}
+		}
+
+		logger.Info().Str("domain", args[0]).Msg(constants.SuccessfullyProcessed)
+
+		details := &utils.Table{
+			Columns:  []utils.Column{{Key: "field", Header: "Field"}, {Key: "value", Header: "Value"}},
+			Headless: true,
+			Rows: [][]string{
+				{"Domain", route.Destination},
+				{"Gateway", route.Gateway},
+				{"Group", route.Group},
+				{"Active", strconv.FormatBool(route.Active)},
+				{"Source", route.Source},
+				{"Accumulate", strconv.FormatBool(route.Accumulate)},
+				{"Expires In", utils.Remaining(route)},
+				{"Tags", strings.Join(route.Tags, ", ")},
+				{"Comment", route.Comment},
+				{"Routed IPs", strings.Join(route.RoutedIPs, "\n")},
+			},
+		}
+
+		ips := &utils.Table{Columns: []utils.Column{
+			{Key: "ip", Header: "IP"},
+			{Key: "status", Header: "Status"},
+			{Key: "attempts", Header: "Attempts"},
+			{Key: "first-seen", Header: "First Seen"},
+			{Key: "last-seen", Header: "Last Seen"},
+			{Key: "resolvers", Header: "Resolvers"},
+			{Key: "error", Header: "Error"},
+		}}
+
+		for _, ip := range route.IPs {
+			ips.Rows = append(ips.Rows, []string{
+				ip.IP,
+				string(ip.Status),
+				strconv.Itoa(ip.Attempts),
+				ip.FirstSeen.Local().Format(time.RFC3339),
+				ip.LastSeen.Local().Format(time.RFC3339),
+				strings.Join(ip.Resolvers, "\n"),
+				ip.LastError,
+			})
+		}
+
+		// plain output is the IPs only, so that they can be piped line by line
+		if utils.OutputFormat(cmd) == utils.FormatPlain {
+			return utils.Render(cmd, nil, ips)
+		}
+
+		if err := utils.Render(cmd, utils.NewRouteOutput(route), details, ips); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,188 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+func init() {
+	GroupCmd.AddCommand(createCmd)
+	GroupCmd.AddCommand(addCmd)
+	GroupCmd.AddCommand(enableCmd)
+	GroupCmd.AddCommand(disableCmd)
+	GroupCmd.AddCommand(listCmd)
+}
+
+// GroupCmd represents the group command
+var GroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "manage named groups of domains that can be enabled and disabled together",
+}
+
+var createCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create a new enabled group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return change(cmd, args, fmt.Sprintf("created group %s", args[0]), func(ctx context.Context, cl *client.Client) error {
+			return cl.CreateGroup(ctx, args[0])
+		})
+	},
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <name> <domain>...",
+	Short: "add domains to the group, existing entries are moved into the group",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		message := fmt.Sprintf("added %d destination(s) to group %s", len(args[1:]), args[0])
+		return change(cmd, args, message, func(ctx context.Context, cl *client.Client) error {
+			return cl.AddToGroup(ctx, args[0], args[1:]...)
+		})
+	},
+}
+
+var enableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "install the routes of all the domains in the group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return change(cmd, args, fmt.Sprintf("enabled group %s", args[0]), func(ctx context.Context, cl *client.Client) error {
+			return cl.EnableGroup(ctx, args[0])
+		})
+	},
+}
+
+var disableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "remove the routes of all the domains in the group while keeping them in the state",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return change(cmd, args, fmt.Sprintf("disabled group %s", args[0]), func(ctx context.Context, cl *client.Client) error {
+			return cl.DisableGroup(ctx, args[0])
+		})
+	},
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the groups and their domains",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var groups []*client.Group
+		if err := call(cmd, args, func(ctx context.Context, cl *client.Client) (err error) {
+			groups, err = cl.ListGroups(ctx)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		doc := GroupsOutput{Groups: make([]GroupOutput, 0, len(groups))}
+		table := &utils.Table{Columns: []utils.Column{
+			{Key: "group", Header: "Group"},
+			{Key: "enabled", Header: "Enabled"},
+			{Key: "domains", Header: "Domains"},
+		}}
+
+		for _, group := range groups {
+			destinations := group.Destinations
+			if destinations == nil {
+				destinations = []string{}
+			}
+
+			doc.Groups = append(doc.Groups, GroupOutput{Name: group.Name, Enabled: group.Enabled, Destinations: destinations})
+			table.Rows = append(table.Rows, []string{group.Name, strconv.FormatBool(group.Enabled), strings.Join(group.Destinations, "\n")})
+		}
+
+		if err := utils.Render(cmd, doc, table); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
+
+// GroupsOutput is the stable schema of the group list command in the structured output formats
+type GroupsOutput struct {
+	Groups []GroupOutput `json:"groups"`
+}
+
+// GroupOutput is the stable schema of a group in the structured output formats
+type GroupOutput struct {
+	Name         string   `json:"name"`
+	Enabled      bool     `json:"enabled"`
+	Destinations []string `json:"destinations"`
+}
+
+// ChangeOutput is the stable schema of the commands that change a group in the structured output formats
+type ChangeOutput struct {
+	Group     string `json:"group"`
+	Operation string `json:"operation"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// change calls the daemon with fn like call, and renders the given message once it succeeds
+func change(cmd *cobra.Command, args []string, message string, fn func(ctx context.Context, cl *client.Client) error) error {
+	if err := call(cmd, args, fn); err != nil {
+		return err
+	}
+
+	doc := ChangeOutput{Group: args[0], Operation: cmd.Name(), Success: true, Message: message}
+	if utils.OutputFormat(cmd).Structured() {
+		return utils.Render(cmd, doc)
+	}
+
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), message)
+
+	return err
+}
+
+// call connects to the daemon, calls it with fn and logs the result
+func call(cmd *cobra.Command, args []string, fn func(ctx context.Context, cl *client.Client) error) error {
+	logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+	operation := cmd.Parent().Name() + " " + cmd.Name()
+
+	logger.Info().
+		Str("operation", operation).
+		Any("args", args).
+		Msg(constants.ProcessCommand)
+
+	cl, err := utils.DialDaemon(cmd)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := fn(ctx, cl); err != nil {
+		rpcErr := utils.DecodeError(err)
+		logger.Error().
+			Str("operation", operation).
+			Str("code", string(rpcErr.Code)).
+			Err(err).
+			Msg(constants.FailedToProcessCommand)
+
+		if rpcErr.Business() {
+			return &utils.CommandError{Err: rpcErr, Code: 15}
+		}
+
+		return &utils.CommandError{Err: rpcErr, Code: 14}
+	}
+
+	logger.Info().Str("operation", operation).Msg(constants.SuccessfullyProcessed)
+
+	return nil
+}
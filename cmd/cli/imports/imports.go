@@ -0,0 +1,211 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+var (
+	// format is the format of the list, it is detected from the file name and the content if it is empty
+	format string
+	// mode is merge or replace
+	mode string
+	// dryRun reports the actions without taking them
+	dryRun bool
+)
+
+// modes are the import modes of the mode flag, whether they replace the routed destinations
+var modes = map[string]bool{
+	"merge":   false,
+	"replace": true,
+}
+
+func init() {
+	ImportCmd.Flags().StringVarP(&format, "format", "", "", "format of the list, one of json, text, csv and hosts, detected from the file name and the content if not set")
+	ImportCmd.Flags().StringVarP(&mode, "mode", "", "merge", "merge adds the destinations that are not routed yet, replace also removes the ones that are not in the list except for the ones of the config file")
+	ImportCmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "report what would be added and removed without changing anything")
+}
+
+// ImportOutput is the stable schema of the import command in the structured output formats
+type ImportOutput struct {
+	Format string `json:"format"`
+	DryRun bool   `json:"dryRun"`
+	// Summary is the number of the lines per action, such as "2 add, 1 duplicate"
+	Summary string                `json:"summary"`
+	Routes  []ImportedRouteOutput `json:"routes"`
+}
+
+// ImportedRouteOutput is the stable schema of the result of a single destination in the structured output formats
+type ImportedRouteOutput struct {
+	// Line is zero for the removed destinations, since they are not in the list
+	Line        int      `json:"line"`
+	Destination string   `json:"destination"`
+	Action      string   `json:"action"`
+	IPs         []string `json:"ips"`
+	Reason      string   `json:"reason,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// readList returns the content of the list in the given file, stdin is read for - and for no file
+func readList(cmd *cobra.Command, args []string) (string, []byte, error) {
+	if len(args) == 0 || args[0] == "-" {
+		content, err := io.ReadAll(cmd.InOrStdin())
+		return "-", content, errors.Wrap(err, "failed to read list from stdin")
+	}
+
+	content, err := os.ReadFile(args[0])
+	return args[0], content, errors.Wrapf(err, "failed to read list from %s", args[0])
+}
+
+// ImportCmd represents the import command
+var ImportCmd = &cobra.Command{
+	Use:   "import [file|-]",
+	Short: "route the destinations of a list such as the output of stt-cli export, a text file with one destination per line, a CSV or a hosts file",
+	Args:  cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if _, ok := modes[mode]; !ok {
+			return utils.ErrImportMode
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Any("args", args).
+			Msg(constants.ProcessCommand)
+
+		name, content, err := readList(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		// the file name is known only here, so the format is detected before the list is sent
+		if format == "" {
+			format = string(bypasslist.Detect(name, content))
+		}
+
+		listFormat, err := utils.ListFormat(format)
+		if err != nil {
+			return err
+		}
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		// every new destination is resolved, so the lists get more time than a single destination
+		ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+		defer cancel()
+
+		imported, err := cl.Import(ctx, string(content), client.ImportOptions{Format: listFormat, Replace: modes[mode], DryRun: dryRun})
+		if err != nil {
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			if rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 23}
+			}
+
+			return &utils.CommandError{Err: rpcErr, Code: 22}
+		}
+
+		doc := ImportOutput{Format: format, DryRun: dryRun, Routes: make([]ImportedRouteOutput, 0, len(imported))}
+		table := &utils.Table{Columns: []utils.Column{
+			{Key: "line", Header: "Line"},
+			{Key: "destination", Header: "Domain"},
+			{Key: "action", Header: "Action"},
+			{Key: "ips", Header: "IPs"},
+			{Key: "reason", Header: "Reason"},
+		}}
+
+		var actions []string
+		counts := make(map[string]int)
+		var failed int
+		for _, route := range imported {
+			out := ImportedRouteOutput{
+				Line:        route.Line,
+				Destination: route.Destination,
+				Action:      string(route.Action),
+				IPs:         append([]string{}, route.IPs...),
+				Reason:      route.Reason,
+			}
+
+			if route.Err != nil {
+				out.Error = route.Err.Message
+			}
+			doc.Routes = append(doc.Routes, out)
+
+			if counts[out.Action] == 0 {
+				actions = append(actions, out.Action)
+			}
+			counts[out.Action]++
+
+			if out.Error != "" || route.Action == client.ImportActionInvalid {
+				failed++
+			}
+
+			line := "-"
+			if out.Line > 0 {
+				line = strconv.Itoa(out.Line)
+			}
+
+			reason := out.Reason
+			if out.Error != "" {
+				reason = out.Error
+			}
+
+			table.Rows = append(table.Rows, []string{line, out.Destination, out.Action, strings.Join(out.IPs, "\n"), reason})
+		}
+
+		summary := make([]string, 0, len(actions))
+		for _, a := range actions {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[a], a))
+		}
+
+		doc.Summary = strings.Join(summary, ", ")
+		if doc.Summary == "" {
+			doc.Summary = "no destinations"
+		}
+
+		if doc.DryRun {
+			doc.Summary = "dry run, nothing is changed: " + doc.Summary
+		}
+
+		logger.Info().Str("response", doc.Summary).Msg(constants.SuccessfullyProcessed)
+
+		outputFormat := utils.OutputFormat(cmd)
+		if err := utils.Render(cmd, doc, table); err != nil {
+			return err
+		}
+
+		if outputFormat == utils.FormatTable || outputFormat == utils.FormatWide {
+			fmt.Fprintln(cmd.OutOrStdout(), doc.Summary)
+		}
+
+		// the invalid lines fail the import even on a dry run, so that the lists can be checked before they are used
+		if failed > 0 {
+			return &utils.CommandError{Err: errors.Errorf("%d of %d lines failed", failed, len(doc.Routes)), Code: utils.ItemsFailedCode}
+		}
+
+		return nil
+	},
+}
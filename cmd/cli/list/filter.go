@@ -0,0 +1,108 @@
+package list
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// filter is a key=value filter of the routes
+type filter struct {
+	key   string
+	value string
+}
+
+// filterKeys match the routes with the values of the filters of their keys
+var filterKeys = map[string]func(route *client.Route, value string) bool{
+	"destination": func(route *client.Route, value string) bool {
+		matched, _ := path.Match(value, route.Destination)
+		return matched
+	},
+	"gateway": func(route *client.Route, value string) bool { return route.Gateway == value },
+	"group":   func(route *client.Route, value string) bool { return route.Group == value },
+	"source":  func(route *client.Route, value string) bool { return route.Source == value },
+	"active": func(route *client.Route, value string) bool {
+		active, _ := strconv.ParseBool(value)
+		return route.Active == active
+	},
+	"status": func(route *client.Route, value string) bool {
+		for _, ip := range route.IPs {
+			if string(ip.Status) == value {
+				return true
+			}
+		}
+
+		return false
+	},
+}
+
+// parseFilters parses the given key=value filters
+func parseFilters(args []string) ([]filter, error) {
+	parsed := make([]filter, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid filter %q, key=value is expected", arg)
+		}
+
+		if _, ok := filterKeys[key]; !ok {
+			return nil, errors.Errorf("unknown filter key %q, one of destination, gateway, group, source, active and status is expected", key)
+		}
+
+		if _, err := path.Match(value, ""); key == "destination" && err != nil {
+			return nil, errors.Wrapf(err, "invalid destination pattern %q", value)
+		}
+
+		if _, err := strconv.ParseBool(value); key == "active" && err != nil {
+			return nil, errors.Errorf("invalid value %q of active, true or false is expected", value)
+		}
+
+		parsed = append(parsed, filter{key: key, value: value})
+	}
+
+	return parsed, nil
+}
+
+// matchesAll returns true if the given route matches all the given filters
+func matchesAll(route *client.Route, filters []filter) bool {
+	for _, f := range filters {
+		if !filterKeys[f.key](route, f.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortKeys return the values of the routes to sort them by
+var sortKeys = map[string]func(route *client.Route) string{
+	"destination": func(route *client.Route) string { return route.Destination },
+	"gateway":     func(route *client.Route) string { return route.Gateway },
+	"group":       func(route *client.Route) string { return route.Group },
+}
+
+// sortRoutes sorts the given routes by the given key in place, the ones with equal keys keep their order. The routes
+// are sorted by the time left until their expiry for expires, the permanent ones are the last
+func sortRoutes(routes []*client.Route, key string) {
+	if key == "expires" {
+		sort.SliceStable(routes, func(i, j int) bool {
+			left, right := routes[i].ExpiresAt, routes[j].ExpiresAt
+			if left == nil || right == nil {
+				return left != nil
+			}
+
+			return left.Before(*right)
+		})
+
+		return
+	}
+
+	if value, ok := sortKeys[key]; ok {
+		sort.SliceStable(routes, func(i, j int) bool { return value(routes[i]) < value(routes[j]) })
+	}
+}
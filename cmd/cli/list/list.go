@@ -1,18 +1,55 @@
 package list
 
 import (
-	"os"
+	"context"
+	"strconv"
 	"strings"
-
-	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
-	"github.com/olekukonko/tablewriter"
+	"time"
 
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// sortBy is the key to sort the routes by, the routes are listed in the order of the daemon if it is empty
+	sortBy string
+	// filterArgs are the key=value filters of the routes
+	filterArgs []string
+	// filters are the parsed filterArgs
+	filters []filter
+	// selected are the keys of the columns to render, all the columns of the output format are rendered if it is empty
+	selected []string
+)
+
+// columns are the columns of the table of the routes, the wide ones are rendered with --output wide
+var columns = []utils.Column{
+	{Key: "destination", Header: "Domain"},
+	{Key: "gateway", Header: "Gateway"},
+	{Key: "ips", Header: "IPs"},
+	{Key: "group", Header: "Group"},
+	{Key: "expires", Header: "Expires In"},
+	{Key: "errors", Header: "Errors"},
+	{Key: "source", Header: "Source", Wide: true},
+	{Key: "accumulate", Header: "Accumulate", Wide: true},
+	{Key: "routed", Header: "Routed IPs", Wide: true},
+	{Key: "tags", Header: "Tags", Wide: true},
+}
+
+func init() {
+	ListCmd.Flags().StringVarP(&sortBy, "sort", "", "", "sort the routes by destination, gateway, group or expires")
+	ListCmd.Flags().StringArrayVarP(&filterArgs, "filter", "f", nil, "list only the routes that match the given key=value, one of destination (glob), gateway, group, source, active and status (of any IP), can be repeated")
+	ListCmd.Flags().StringSliceVarP(&selected, "columns", "c", nil, "comma-separated columns of the table in the given order, one or more of destination, gateway, ips, group, expires, errors, source, accumulate, routed and tags")
+}
+
+// RoutesOutput is the stable schema of the list command in the structured output formats
+type RoutesOutput struct {
+	Routes []utils.RouteOutput `json:"routes"`
+}
+
 // ListCmd represents the list command
 var ListCmd = &cobra.Command{
 	Use:   "list",
@@ -28,46 +65,100 @@ to quickly create a Cobra application.`,
 			return utils.ErrTooManyArgs
 		}
 
+		if _, ok := sortKeys[sortBy]; !ok && sortBy != "" && sortBy != "expires" {
+			return errors.Errorf("unknown sort key %q, one of destination, gateway, group and expires is expected", sortBy)
+		}
+
+		var err error
+		if filters, err = parseFilters(filterArgs); err != nil {
+			return err
+		}
+
+		// columns are validated before calling the daemon
+		if len(selected) > 0 {
+			return (&utils.Table{Columns: columns}).Select(selected)
+		}
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
-		socketPath := cmd.Context().Value(constants.SocketPathKey{}).(string)
 
 		logger.Info().
 			Str("operation", cmd.Name()).
 			Msg(constants.ProcessCommand)
 
-		res, err := utils.SendCommandToDaemon(socketPath, cmd.Name())
+		cl, err := utils.DialDaemon(cmd)
 		if err != nil {
-			logger.Error().Str("command", cmd.Name()).Err(err).Msg(constants.FailedToProcessCommand)
-
-			return &utils.CommandError{Err: err, Code: 10}
+			return err
 		}
+		defer cl.Close()
 
-		logger.Info().Str("command", cmd.Name()).Msg(constants.SuccessfullyProcessed)
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
 
-		domains, err := state.FromStringSlice(res)
+		routes, err := cl.List(ctx)
 		if err != nil {
-			logger.Error().Err(err).Msg("failed to parse response")
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("command", cmd.Name()).Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			if rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 11}
+			}
 
-			return &utils.CommandError{Err: err, Code: 11}
+			return &utils.CommandError{Err: rpcErr, Code: 10}
 		}
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Domain", "Gateway", "IPs"})
-		// Set the Alignment for each column to center
-		table.SetColumnAlignment([]int{tablewriter.ALIGN_CENTER, tablewriter.ALIGN_CENTER, tablewriter.ALIGN_CENTER})
-		table.SetBorder(true)  // Set to false if you do not want borders
-		table.SetRowLine(true) // Enable row line for more clarity
-		table.SetAlignment(tablewriter.ALIGN_CENTER)
+		logger.Info().Str("command", cmd.Name()).Msg(constants.SuccessfullyProcessed)
 
-		for _, info := range domains {
-			table.Append([]string{info.Domain, info.Gateway, strings.Join(info.ResolvedIPs, "\n")})
+		filtered := make([]*client.Route, 0, len(routes))
+		for _, route := range routes {
+			if matchesAll(route, filters) {
+				filtered = append(filtered, route)
+			}
 		}
 
-		table.Render() // Send output
+		sortRoutes(filtered, sortBy)
+
+		doc := RoutesOutput{Routes: make([]utils.RouteOutput, 0, len(filtered))}
+		table := &utils.Table{Columns: columns}
+		for _, route := range filtered {
+			doc.Routes = append(doc.Routes, utils.NewRouteOutput(route))
+
+			ips, errs := utils.RouteIPs(route)
+			table.Rows = append(table.Rows, []string{
+				route.Destination,
+				route.Gateway,
+				strings.Join(ips, "\n"),
+				group(route),
+				utils.Remaining(route),
+				strings.Join(errs, "\n"),
+				route.Source,
+				strconv.FormatBool(route.Accumulate),
+				strings.Join(route.RoutedIPs, "\n"),
+				strings.Join(route.Tags, ", "),
+			})
+		}
+
+		if len(selected) > 0 {
+			if err := table.Select(selected); err != nil {
+				return err
+			}
+		}
+
+		if err := utils.Render(cmd, doc, table); err != nil {
+			return err
+		}
 
 		return nil
 	},
 }
+
+// group returns the group of the given route, marked if the group is disabled
+func group(route *client.Route) string {
+	if route.Group != "" && !route.Active {
+		return route.Group + " (disabled)"
+	}
+
+	return route.Group
+}
@@ -0,0 +1,216 @@
+package preset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+func init() {
+	PresetCmd.AddCommand(listCmd)
+	PresetCmd.AddCommand(enableCmd)
+	PresetCmd.AddCommand(disableCmd)
+}
+
+// PresetCmd represents the preset command
+var PresetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "manage the built-in presets of the domains and CIDRs that common services such as zoom and slack need",
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the presets of the catalog and whether they are enabled",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var catalog *client.PresetCatalog
+		if err := call(cmd, args, func(ctx context.Context, cl *client.Client) (err error) {
+			catalog, err = cl.ListPresets(ctx)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		doc := PresetsOutput{CatalogVersion: catalog.Version, Presets: make([]PresetOutput, 0, len(catalog.Presets))}
+		table := &utils.Table{Columns: []utils.Column{
+			{Key: "preset", Header: "Preset"},
+			{Key: "enabled", Header: "Enabled"},
+			{Key: "description", Header: "Description"},
+			{Key: "overridden", Header: "Overridden", Wide: true},
+			{Key: "destinations", Header: "Destinations", Wide: true},
+		}}
+
+		for _, p := range catalog.Presets {
+			doc.Presets = append(doc.Presets, PresetOutput{
+				Name:         p.Name,
+				Description:  p.Description,
+				Enabled:      p.Enabled,
+				Overridden:   p.Overridden,
+				Destinations: nonNil(p.Destinations),
+			})
+			table.Rows = append(table.Rows, []string{
+				p.Name,
+				strconv.FormatBool(p.Enabled),
+				p.Description,
+				strconv.FormatBool(p.Overridden),
+				strings.Join(p.Destinations, "\n"),
+			})
+		}
+
+		return utils.Render(cmd, doc, table)
+	},
+}
+
+var enableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "add the destinations of the preset to its own group, an enabled preset is synced to the catalog again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var change *client.PresetChange
+		if err := call(cmd, args, func(ctx context.Context, cl *client.Client) (err error) {
+			change, err = cl.EnablePreset(ctx, args[0])
+			return err
+		}); err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("enabled preset %s, added %d and removed %d destination(s)", args[0], len(change.Added), len(change.Removed))
+		if len(change.Unresolved) > 0 {
+			message += fmt.Sprintf(", %d destination(s) could not be resolved yet", len(change.Unresolved))
+		}
+
+		doc := ChangeOutput{
+			Preset:     args[0],
+			Operation:  cmd.Name(),
+			Success:    true,
+			Message:    message,
+			Added:      nonNil(change.Added),
+			Removed:    nonNil(change.Removed),
+			Unresolved: nonNil(change.Unresolved),
+		}
+
+		return render(cmd, doc)
+	},
+}
+
+var disableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "remove the destinations of the preset together with its group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var change *client.PresetChange
+		if err := call(cmd, args, func(ctx context.Context, cl *client.Client) (err error) {
+			change, err = cl.DisablePreset(ctx, args[0])
+			return err
+		}); err != nil {
+			return err
+		}
+
+		doc := ChangeOutput{
+			Preset:     args[0],
+			Operation:  cmd.Name(),
+			Success:    true,
+			Message:    fmt.Sprintf("disabled preset %s, removed %d destination(s)", args[0], len(change.Removed)),
+			Added:      []string{},
+			Removed:    nonNil(change.Removed),
+			Unresolved: []string{},
+		}
+
+		return render(cmd, doc)
+	},
+}
+
+// PresetsOutput is the stable schema of the preset list command in the structured output formats
+type PresetsOutput struct {
+	CatalogVersion int            `json:"catalogVersion"`
+	Presets        []PresetOutput `json:"presets"`
+}
+
+// PresetOutput is the stable schema of a preset in the structured output formats
+type PresetOutput struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Enabled      bool     `json:"enabled"`
+	Overridden   bool     `json:"overridden"`
+	Destinations []string `json:"destinations"`
+}
+
+// ChangeOutput is the stable schema of the commands that enable or disable a preset in the structured output formats
+type ChangeOutput struct {
+	Preset     string   `json:"preset"`
+	Operation  string   `json:"operation"`
+	Success    bool     `json:"success"`
+	Message    string   `json:"message"`
+	Added      []string `json:"added"`
+	Removed    []string `json:"removed"`
+	Unresolved []string `json:"unresolved"`
+}
+
+// render writes the given ChangeOutput in the structured output formats, and its message otherwise
+func render(cmd *cobra.Command, doc ChangeOutput) error {
+	if utils.OutputFormat(cmd).Structured() {
+		return utils.Render(cmd, doc)
+	}
+
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), doc.Message)
+
+	return err
+}
+
+// nonNil returns an empty slice instead of nil, so that the structured outputs have arrays rather than nulls
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// call connects to the daemon, calls it with fn and logs the result
+func call(cmd *cobra.Command, args []string, fn func(ctx context.Context, cl *client.Client) error) error {
+	logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+	operation := cmd.Parent().Name() + " " + cmd.Name()
+
+	logger.Info().
+		Str("operation", operation).
+		Any("args", args).
+		Msg(constants.ProcessCommand)
+
+	cl, err := utils.DialDaemon(cmd)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// enabling a preset resolves all of its destinations
+	ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
+	defer cancel()
+
+	if err := fn(ctx, cl); err != nil {
+		rpcErr := utils.DecodeError(err)
+		logger.Error().
+			Str("operation", operation).
+			Str("code", string(rpcErr.Code)).
+			Err(err).
+			Msg(constants.FailedToProcessCommand)
+
+		if rpcErr.Business() {
+			return &utils.CommandError{Err: rpcErr, Code: 25}
+		}
+
+		return &utils.CommandError{Err: rpcErr, Code: 24}
+	}
+
+	logger.Info().Str("operation", operation).Msg(constants.SuccessfullyProcessed)
+
+	return nil
+}
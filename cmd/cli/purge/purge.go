@@ -1,9 +1,14 @@
 package purge
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/rs/zerolog"
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
 
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
 	"github.com/spf13/cobra"
@@ -30,24 +35,49 @@ to quickly create a Cobra application.`,
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
-		socketPath := cmd.Context().Value(constants.SocketPathKey{}).(string)
 
 		logger.Info().
 			Str("operation", cmd.Name()).
 			Msg(constants.ProcessCommand)
 
-		res, err := utils.SendCommandToDaemon(socketPath, cmd.Name())
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+
+		purged, err := cl.Purge(ctx)
 		if err != nil {
-			logger.Error().Str("command", cmd.Name()).Err(err).Msg(constants.FailedToProcessCommand)
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("command", cmd.Name()).Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
 
-			return &utils.CommandError{Err: err, Code: 12}
+			return &utils.CommandError{Err: rpcErr, Code: 12}
 		}
 
+		// structured formats render the empty results, so that the scripts always get the same schema
+		format := utils.OutputFormat(cmd)
+		if len(purged) == 0 && !format.Structured() {
+			if format != utils.FormatPlain {
+				fmt.Fprintln(cmd.OutOrStdout(), constants.NoRoutesToPurge)
+			}
+
+			return nil
+		}
+
+		items := make([]*ipc.ItemResult, 0, len(purged))
+		for _, route := range purged {
+			items = append(items, utils.NewItemResult(route.Destination, ipc.StatusRemoved, route.IPs, (*utils.RPCError)(route.Err)))
+		}
+
+		res := ipc.NewItemsResponse(items)
 		logger.Info().
 			Str("command", cmd.Name()).
-			Str("response", res).
+			Str("response", res.Response).
 			Msg(constants.SuccessfullyProcessed)
 
-		return nil
+		return utils.RenderResults(cmd, res)
 	},
 }
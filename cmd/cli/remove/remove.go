@@ -1,11 +1,14 @@
 package remove
 
 import (
-	"fmt"
+	"context"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
 
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
 	"github.com/spf13/cobra"
@@ -30,31 +33,40 @@ to quickly create a Cobra application.`,
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
-		socketPath := cmd.Context().Value(constants.SocketPathKey{}).(string)
 
 		logger.Info().
 			Str("operation", cmd.Name()).
 			Any("args", args).
 			Msg(constants.ProcessCommand)
 
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		items := make([]*ipc.ItemResult, 0, len(args))
 		for _, arg := range args {
-			req := fmt.Sprintf("%s %s", cmd.Name(), arg)
-			res, err := utils.SendCommandToDaemon(socketPath, req)
-			if err != nil {
-				logger.Error().
-					Str("command", req).
-					Err(err).
-					Msg(constants.FailedToProcessCommand)
-
-				continue
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			ips, err := cl.Remove(ctx, arg)
+			cancel()
+
+			// business errors are reported per destination, the others mean that the daemon cannot be reached
+			rpcErr := utils.DecodeError(err)
+			if rpcErr != nil && !rpcErr.Business() {
+				logger.Error().Str("domain", arg).Err(err).Msg(constants.FailedToProcessCommand)
+
+				return &utils.CommandError{Err: errors.Wrap(rpcErr, constants.FailedToConnectToDaemon), Code: utils.ConnectionFailedCode}
 			}
 
-			logger.Info().
-				Str("command", req).
-				Str("response", res).
-				Msg(constants.SuccessfullyProcessed)
+			items = append(items, utils.NewItemResult(arg, ipc.StatusRemoved, ips, rpcErr))
 		}
 
-		return nil
+		res := ipc.NewItemsResponse(items)
+		logger.Info().
+			Str("response", res.Response).
+			Msg(constants.SuccessfullyProcessed)
+
+		return utils.RenderResults(cmd, res)
 	},
 }
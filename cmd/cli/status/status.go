@@ -0,0 +1,184 @@
+package status
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// StatusOutput is the stable schema of the status command in the structured output formats
+type StatusOutput struct {
+	Version          string     `json:"version"`
+	GitCommit        string     `json:"gitCommit"`
+	StartedAt        time.Time  `json:"startedAt"`
+	UptimeSeconds    int64      `json:"uptimeSeconds"`
+	Gateway          string     `json:"gateway"`
+	GatewayInterface string     `json:"gatewayInterface"`
+	Backend          string     `json:"backend"`
+	RouteMode        string     `json:"routeMode"`
+	Routes           int        `json:"routes"`
+	ActiveRoutes     int        `json:"activeRoutes"`
+	TemporaryRoutes  int        `json:"temporaryRoutes"`
+	Groups           int        `json:"groups"`
+	FailedIPs        int        `json:"failedIps"`
+	LastRefresh      *time.Time `json:"lastRefresh"`
+	DNSServers       []string   `json:"dnsServers"`
+	// Subscriptions are the outcomes of the last syncs of the subscribed lists
+	Subscriptions []SubscriptionOutput `json:"subscriptions"`
+}
+
+// SubscriptionOutput is the outcome of the last sync of a subscribed list
+type SubscriptionOutput struct {
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`
+	Group        string     `json:"group"`
+	Status       string     `json:"status"`
+	LastSync     *time.Time `json:"lastSync"`
+	LastSuccess  *time.Time `json:"lastSuccess"`
+	LastError    string     `json:"lastError,omitempty"`
+	Destinations int        `json:"destinations"`
+	Invalid      int        `json:"invalid"`
+}
+
+// StatusCmd represents the status command
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the version and the uptime of the daemon, the detected gateway, the backend, the counts of the routes and the syncs of the subscriptions",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		status, err := cl.Status(ctx)
+		if err != nil {
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("command", cmd.Name()).Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			return &utils.CommandError{Err: rpcErr, Code: utils.ConnectionFailedCode}
+		}
+
+		logger.Info().Str("command", cmd.Name()).Msg(constants.SuccessfullyProcessed)
+
+		doc := StatusOutput{
+			Version:          status.Version,
+			GitCommit:        status.GitCommit,
+			StartedAt:        status.StartedAt,
+			UptimeSeconds:    int64(status.Uptime.Seconds()),
+			Gateway:          status.Gateway,
+			GatewayInterface: status.GatewayInterface,
+			Backend:          status.Backend,
+			RouteMode:        status.RouteMode,
+			Routes:           status.Routes,
+			ActiveRoutes:     status.ActiveRoutes,
+			TemporaryRoutes:  status.TemporaryRoutes,
+			Groups:           status.Groups,
+			FailedIPs:        status.FailedIPs,
+			LastRefresh:      status.LastRefresh,
+			DNSServers:       status.DNSServers,
+			Subscriptions:    make([]SubscriptionOutput, 0, len(status.Subscriptions)),
+		}
+
+		if doc.DNSServers == nil {
+			doc.DNSServers = []string{}
+		}
+
+		gateway := status.Gateway
+		switch {
+		case gateway == "":
+			gateway = "not detected"
+		case status.GatewayInterface != "":
+			gateway += " dev " + status.GatewayInterface
+		}
+
+		lastRefresh := "never"
+		if status.LastRefresh != nil {
+			lastRefresh = time.Since(*status.LastRefresh).Round(time.Second).String() + " ago"
+		}
+
+		table := &utils.Table{
+			Columns:  []utils.Column{{Key: "field", Header: "Field"}, {Key: "value", Header: "Value"}},
+			Headless: true,
+			Rows: [][]string{
+				{"Version", status.Version},
+				{"Git Commit", status.GitCommit},
+				{"Started At", status.StartedAt.Local().Format(time.RFC3339)},
+				{"Uptime", status.Uptime.Round(time.Second).String()},
+				{"Gateway", gateway},
+				{"Backend", status.Backend},
+				{"Route Mode", status.RouteMode},
+				{"Routes", strconv.Itoa(status.Routes) + " (" + strconv.Itoa(status.ActiveRoutes) + " active, " + strconv.Itoa(status.TemporaryRoutes) + " temporary)"},
+				{"Groups", strconv.Itoa(status.Groups)},
+				{"Failed IPs", strconv.Itoa(status.FailedIPs)},
+				{"Last Refresh", lastRefresh},
+				{"DNS Servers", strings.Join(status.DNSServers, "\n")},
+			},
+		}
+
+		tables := []*utils.Table{table}
+		if len(status.Subscriptions) > 0 {
+			subscriptions := &utils.Table{Columns: []utils.Column{
+				{Key: "name", Header: "Subscription"},
+				{Key: "group", Header: "Group"},
+				{Key: "status", Header: "Status"},
+				{Key: "destinations", Header: "Destinations"},
+				{Key: "invalid", Header: "Invalid", Wide: true},
+				{Key: "last-sync", Header: "Last Sync"},
+				{Key: "url", Header: "URL", Wide: true},
+				{Key: "error", Header: "Error"},
+			}}
+
+			for _, sub := range status.Subscriptions {
+				doc.Subscriptions = append(doc.Subscriptions, SubscriptionOutput{
+					Name:         sub.Name,
+					URL:          sub.URL,
+					Group:        sub.Group,
+					Status:       string(sub.Status),
+					LastSync:     sub.LastSync,
+					LastSuccess:  sub.LastSuccess,
+					LastError:    sub.LastError,
+					Destinations: sub.Destinations,
+					Invalid:      sub.Invalid,
+				})
+
+				lastSync := "never"
+				if sub.LastSync != nil {
+					lastSync = time.Since(*sub.LastSync).Round(time.Second).String() + " ago"
+				}
+
+				subscriptions.Rows = append(subscriptions.Rows, []string{
+					sub.Name,
+					sub.Group,
+					string(sub.Status),
+					strconv.Itoa(sub.Destinations),
+					strconv.Itoa(sub.Invalid),
+					lastSync,
+					sub.URL,
+					sub.LastError,
+				})
+			}
+
+			tables = append(tables, subscriptions)
+		}
+
+		return utils.Render(cmd, doc, tables...)
+	},
+}
@@ -0,0 +1,152 @@
+package trace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// TraceOutput is the stable schema of the trace command in the structured output formats
+type TraceOutput struct {
+	Host string `json:"host"`
+	// Route is the destination that covers the host, null if none does
+	Route   *utils.RouteOutput `json:"route"`
+	Gateway string             `json:"gateway"`
+	IPs     []TracedIPOutput   `json:"ips"`
+}
+
+// TracedIPOutput is the stable schema of the path of a single IP in the structured output formats
+type TracedIPOutput struct {
+	IP          string   `json:"ip"`
+	Path        string   `json:"path"`
+	Routed      bool     `json:"routed"`
+	Interface   string   `json:"interface"`
+	Gateway     string   `json:"gateway"`
+	Source      string   `json:"source"`
+	Table       string   `json:"table"`
+	Rule        string   `json:"rule"`
+	KernelRoute string   `json:"kernelRoute"`
+	Resolvers   []string `json:"resolvers"`
+	Reason      string   `json:"reason"`
+}
+
+// TraceCmd represents the trace command
+var TraceCmd = &cobra.Command{
+	Use:   "trace <host>",
+	Short: "explain whether the traffic to the IPs of a host bypasses VPN, with the routes and the rules that the kernel picks",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Any("args", args).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		trace, err := cl.Trace(ctx, args[0])
+		if err != nil {
+			rpcErr := utils.DecodeError(err)
+			logger.Error().Str("domain", args[0]).Str("code", string(rpcErr.Code)).Err(err).Msg(constants.FailedToProcessCommand)
+
+			if rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 19}
+			}
+
+			return &utils.CommandError{Err: rpcErr, Code: 18}
+		}
+
+		logger.Info().Str("domain", args[0]).Msg(constants.SuccessfullyProcessed)
+
+		doc := TraceOutput{
+			Host:    trace.Host,
+			Gateway: trace.Gateway,
+			IPs:     make([]TracedIPOutput, 0, len(trace.IPs)),
+		}
+
+		destination := "none"
+		if trace.Route != nil {
+			route := utils.NewRouteOutput(trace.Route)
+			doc.Route = &route
+			destination = route.Destination
+		}
+
+		gateway := trace.Gateway
+		if gateway == "" {
+			gateway = "not detected"
+		}
+
+		summary := &utils.Table{
+			Columns:  []utils.Column{{Key: "field", Header: "Field"}, {Key: "value", Header: "Value"}},
+			Headless: true,
+			Rows: [][]string{
+				{"Host", trace.Host},
+				{"Destination", destination},
+				{"Bypass Gateway", gateway},
+			},
+		}
+
+		ips := &utils.Table{Columns: []utils.Column{
+			{Key: "ip", Header: "IP"},
+			{Key: "path", Header: "Path"},
+			{Key: "interface", Header: "Interface"},
+			{Key: "gateway", Header: "Gateway"},
+			{Key: "source", Header: "Source", Wide: true},
+			{Key: "table", Header: "Table", Wide: true},
+			{Key: "rule", Header: "Rule", Wide: true},
+			{Key: "kernel-route", Header: "Kernel Route", Wide: true},
+			{Key: "resolvers", Header: "Resolvers", Wide: true},
+			{Key: "reason", Header: "Reason"},
+		}}
+
+		for _, ip := range trace.IPs {
+			doc.IPs = append(doc.IPs, TracedIPOutput{
+				IP:          ip.IP,
+				Path:        string(ip.Path),
+				Routed:      ip.Routed,
+				Interface:   ip.Interface,
+				Gateway:     ip.Gateway,
+				Source:      ip.Source,
+				Table:       ip.Table,
+				Rule:        ip.Rule,
+				KernelRoute: ip.KernelRoute,
+				Resolvers:   append([]string{}, ip.Resolvers...),
+				Reason:      ip.Reason,
+			})
+
+			ips.Rows = append(ips.Rows, []string{
+				ip.IP,
+				string(ip.Path),
+				ip.Interface,
+				ip.Gateway,
+				ip.Source,
+				ip.Table,
+				ip.Rule,
+				ip.KernelRoute,
+				strings.Join(ip.Resolvers, "\n"),
+				ip.Reason,
+			})
+		}
+
+		// plain output is the paths of the IPs only, so that they can be piped line by line
+		if utils.OutputFormat(cmd) == utils.FormatPlain {
+			return utils.Render(cmd, nil, ips)
+		}
+
+		return utils.Render(cmd, doc, summary, ips)
+	},
+}
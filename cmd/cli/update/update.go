@@ -0,0 +1,110 @@
+package update
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+var (
+	// ttl is the new duration of the routes, they expire after it
+	ttl time.Duration
+	// permanent makes the routes permanent
+	permanent bool
+	// accumulate is the new accumulate mode of the routes
+	accumulate bool
+)
+
+func init() {
+	UpdateCmd.Flags().DurationVarP(&ttl, "for", "", 0, "make the routes temporary, they are removed automatically after the given duration from now (e.g. 2h)")
+	UpdateCmd.Flags().BoolVarP(&permanent, "permanent", "", false, "make the routes permanent")
+	UpdateCmd.Flags().BoolVarP(&accumulate, "accumulate", "", false, "keep the IPs that are resolved over time routed, --accumulate=false keeps only the latest ones")
+	UpdateCmd.MarkFlagsMutuallyExclusive("for", "permanent")
+}
+
+// UpdateCmd represents the update command
+var UpdateCmd = &cobra.Command{
+	Use:   "update <destination>...",
+	Short: "change the expiry and the accumulate mode of the destinations without resolving them again",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return utils.ErrNoArgs
+		}
+
+		if ttl < 0 {
+			return utils.ErrNegativeTTL
+		}
+
+		if !cmd.Flags().Changed("for") && !permanent && !cmd.Flags().Changed("accumulate") {
+			return ErrNothingToUpdate
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Any("args", args).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		var opts client.UpdateOptions
+		switch {
+		case permanent:
+			opts.TTL = new(time.Duration)
+		case cmd.Flags().Changed("for"):
+			opts.TTL = &ttl
+		}
+
+		if cmd.Flags().Changed("accumulate") {
+			opts.Accumulate = &accumulate
+		}
+
+		items := make([]*ipc.ItemResult, 0, len(args))
+		for _, arg := range args {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			route, err := cl.Update(ctx, arg, opts)
+			cancel()
+
+			// business errors are reported per destination, the others mean that the daemon cannot be reached
+			rpcErr := utils.DecodeError(err)
+			if rpcErr != nil && !rpcErr.Business() {
+				logger.Error().Str("domain", arg).Err(err).Msg(constants.FailedToProcessCommand)
+
+				return &utils.CommandError{Err: errors.Wrap(rpcErr, constants.FailedToConnectToDaemon), Code: utils.ConnectionFailedCode}
+			}
+
+			var ips []string
+			if route != nil {
+				ips = route.RoutedIPs
+			}
+
+			items = append(items, utils.NewItemResult(arg, ipc.StatusUpdated, ips, rpcErr))
+		}
+
+		res := ipc.NewItemsResponse(items)
+		logger.Info().
+			Str("response", res.Response).
+			Msg(constants.SuccessfullyProcessed)
+
+		return utils.RenderResults(cmd, res)
+	},
+}
+
+// ErrNothingToUpdate is returned when none of the flags that change the destinations is given
+var ErrNothingToUpdate = errors.New("nothing to update, one of --for, --permanent and --accumulate is required")
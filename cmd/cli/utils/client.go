@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// ConnectionFailedCode is the exit code of the commands that cannot connect to the daemon
+const ConnectionFailedCode = 13
+
+// DialDaemon returns a client.Client of the gRPC socket of the daemon that is resolved for the given command. The
+// returned Client should be closed by the caller
+func DialDaemon(cmd *cobra.Command) (*client.Client, error) {
+	logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+	// the user agent identifies the CLI in the logs of the daemon
+	cl, err := client.New(client.Options{
+		SocketPath: cmd.Context().Value(constants.GrpcSocketPathKey{}).(string),
+		UserAgent:  "stt-cli/" + version.Get().GitVersion,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToConnectToDaemon)
+
+		return nil, &CommandError{Err: err, Code: ConnectionFailedCode}
+	}
+
+	return cl, nil
+}
+
+// itemStatuses maps the codes of the business errors to the statuses of the failed items
+var itemStatuses = map[client.Code]ipc.ItemStatus{
+	client.CodeRouteAlreadyExists: ipc.StatusAlreadyExists,
+	client.CodeRouteNotFound:      ipc.StatusNotFound,
+	client.CodeResolveFailed:      ipc.StatusResolveFailed,
+	client.CodeRouteFailed:        ipc.StatusRouteFailed,
+	client.CodeInvalidDestination: ipc.StatusInvalid,
+	client.CodeInvalidTTL:         ipc.StatusInvalid,
+	client.CodePermissionDenied:   ipc.StatusPermissionDenied,
+}
+
+// NewItemResult returns the ipc.ItemResult of a single destination of a batch command. The item gets the given status
+// if rpcErr is nil, otherwise its status is derived from the code of rpcErr
+func NewItemResult(destination string, status ipc.ItemStatus, ips []string, rpcErr *RPCError) *ipc.ItemResult {
+	item := &ipc.ItemResult{Domain: destination, Status: status, IPs: ips}
+	if rpcErr == nil {
+		return item
+	}
+
+	item.Status = ipc.StatusStateFailed
+	if status, ok := itemStatuses[rpcErr.Code]; ok {
+		item.Status = status
+	}
+
+	// an existing destination is not a failure, so it is reported without an error
+	if item.Status != ipc.StatusAlreadyExists {
+		item.Error = rpcErr.Error()
+	}
+
+	return item
+}
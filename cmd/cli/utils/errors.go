@@ -1,5 +1,15 @@
 package utils
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
 type CommandError struct {
 	Code int
 	Err  error
@@ -8,3 +18,68 @@ type CommandError struct {
 func (e *CommandError) Error() string {
 	return e.Err.Error()
 }
+
+// hints are the suggestions that are printed with the business errors that the user can fix
+var hints = map[client.Code]string{
+	client.CodePermissionDenied: "the daemon is not permitted to change the routing table or its state, is it running as root?",
+	client.CodeGatewayNotFound:  "no default gateway outside the VPN is found, is the machine connected to a network?",
+	client.CodeResolveFailed:    "none of the DNS servers could resolve the destination, check the dnsservers of the daemon",
+	client.CodeStateWriteFailed: "the state of the daemon could not be written, check the free space and the permissions of its state directory",
+}
+
+// RPCError is a client.Error that is returned by the daemon, it is printed with the hint of its code
+type RPCError client.Error
+
+// DecodeError returns the *RPCError of the given error of a client.Client call, it returns nil for nil error
+func DecodeError(err error) *RPCError {
+	if err == nil {
+		return nil
+	}
+
+	var e *client.Error
+	if !errors.As(err, &e) {
+		e = client.DecodeError(err)
+	}
+
+	return (*RPCError)(e)
+}
+
+// Business returns true if the error is returned by the daemon for the request, rather than a failure to reach it
+func (e *RPCError) Business() bool {
+	return (*client.Error)(e).Business()
+}
+
+// Error returns the readable message of the error with its invalid fields, its subject and the hint of its code
+func (e *RPCError) Error() string {
+	msg := e.Message
+	if len(e.Violations) > 0 {
+		fields := make([]string, 0, len(e.Violations))
+		for _, v := range e.Violations {
+			fields = append(fields, fmt.Sprintf("%s: %s", v.Field, v.Description))
+		}
+
+		msg = fmt.Sprintf("invalid request (%s)", strings.Join(fields, ", "))
+	}
+
+	if len(e.Metadata) > 0 {
+		keys := make([]string, 0, len(e.Metadata))
+		for key := range e.Metadata {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		subject := make([]string, 0, len(keys))
+		for _, key := range keys {
+			subject = append(subject, key+"="+e.Metadata[key])
+		}
+
+		msg += fmt.Sprintf(" [%s]", strings.Join(subject, " "))
+	}
+
+	if hint, ok := hints[e.Code]; ok {
+		msg += ", " + hint
+	}
+
+	return msg
+}
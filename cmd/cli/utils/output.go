@@ -0,0 +1,292 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// Format is the output format of the results of the commands, see the --output flag
+type Format string
+
+const (
+	// FormatTable renders the results as tables, it is the default
+	FormatTable Format = "table"
+	// FormatWide renders the results as tables with the additional columns
+	FormatWide Format = "wide"
+	// FormatJSON renders the results as indented JSON documents, one JSON object per line for the streams
+	FormatJSON Format = "json"
+	// FormatYAML renders the results as YAML documents
+	FormatYAML Format = "yaml"
+	// FormatPlain renders the rows of the tables as tab-separated lines without headers, for the shell pipelines
+	FormatPlain Format = "plain"
+)
+
+// Formats are the supported output formats
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatWide, FormatPlain}
+
+// ParseFormat returns the Format of the given name
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+
+	return "", errors.Errorf("unknown output format %q, one of %s is expected", name, formatNames())
+}
+
+// formatNames returns the names of the supported output formats such as table|json
+func formatNames() string {
+	names := make([]string, 0, len(Formats))
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// OutputFormat returns the output format of the given command, FormatTable if it is not set
+func OutputFormat(cmd *cobra.Command) Format {
+	if format, ok := cmd.Context().Value(constants.OutputFormatKey{}).(Format); ok {
+		return format
+	}
+
+	return FormatTable
+}
+
+// Structured returns true if the given format is a machine-readable document format, such as json
+func (f Format) Structured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Column is a column of a Table
+type Column struct {
+	// Key is the name of the column for the --columns flag, such as expires
+	Key string
+	// Header is the header of the column, such as Expires In
+	Header string
+	// Wide columns are only rendered with FormatWide, unless they are selected explicitly
+	Wide bool
+}
+
+// Table is the tabular representation of the results of a command, it is rendered with FormatTable, FormatWide and
+// FormatPlain
+type Table struct {
+	Columns []Column
+	// Rows have a cell for every column, in the order of the columns
+	Rows [][]string
+	// Headless tables are rendered without the header, such as the key-value tables
+	Headless bool
+}
+
+// Select keeps only the columns with the given keys in the given order, the selected wide columns are rendered in all
+// the formats
+func (t *Table) Select(keys []string) error {
+	indexes := make([]int, 0, len(keys))
+	columns := make([]Column, 0, len(keys))
+	for _, key := range keys {
+		index := -1
+		for i, column := range t.Columns {
+			if column.Key == key {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			return errors.Errorf("unknown column %q, one of %s is expected", key, t.keys())
+		}
+
+		column := t.Columns[index]
+		column.Wide = false
+		indexes = append(indexes, index)
+		columns = append(columns, column)
+	}
+
+	for i, row := range t.Rows {
+		selected := make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			selected = append(selected, row[index])
+		}
+
+		t.Rows[i] = selected
+	}
+
+	t.Columns = columns
+
+	return nil
+}
+
+// keys returns the keys of the columns of the table such as destination|gateway
+func (t *Table) keys() string {
+	keys := make([]string, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		keys = append(keys, column.Key)
+	}
+
+	return strings.Join(keys, "|")
+}
+
+// visible returns the indexes of the columns that are rendered with the given format
+func (t *Table) visible(format Format) []int {
+	indexes := make([]int, 0, len(t.Columns))
+	for i, column := range t.Columns {
+		if !column.Wide || format == FormatWide {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// Render writes the results of the given command to its output in its output format. The structured formats encode
+// doc, the others render the given tables
+func Render(cmd *cobra.Command, doc any, tables ...*Table) error {
+	return RenderTo(cmd.OutOrStdout(), OutputFormat(cmd), doc, tables...)
+}
+
+// RenderTo writes the results to the given writer in the given format, see Render
+func RenderTo(w io.Writer, format Format, doc any, tables ...*Table) error {
+	switch format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to encode output")
+		}
+
+		_, err = fmt.Fprintln(w, string(out))
+
+		return err
+	case FormatYAML:
+		out, err := marshalYAML(doc)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(out)
+
+		return err
+	case FormatPlain:
+		for _, table := range tables {
+			renderPlain(w, table)
+		}
+	default:
+		for _, table := range tables {
+			renderTable(w, table, format)
+		}
+	}
+
+	return nil
+}
+
+// Encode writes the given document as a single line of JSON, or as a YAML document that starts with a separator, so
+// that the documents of a stream can be told apart
+func Encode(w io.Writer, format Format, doc any) error {
+	if format == FormatYAML {
+		out, err := marshalYAML(doc)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "---\n%s", out)
+
+		return err
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode output")
+	}
+
+	_, err = fmt.Fprintln(w, string(out))
+
+	return err
+}
+
+// marshalYAML encodes the given document as YAML with the keys of its JSON encoding, so that both formats share the
+// same schema
+func marshalYAML(doc any) ([]byte, error) {
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode output")
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the order of the keys
+	var node yaml.Node
+	if err := yaml.Unmarshal(out, &node); err != nil {
+		return nil, errors.Wrap(err, "failed to encode output")
+	}
+
+	clearStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, errors.Wrap(err, "failed to encode output")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// clearStyle clears the JSON styles such as the flow mappings and the quoted strings of the given node and its
+// children, so that they are encoded in the block style
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// renderTable renders the given table with its borders, the wide columns are rendered only with FormatWide
+func renderTable(w io.Writer, t *Table, format Format) {
+	visible := t.visible(format)
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(true)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+
+	if !t.Headless {
+		headers := make([]string, 0, len(visible))
+		for _, i := range visible {
+			headers = append(headers, t.Columns[i].Header)
+		}
+
+		table.SetHeader(headers)
+	}
+
+	for _, row := range t.Rows {
+		cells := make([]string, 0, len(visible))
+		for _, i := range visible {
+			cells = append(cells, row[i])
+		}
+
+		table.Append(cells)
+	}
+
+	table.Render()
+}
+
+// renderPlain writes the rows of the given table as tab-separated lines, the multi-line cells are joined with commas
+func renderPlain(w io.Writer, t *Table) {
+	visible := t.visible(FormatPlain)
+	for _, row := range t.Rows {
+		cells := make([]string, 0, len(visible))
+		for _, i := range visible {
+			cells = append(cells, strings.ReplaceAll(row[i], "\n", ","))
+		}
+
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+}
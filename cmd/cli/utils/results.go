@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ItemsFailedCode is the exit code of the batch commands that have at least one failed item
+const ItemsFailedCode = 16
+
+// ResultsOutput is the stable schema of the results of a batch command in the structured output formats
+type ResultsOutput struct {
+	Success bool `json:"success"`
+	// Summary is the number of the items per status, such as "2 added, 1 already-exists"
+	Summary string       `json:"summary"`
+	Error   string       `json:"error,omitempty"`
+	Items   []ItemOutput `json:"items"`
+}
+
+// ItemOutput is the stable schema of the result of a single destination of a batch command
+type ItemOutput struct {
+	Destination string   `json:"destination"`
+	Status      string   `json:"status"`
+	IPs         []string `json:"ips"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// RenderResults renders the results of a batch operation in the output format of the given command, the tables are
+// followed by a summary line. It returns a CommandError with ItemsFailedCode if any of the items failed
+func RenderResults(cmd *cobra.Command, resp *ipc.DaemonResponse) error {
+	doc := ResultsOutput{Success: resp.Success, Summary: resp.Response, Error: resp.Error, Items: make([]ItemOutput, 0, len(resp.Items))}
+	table := &Table{Columns: []Column{
+		{Key: "destination", Header: "Domain"},
+		{Key: "status", Header: "Status"},
+		{Key: "ips", Header: "IPs"},
+		{Key: "error", Header: "Error"},
+	}}
+
+	for _, item := range resp.Items {
+		doc.Items = append(doc.Items, ItemOutput{Destination: item.Domain, Status: string(item.Status), IPs: nonNil(item.IPs), Error: item.Error})
+		table.Rows = append(table.Rows, []string{item.Domain, string(item.Status), strings.Join(item.IPs, "\n"), item.Error})
+	}
+
+	format := OutputFormat(cmd)
+	if err := RenderTo(cmd.OutOrStdout(), format, doc, table); err != nil {
+		return err
+	}
+
+	if format == FormatTable || format == FormatWide {
+		fmt.Fprintln(cmd.OutOrStdout(), resp.Response)
+	}
+
+	if !resp.Success {
+		return &CommandError{Err: errors.New(resp.Error), Code: ItemsFailedCode}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// Remaining returns the human-readable time left until the expiry of the given route, "-" for the permanent ones
+func Remaining(route *client.Route) string {
+	if route.ExpiresAt == nil {
+		return "-"
+	}
+
+	left := time.Until(*route.ExpiresAt).Round(time.Second)
+	if left <= 0 {
+		return "expired"
+	}
+
+	return left.String()
+}
+
+// RouteIPs returns the IPs of the given route with the statuses of their routes, and the last errors of the failed
+// ones
+func RouteIPs(route *client.Route) ([]string, []string) {
+	// routes of the daemons that do not track the statuses of the routes
+	if len(route.IPs) == 0 {
+		return route.RoutedIPs, nil
+	}
+
+	ips := make([]string, 0, len(route.IPs))
+	var errs []string
+	for _, ip := range route.IPs {
+		ips = append(ips, fmt.Sprintf("%s (%s)", ip.IP, ip.Status))
+		if ip.LastError != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", ip.IP, ip.LastError))
+		}
+	}
+
+	return ips, errs
+}
+
+// ListFormat returns the client.ListFormat of the given name of a bypasslist.Format, such as csv
+func ListFormat(name string) (client.ListFormat, error) {
+	format, err := bypasslist.ParseFormat(name)
+	if err != nil {
+		return client.ListFormatAuto, err
+	}
+
+	return client.ListFormat(format), nil
+}
+
+// RouteOutput is the stable schema of a route in the structured output formats
+type RouteOutput struct {
+	Destination string     `json:"destination"`
+	Gateway     string     `json:"gateway"`
+	Group       string     `json:"group"`
+	Active      bool       `json:"active"`
+	Accumulate  bool       `json:"accumulate"`
+	Source      string     `json:"source"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	// IPs are the statuses of the routes of the IPs, empty for the daemons that do not track them
+	IPs       []RouteIPOutput `json:"ips"`
+	RoutedIPs []string        `json:"routedIps"`
+	Tags      []string        `json:"tags"`
+	Comment   string          `json:"comment"`
+}
+
+// RouteIPOutput is the stable schema of the route of a single IP in the structured output formats
+type RouteIPOutput struct {
+	IP        string    `json:"ip"`
+	Status    string    `json:"status"`
+	LastError string    `json:"lastError"`
+	Attempts  int       `json:"attempts"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Resolvers []string  `json:"resolvers"`
+}
+
+// NewRouteOutput converts the given client.Route into a RouteOutput, the empty lists are kept as empty arrays
+func NewRouteOutput(route *client.Route) RouteOutput {
+	out := RouteOutput{
+		Destination: route.Destination,
+		Gateway:     route.Gateway,
+		Group:       route.Group,
+		Active:      route.Active,
+		Accumulate:  route.Accumulate,
+		Source:      route.Source,
+		ExpiresAt:   route.ExpiresAt,
+		IPs:         make([]RouteIPOutput, 0, len(route.IPs)),
+		RoutedIPs:   nonNil(route.RoutedIPs),
+		Tags:        nonNil(route.Tags),
+		Comment:     route.Comment,
+	}
+
+	for _, ip := range route.IPs {
+		out.IPs = append(out.IPs, RouteIPOutput{
+			IP:        ip.IP,
+			Status:    string(ip.Status),
+			LastError: ip.LastError,
+			Attempts:  ip.Attempts,
+			FirstSeen: ip.FirstSeen,
+			LastSeen:  ip.LastSeen,
+			Resolvers: nonNil(ip.Resolvers),
+		})
+	}
+
+	return out
+}
+
+// nonNil returns an empty slice for nil, so that the empty lists are encoded as empty arrays rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
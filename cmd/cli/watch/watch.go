@@ -0,0 +1,163 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/cli/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+var (
+	// types are the names of the event types to watch, such as ips-changed
+	types []string
+	// destinations are the destinations to watch
+	destinations []string
+	// replay is the number of the past events to print before the live ones
+	replay int
+)
+
+func init() {
+	WatchCmd.Flags().StringSliceVarP(&types, "type", "t", nil, "event types to watch, one or more of entry-added, entry-removed, ips-changed, route-failed, gateway-changed and config-reloaded")
+	WatchCmd.Flags().StringSliceVarP(&destinations, "destination", "d", nil, "destinations to watch, events that are not about a destination are always printed")
+	WatchCmd.Flags().IntVarP(&replay, "replay", "r", 0, "number of the past events to print before the live ones")
+}
+
+// WatchCmd represents the watch command
+var WatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "print the changes on the routes and the state of the daemon as they happen",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := cmd.Context().Value(constants.LoggerKey{}).(zerolog.Logger)
+
+		opts := client.WatchOptions{Destinations: destinations, Replay: replay}
+		for _, name := range types {
+			opts.Types = append(opts.Types, client.EventType(name))
+		}
+
+		logger.Info().
+			Str("operation", cmd.Name()).
+			Strs("types", types).
+			Strs("destinations", destinations).
+			Msg(constants.ProcessCommand)
+
+		cl, err := utils.DialDaemon(cmd)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		watcher, err := cl.Watch(cmd.Context(), opts)
+		if err != nil {
+			logger.Error().Err(err).Msg(constants.FailedToProcessCommand)
+
+			// unknown event types are rejected before the daemon is called
+			if rpcErr := utils.DecodeError(err); rpcErr.GRPCCode == codes.InvalidArgument && !rpcErr.Business() {
+				return &utils.CommandError{Err: rpcErr, Code: 1}
+			}
+
+			return &utils.CommandError{Err: errors.Wrap(err, constants.FailedToConnectToDaemon), Code: utils.ConnectionFailedCode}
+		}
+		defer watcher.Close()
+
+		for {
+			e, err := watcher.Next()
+			if err == io.EOF || errors.Is(err, context.Canceled) {
+				return nil
+			}
+
+			if err != nil {
+				logger.Error().Err(err).Msg(constants.FailedToProcessCommand)
+
+				return &utils.CommandError{Err: utils.DecodeError(err), Code: 14}
+			}
+
+			// structured formats write a document per event, so that the stream can be consumed as it goes
+			if format := utils.OutputFormat(cmd); format.Structured() {
+				if err := utils.Encode(cmd.OutOrStdout(), format, newEventOutput(e)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), formatEvent(e))
+		}
+	},
+}
+
+// EventOutput is the stable schema of an event in the structured output formats
+type EventOutput struct {
+	Sequence        uint64    `json:"sequence"`
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	Destination     string    `json:"destination,omitempty"`
+	IPs             []string  `json:"ips,omitempty"`
+	AddedIPs        []string  `json:"addedIps,omitempty"`
+	RemovedIPs      []string  `json:"removedIps,omitempty"`
+	Gateway         string    `json:"gateway,omitempty"`
+	PreviousGateway string    `json:"previousGateway,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// newEventOutput converts the given client.Event into an EventOutput
+func newEventOutput(e *client.Event) EventOutput {
+	return EventOutput{
+		Sequence:        e.Sequence,
+		Type:            string(e.Type),
+		Time:            e.Time,
+		Destination:     e.Destination,
+		IPs:             e.IPs,
+		AddedIPs:        e.AddedIPs,
+		RemovedIPs:      e.RemovedIPs,
+		Gateway:         e.Gateway,
+		PreviousGateway: e.PreviousGateway,
+		Error:           e.Error,
+	}
+}
+
+// formatEvent returns the single line representation of the given client.Event
+func formatEvent(e *client.Event) string {
+	fields := []string{
+		e.Time.Local().Format(time.RFC3339),
+		fmt.Sprintf("#%d", e.Sequence),
+		string(e.Type),
+	}
+
+	if e.Destination != "" {
+		fields = append(fields, e.Destination)
+	}
+
+	attrs := []struct {
+		key    string
+		values []string
+	}{
+		{"ips", e.IPs},
+		{"added", e.AddedIPs},
+		{"removed", e.RemovedIPs},
+		{"gateway", []string{e.Gateway}},
+		{"previous-gateway", []string{e.PreviousGateway}},
+	}
+
+	for _, attr := range attrs {
+		if values := strings.Join(attr.values, ","); values != "" {
+			fields = append(fields, attr.key+"="+values)
+		}
+	}
+
+	if e.Error != "" {
+		fields = append(fields, fmt.Sprintf("error=%q", e.Error))
+	}
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/daemon/options"
+)
+
+// ErrInvalidConfig is returned by the validate command when the configuration file has problems
+var ErrInvalidConfig = errors.New("config file is invalid")
+
+// ErrNoConfigFile is returned by the validate command when no file is given and none of the config files exist
+var ErrNoConfigFile = errors.New("no config file found")
+
+func init() {
+	ConfigCmd.AddCommand(validateCmd)
+}
+
+// ConfigCmd represents the config command
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "inspect the configuration file",
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "validate the configuration file, exits with a non-zero code if it has problems",
+	Long: `Validates the configuration file, the config files that the daemon would read are validated if no file is given.
+Unknown keys, values of a wrong type and invalid values are reported with their line numbers, one per line, so that
+the command can be used in pre-commit hooks and CI pipelines.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := options.GetRootOptions()
+		files := args
+		if len(files) == 0 {
+			resolved, err := opts.ResolveConfigFiles()
+			if err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), err)
+				return err
+			}
+
+			if len(resolved) == 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), ErrNoConfigFile)
+				return ErrNoConfigFile
+			}
+
+			files = resolved
+		}
+
+		var result error
+		for _, path := range files {
+			if err := validate(cmd, opts, path); err != nil {
+				result = err
+			}
+		}
+
+		return result
+	},
+}
+
+// validate validates a single config file and reports its problems to stderr
+func validate(cmd *cobra.Command, opts *options.RootOptions, path string) error {
+	if err := opts.ValidateFile(path); err != nil {
+		var verrs options.ValidationErrors
+		if !errors.As(err, &verrs) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", path, err)
+			return err
+		}
+
+		// reported in the file:line: message format that is understood by the editors and CI tools
+		for _, verr := range verrs {
+			location := path
+			if verr.Line > 0 {
+				location = fmt.Sprintf("%s:%d", path, verr.Line)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", location, verr.Description())
+		}
+
+		return ErrInvalidConfig
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s: config file is valid\n", path)
+
+	return nil
+}
@@ -2,41 +2,43 @@ package main
 
 import (
 	"context"
-	"log"
 	"net"
-
-	"google.golang.org/grpc"
-
-	//"fmt"
 	"os"
-	//"os/signal"
-	//"syscall"
-	//"time"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
-	//"github.com/bilalcaliskan/split-the-tunnel/internal/state"
-
-	//"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/preset"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/server"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/subscription"
 
-	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
 
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/daemon/config"
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/daemon/openapi"
 	"github.com/bilalcaliskan/split-the-tunnel/cmd/daemon/options"
-	//"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
-	//"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/ipc"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
 
-type server struct {
-	pb.UnimplementedRouteManagerServer
-}
-
 func init() {
 	opts = options.GetRootOptions()
 	if err := opts.InitFlags(daemonCmd); err != nil {
 		panic(errors.Wrap(err, "failed to initialize flags"))
 	}
+
+	daemonCmd.AddCommand(config.ConfigCmd)
+	daemonCmd.AddCommand(openapi.OpenAPICmd)
 }
 
 var (
@@ -51,77 +53,359 @@ var (
 		Long:    ``,
 		Version: ver.GitVersion,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			lis, err := net.Listen("tcp", ":50051")
+			if err := opts.ReadConfig(); err != nil {
+				return errors.Wrap(err, "failed to read config")
+			}
+
+			for _, path := range []string{opts.SocketPath, opts.GrpcSocketPath, opts.StatePath} {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return errors.Wrapf(err, "failed to create directory of %s", path)
+				}
+			}
+
+			if opts.Verbose {
+				logging.EnableDebugLogging()
+			}
+
+			utils.SetDNSServers(opts.DNSServerList())
+			utils.SetResolveStrategy(utils.ResolveStrategy(opts.DnsStrategy), opts.DnsQueries)
+
+			logger := logging.GetLogger().With().Str("job", constants.JobMain).Logger()
+			logger.Info().Str("appVersion", ver.GitVersion).Str("goVersion", ver.GoVersion).Str("goOS", ver.GoOs).
+				Str("goArch", ver.GoArch).Str("gitCommit", ver.GitCommit).Str("buildDate", ver.BuildDate).
+				Msg(constants.AppStarted)
+
+			bus := events.NewBus(constants.EventHistorySize)
+			st := state.NewState(logger, opts.StatePath)
+			st.SetEventBus(bus)
+			st.SetRouteMode(state.RouteMode(opts.RouteMode))
+			st.SetGracePeriod(time.Duration(int64(opts.GracePeriodMin)) * time.Minute)
+			st.SetAccumulation(time.Duration(int64(opts.AccumulateWindowMin))*time.Minute, opts.AccumulateMaxIPs)
+
+			// the state is loaded before gRPC is served, so that no call can see or overwrite an empty state
+			if err := st.Reload(); err != nil {
+				logger.Error().Err(err).Msg(constants.FailedToReloadState)
+				return err
+			}
+
+			// socket may be left behind by a daemon that is not stopped gracefully
+			if err := os.Remove(opts.GrpcSocketPath); err != nil && !os.IsNotExist(err) {
+				logger.Error().Err(err).Msg(constants.FailedToInitializeGrpc)
+				return err
+			}
+
+			lis, err := net.Listen("unix", opts.GrpcSocketPath)
 			if err != nil {
-				log.Fatalf("failed to listen: %v", err)
+				logger.Error().Err(err).Msg(constants.FailedToInitializeGrpc)
+				return err
 			}
-			s := grpc.NewServer()
-			pb.RegisterRouteManagerServer(s, &server{})
-			if err := s.Serve(lis); err != nil {
-				log.Fatalf("failed to serve: %v", err)
+
+			catalog, err := preset.Load(opts.PresetFiles()...)
+			if err != nil {
+				logger.Error().Err(err).Msg(constants.FailedToLoadPresets)
+				return err
 			}
 
-			return nil
+			logger.Info().Int("version", catalog.Version).Int("presets", len(catalog.Presets)).Msg(constants.LoadedPresets)
+
+			srv := server.NewServer(st, bus, logger)
+			srv.SetPresets(catalog)
+
+			// gRPC is served before the routes are restored and the config is converged, so that the health service
+			// reports NOT_SERVING until they are done, the calls of the RouteManager service wait for the lock of the
+			// state meanwhile
+			grpcServer, healthServer := server.NewGRPCServer(srv, server.GRPCOptions{
+				Reflection: opts.GrpcReflection,
+				Timeout:    time.Duration(int64(opts.GrpcTimeoutSec)) * time.Second,
+			}, logging.GetLogger().With().Str("job", constants.JobGrpc).Logger())
+
+			go func() {
+				if err := grpcServer.Serve(lis); err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToServeGrpc)
+				}
+			}()
+
+			defer grpcServer.GracefulStop()
+			defer healthServer.Shutdown()
+
+			if opts.GatewaySocketPath != "" || opts.GatewayAddress != "" {
+				stopGateway, err := serveGateway(opts, logger)
+				if err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToInitializeGateway)
+					return err
+				}
+
+				// event streams of the gateway are gRPC streams too, so the gateway is stopped before the gRPC server
+				defer stopGateway()
+			}
+
+			// the routes are restored to the routing table after gRPC is served, the health service reports
+			// NOT_SERVING until then
+			restore := func() {
+				gw, err := utils.GetDefaultNonVPNGateway()
+				if err != nil {
+					// routes are restored via the gateways that they are installed with
+					logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+				}
+
+				st.Lock()
+				defer st.Unlock()
+
+				if err := st.RestoreRoutes(gw); err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToRestoreRoutes)
+				}
+			}
+
+			converge := func(declaration *state.Declaration) {
+				gw, err := utils.GetDefaultNonVPNGateway()
+				if err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+					return
+				}
+
+				st.Lock()
+				defer st.Unlock()
+
+				if err := st.Converge(declaration, gw); err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToConvergeState)
+					return
+				}
+
+				logger.Info().Msg(constants.ConvergedState)
+			}
+
+			// syncPresets brings the groups of the enabled presets to the catalog, which changes with the override files
+			syncPresets := func() {
+				gw, err := utils.GetDefaultNonVPNGateway()
+				if err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+					return
+				}
+
+				st.Lock()
+				defer st.Unlock()
+
+				for _, name := range st.EnabledPresets() {
+					p := catalog.Get(name)
+					if p == nil {
+						logger.Warn().Str("preset", name).Msg(constants.EnabledPresetRemoved)
+						continue
+					}
+
+					added, removed, err := st.SyncPreset(p.Name, p.Destinations, gw)
+					if err != nil {
+						logger.Error().Err(err).Str("preset", name).Msg(constants.FailedToSyncPreset)
+						continue
+					}
+
+					logger.Info().Str("preset", name).Strs("added", added).Strs("removed", removed).Msg(constants.SyncedPreset)
+				}
+			}
+
+			restore()
+			converge(opts.Declaration())
+			syncPresets()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			subscriptions := subscription.NewManager(st, logger.With().Str("job", constants.JobSubscription).Logger())
+			subscriptions.SetSubscriptions(opts.SubscriptionConfigs())
+			go subscriptions.Run(ctx)
 
-			//if err := os.MkdirAll(opts.Workspace, 0755); err != nil {
-			//	return errors.Wrap(err, "failed to create workspace directory")
-			//}
-			//
-			//if err := opts.ReadConfig(); err != nil {
-			//	return errors.Wrap(err, "failed to read config")
-			//}
-			//
-			//fmt.Println(opts)
-			//
-			//logger := logging.GetLogger().With().Str("job", constants.JobMain).Logger()
-			//logger.Info().Str("appVersion", ver.GitVersion).Str("goVersion", ver.GoVersion).Str("goOS", ver.GoOs).
-			//	Str("goArch", ver.GoArch).Str("gitCommit", ver.GitCommit).Str("buildDate", ver.BuildDate).
-			//	Msg(constants.AppStarted)
-			//
-			//st := state.NewState(logger, opts.StatePath)
-			//
-			//// initialize IPC for communication between CLI and daemon
-			//if err := ipc.InitIPC(st, opts.SocketPath, logger); err != nil {
-			//	logger.Error().Err(err).Msg(constants.FailedToInitializeIPC)
-			//	return err
-			//}
-			//
-			//logger.Info().Str("socket", opts.SocketPath).Msg(constants.IPCInitialized)
-			//
-			//defer func() {
-			//	logger := logger.With().Str("job", constants.JobCleanup).Logger()
-			//	logger.Info().Msg(constants.CleaningUpIPC)
-			//	if err := ipc.Cleanup(opts.SocketPath); err != nil {
-			//		logger.Error().Err(err).Msg(constants.FailedToCleanupIPC)
-			//	}
-			//}()
-			//
-			//logger.Info().Str("socket", opts.SocketPath).Msg(constants.DaemonRunning)
-			//
-			//go func() {
-			//	// Create a ticker that fires every 5 minutes
-			//	ticker := time.NewTicker(time.Duration(int64(opts.CheckIntervalMin)) * time.Minute)
-			//	logger := logger.With().Str("job", constants.JobIpChangeCheck).Logger()
-			//
-			//	for range ticker.C {
-			//		if <-ticker.C; true {
-			//			if err := st.CheckIPChanges(); err != nil {
-			//				logger.Error().Err(err).Msg("failed to check ip changes")
-			//			}
-			//		}
-			//	}
-			//}()
-			//
-			//// setup signal handling for graceful shutdown
-			//sigs := make(chan os.Signal, 1)
-			//signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-			//
-			//// Wait for termination signal to gracefully shut down the daemon
-			//s := <-sigs
-			//logger.Info().Any("signal", s.String()).Msg(constants.TermSignalReceived)
-			//logger.Info().Msg(constants.ShuttingDownDaemon)
-			//
-			//return nil
+			healthServer.Resume()
+			logger.Info().Msg(constants.DaemonServing)
+
+			// intervalCh carries the new check interval of the ip change check job on config reloads
+			intervalCh := make(chan time.Duration, 1)
+
+			// running is the configuration in effect, reload replaces it under reloadMu. opts is not modified after the
+			// startup, so the other goroutines can read the settings that are not reloaded such as the socket paths
+			running := opts
+			var reloadMu sync.Mutex
+			reload := func(trigger string) {
+				reloadMu.Lock()
+				defer reloadMu.Unlock()
+
+				logger := logger.With().Str("job", constants.JobConfigReload).Str("trigger", trigger).Logger()
+
+				next, err := running.Reload()
+				if err != nil {
+					logger.Error().Err(err).Msg(constants.RejectedConfigReload)
+					return
+				}
+
+				if next.DnsServers != running.DnsServers {
+					utils.SetDNSServers(next.DNSServerList())
+					logger.Info().Str("dnsServers", next.DnsServers).Msg(constants.AppliedDNSServers)
+				}
+
+				if next.DnsStrategy != running.DnsStrategy || next.DnsQueries != running.DnsQueries {
+					utils.SetResolveStrategy(utils.ResolveStrategy(next.DnsStrategy), next.DnsQueries)
+					logger.Info().Str("dnsStrategy", next.DnsStrategy).Int("dnsQueries", next.DnsQueries).
+						Msg(constants.AppliedDNSStrategy)
+				}
+
+				if next.CheckIntervalMin != running.CheckIntervalMin {
+					// a pending interval that the ip change check job has not picked up yet is replaced, so that
+					// the reload does not wait for a running check
+					select {
+					case <-intervalCh:
+					default:
+					}
+
+					intervalCh <- time.Duration(int64(next.CheckIntervalMin)) * time.Minute
+					logger.Info().Int("checkIntervalMin", next.CheckIntervalMin).Msg(constants.AppliedCheckInterval)
+				}
+
+				if next.Verbose != running.Verbose {
+					level := logging.Level
+					if next.Verbose {
+						level = zerolog.DebugLevel
+					}
+
+					logging.SetLevel(level)
+					logger.Info().Bool("verbose", next.Verbose).Msg(constants.AppliedLogLevel)
+				}
+
+				if next.RouteMode != running.RouteMode {
+					st.Lock()
+					st.SetRouteMode(state.RouteMode(next.RouteMode))
+					st.Unlock()
+					logger.Info().Str("routeMode", next.RouteMode).Msg(constants.AppliedRouteMode)
+				}
+
+				if next.GracePeriodMin != running.GracePeriodMin {
+					st.Lock()
+					st.SetGracePeriod(time.Duration(int64(next.GracePeriodMin)) * time.Minute)
+					st.Unlock()
+					logger.Info().Int("gracePeriodMin", next.GracePeriodMin).Msg(constants.AppliedGracePeriod)
+				}
+
+				if next.AccumulateWindowMin != running.AccumulateWindowMin || next.AccumulateMaxIPs != running.AccumulateMaxIPs {
+					st.Lock()
+					st.SetAccumulation(time.Duration(int64(next.AccumulateWindowMin))*time.Minute, next.AccumulateMaxIPs)
+					st.Unlock()
+					logger.Info().Int("accumulateWindowMin", next.AccumulateWindowMin).
+						Int("accumulateMaxIPs", next.AccumulateMaxIPs).Msg(constants.AppliedAccumulation)
+				}
+
+				running = next
+				converge(running.Declaration())
+
+				// an invalid override file keeps the running catalog, like an invalid config file
+				if loaded, err := preset.Load(running.PresetFiles()...); err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToLoadPresets)
+				} else {
+					catalog = loaded
+					srv.SetPresets(catalog)
+					syncPresets()
+				}
+
+				subscriptions.SetSubscriptions(running.SubscriptionConfigs())
+
+				bus.Publish(&events.Event{Type: events.ConfigReloaded})
+				logger.Info().Msg(constants.ConfigReloaded)
+			}
+
+			if err := opts.WatchConfig(func(file string) {
+				reload("file-change")
+			}); err != nil {
+				logger.Error().Err(err).Msg(constants.FailedToWatchConfig)
+			}
+
+			go func() {
+				hups := make(chan os.Signal, 1)
+				signal.Notify(hups, syscall.SIGHUP)
+
+				for range hups {
+					reload("sighup")
+				}
+			}()
+
+			// initialize IPC for communication between CLI and daemon
+			if err := ipc.InitIPC(st, opts.SocketPath, logger); err != nil {
+				logger.Error().Err(err).Msg(constants.FailedToInitializeIPC)
+				return err
+			}
+
+			logger.Info().Str("socket", opts.SocketPath).Msg(constants.IPCInitialized)
+
+			defer func() {
+				logger := logger.With().Str("job", constants.JobCleanup).Logger()
+				logger.Info().Msg(constants.CleaningUpIPC)
+				if err := ipc.Cleanup(opts.SocketPath); err != nil {
+					logger.Error().Err(err).Msg(constants.FailedToCleanupIPC)
+				}
+			}()
+
+			logger.Info().Str("socket", opts.SocketPath).Str("grpcSocket", opts.GrpcSocketPath).Msg(constants.DaemonRunning)
+
+			go func() {
+				ticker := time.NewTicker(time.Duration(int64(opts.CheckIntervalMin)) * time.Minute)
+				logger := logger.With().Str("job", constants.JobIpChangeCheck).Logger()
+
+				// gateway is the last seen default non-vpn gateway, its changes are published to the watchers
+				gateway, _ := utils.GetDefaultNonVPNGateway()
+
+				for {
+					select {
+					case <-ticker.C:
+						if gw, err := utils.GetDefaultNonVPNGateway(); err == nil && gw != gateway {
+							if gateway != "" {
+								logger.Info().Str("gateway", gw).Str("previousGateway", gateway).Msg(constants.GatewayChanged)
+								bus.Publish(&events.Event{Type: events.GatewayChanged, Gateway: gw, PreviousGateway: gateway})
+							}
+
+							gateway = gw
+						}
+
+						st.Lock()
+						if err := st.CheckIPChanges(); err != nil {
+							logger.Error().Err(err).Msg("failed to check ip changes")
+						}
+						st.Unlock()
+					case interval := <-intervalCh:
+						ticker.Reset(interval)
+					}
+				}
+			}()
+
+			go func() {
+				logger := logger.With().Str("job", constants.JobExpiryCheck).Logger()
+				removeExpired := func() {
+					st.Lock()
+					defer st.Unlock()
+
+					expired, err := st.RemoveExpiredEntries(time.Now())
+					if err != nil {
+						logger.Error().Err(err).Msg(constants.FailedToRemoveExpiredEntries)
+					}
+
+					for _, entry := range expired {
+						logger.Info().Str("domain", entry.Domain).Time("expiresAt", *entry.ExpiresAt).Msg(constants.RemovedExpiredEntry)
+					}
+				}
+
+				// entries may have been expired while the daemon was not running
+				removeExpired()
+
+				ticker := time.NewTicker(constants.ExpiryCheckInterval)
+				for range ticker.C {
+					removeExpired()
+				}
+			}()
+
+			// setup signal handling for graceful shutdown
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+			// Wait for termination signal to gracefully shut down the daemon
+			s := <-sigs
+			logger.Info().Any("signal", s.String()).Msg(constants.TermSignalReceived)
+			logger.Info().Msg(constants.ShuttingDownDaemon)
+
+			return nil
 		},
 	}
 )
@@ -131,28 +415,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func (s *server) AddRoute(ctx context.Context, req *pb.AddRouteRequest) (*pb.AddRouteResponse, error) {
-	if req.GetDestination() == "" {
-		return &pb.AddRouteResponse{
-			Response: &pb.AddRouteResponse_Error{
-				Error: &pb.Error{
-					Code:        pb.StatusCode_INVALID_DESTINATION,
-					Description: "Destination cannot be empty",
-				},
-			},
-		}, nil
-	}
-
-	// Simulate adding route logic here...
-
-	// Example response
-	return &pb.AddRouteResponse{
-		Response: &pb.AddRouteResponse_Payload{
-			Payload: &pb.AddRoutePayload{
-				Success: true,
-				Message: "Route added successfully",
-			},
-		},
-	}, nil
-}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/cmd/daemon/options"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/gateway"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// gatewayShutdownTimeout is the time that the open requests of the gateway are given to complete on shutdown
+const gatewayShutdownTimeout = 5 * time.Second
+
+// listenGateway returns the listeners of the HTTP gateway on its unix socket and its loopback port, the unix socket
+// gets the permissions of the gRPC socket
+func listenGateway(opts *options.RootOptions) ([]net.Listener, error) {
+	var listeners []net.Listener
+	closeAll := func() {
+		for _, lis := range listeners {
+			_ = lis.Close()
+		}
+	}
+
+	if opts.GatewaySocketPath != "" {
+		if err := os.MkdirAll(filepath.Dir(opts.GatewaySocketPath), 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create directory of %s", opts.GatewaySocketPath)
+		}
+
+		// socket may be left behind by a daemon that is not stopped gracefully
+		if err := os.Remove(opts.GatewaySocketPath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		lis, err := net.Listen("unix", opts.GatewaySocketPath)
+		if err != nil {
+			return nil, err
+		}
+
+		listeners = append(listeners, lis)
+
+		info, err := os.Stat(opts.GrpcSocketPath)
+		if err == nil {
+			err = os.Chmod(opts.GatewaySocketPath, info.Mode().Perm())
+		}
+
+		if err != nil {
+			closeAll()
+			return nil, errors.Wrap(err, "failed to apply permissions of gRPC socket")
+		}
+	}
+
+	if opts.GatewayAddress != "" {
+		lis, err := net.Listen("tcp", opts.GatewayAddress)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		listeners = append(listeners, lis)
+	}
+
+	return listeners, nil
+}
+
+// serveGateway serves the HTTP gateway of the gRPC server on the socket and the port in the given options, the
+// returned function stops it
+func serveGateway(opts *options.RootOptions, logger zerolog.Logger) (func(), error) {
+	listeners, err := listenGateway(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// gateway calls the gRPC server over its socket, so that the calls go through the same interceptors
+	conn, err := gateway.Dial(opts.GrpcSocketPath)
+	if err != nil {
+		for _, lis := range listeners {
+			_ = lis.Close()
+		}
+
+		return nil, err
+	}
+
+	gatewayLogger := logging.GetLogger().With().Str("job", constants.JobGateway).Logger()
+	httpServer := gateway.NewHTTPServer(gateway.NewGateway(pb.NewRouteManagerClient(conn), gatewayLogger))
+	for _, lis := range listeners {
+		go func(lis net.Listener) {
+			if err := httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error().Err(err).Msg(constants.FailedToServeGateway)
+			}
+		}(lis)
+	}
+
+	logger.Info().Str("socket", opts.GatewaySocketPath).Str("address", opts.GatewayAddress).Msg(constants.GatewayServing)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(ctx); err != nil {
+			_ = httpServer.Close()
+		}
+
+		_ = conn.Close()
+	}, nil
+}
@@ -0,0 +1,27 @@
+package openapi
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/gateway"
+)
+
+// OpenAPICmd represents the openapi command
+var OpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "print the OpenAPI document of the HTTP gateway",
+	Long: `Prints the OpenAPI document of the HTTP gateway, which is built from proto/routemanager.proto. The same document
+is served by the gateway at /v1/openapi.json and shipped as proto/routemanager.openapi.json.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := gateway.OpenAPI()
+		if err != nil {
+			return err
+		}
+
+		_, err = cmd.OutOrStdout().Write(doc)
+
+		return err
+	},
+}
@@ -3,9 +3,17 @@ package options
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/paths"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/subscription"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/pkg/errors"
@@ -14,22 +22,118 @@ import (
 
 var rootOptions = &RootOptions{}
 
+// settings maps the keys of the settings to their flags, environment variables are derived from the flag names such
+// as STT_DNS_SERVERS. Keys of the settings that can be set in the config file are the same with the config keys
+var settings = map[string]string{
+	"workspace":           "workspace",
+	"config-file":         "config-file",
+	"socket-path":         "socket-path",
+	"grpc-socket-path":    "grpc-socket-path",
+	"state-path":          "state-path",
+	"dnsservers":          "dns-servers",
+	"dnsstrategy":         "dns-strategy",
+	"dnsqueries":          "dns-queries",
+	"checkintervalmin":    "check-interval-min",
+	"verbose":             "verbose",
+	"routemode":           "route-mode",
+	"graceperiodmin":      "grace-period-min",
+	"accumulatewindowmin": "accumulate-window-min",
+	"accumulatemaxips":    "accumulate-max-ips",
+	"grpcreflection":      "grpc-reflection",
+	"grpctimeoutsec":      "grpc-timeout-sec",
+	"gatewaysocketpath":   "gateway-socket-path",
+	"gatewayaddress":      "gateway-address",
+}
+
 type RootOptions struct {
-	// Workspace is the directory path where the application will store its data
-	Workspace string
-	// ConfigFile is the path of the configuration file, which will be searched in the Workspace
-	ConfigFile string
-	// SocketPath is the path of the socket file, which will be stored in the Workspace
-	SocketPath string
-	// StatePath is the path of the state file, which will be stored in the Workspace
-	StatePath string
+	// Workspace is the directory path where the application will store its data, system-wide locations are used if
+	// it is empty
+	Workspace string `toml:"-" mapstructure:"-"`
+	// ConfigFile is the path of the configuration file, the system-wide and user config files are layered if it is
+	// empty. Relative paths are searched in the Workspace
+	ConfigFile string `toml:"-" mapstructure:"-"`
+	// ConfigFiles are the resolved configuration files in increasing precedence, only the existing ones are read
+	ConfigFiles []string `toml:"-" mapstructure:"-"`
+	// SocketPath is the path of the IPC socket file
+	SocketPath string `toml:"-" mapstructure:"-"`
+	// GrpcSocketPath is the path of the gRPC socket file
+	GrpcSocketPath string `toml:"-" mapstructure:"-"`
+	// StatePath is the path of the state file
+	StatePath string `toml:"-" mapstructure:"-"`
 
 	// DnsServers is the list of DNS servers to be used for DNS resolving
 	DnsServers string `toml:"dnsservers"`
+	// DnsStrategy is how the answers of the DNS servers are combined, one of first, union and majority
+	DnsStrategy string `toml:"dnsstrategy"`
+	// DnsQueries is the number of the queries that are sent to each DNS server on a single resolution, 0 means 1
+	DnsQueries int `toml:"dnsqueries"`
 	// CheckIntervalMin is the interval in minutes to check the routing table with the collected state.State
 	CheckIntervalMin int `toml:"checkintervalmin"`
 	// Verbose is the flag to enable verbose logging output
 	Verbose bool `toml:"verbose"`
+	// RouteMode is how the failures of the routes of a single entry are handled, either transactional or best-effort
+	RouteMode string `toml:"routemode"`
+	// GracePeriodMin is the duration in minutes that the IPs of an entry are kept routed after they are last resolved
+	GracePeriodMin int `toml:"graceperiodmin"`
+	// AccumulateWindowMin is the duration in minutes that the IPs of the accumulating entries are kept routed after
+	// they are last resolved
+	AccumulateWindowMin int `toml:"accumulatewindowmin"`
+	// AccumulateMaxIPs is the maximum number of the IPs that an accumulating entry keeps routed, 0 means no limit
+	AccumulateMaxIPs int `toml:"accumulatemaxips"`
+	// GrpcReflection registers the gRPC server reflection service, for the tools such as grpcurl
+	GrpcReflection bool `toml:"grpcreflection"`
+	// GrpcTimeoutSec is the deadline in seconds of the gRPC calls that come without one, 0 disables it
+	GrpcTimeoutSec int `toml:"grpctimeoutsec"`
+	// GatewaySocketPath is the path of the unix socket of the HTTP/JSON gateway, the gateway is not served on a socket
+	// if it is empty
+	GatewaySocketPath string `toml:"gatewaysocketpath"`
+	// GatewayAddress is the loopback host:port of the HTTP/JSON gateway, the gateway is not served on a port if it is
+	// empty. Only the read-only endpoints are served on the port
+	GatewayAddress string `toml:"gatewayaddress"`
+	// Routes is the declarative list of domains and CIDRs that the state.State is converged to
+	Routes []*RouteConfig `toml:"routes"`
+	// Groups is the declarative list of groups that the state.State is converged to
+	Groups []*GroupConfig `toml:"groups"`
+	// Subscriptions is the list of the external lists whose destinations are synced into their managed groups
+	Subscriptions []*SubscriptionConfig `toml:"subscriptions"`
+
+	// flags are the flags of the root command, which take precedence over the environment variables and config files
+	flags *pflag.FlagSet
+}
+
+// RouteConfig is a single domain or CIDR declared in the config file
+type RouteConfig struct {
+	// Destination is the domain or CIDR to bypass VPN
+	Destination string `toml:"destination"`
+	// Group is the name of the group that the destination belongs to, optional
+	Group string `toml:"group"`
+	// Accumulate keeps the IPs of the destination that are resolved within the accumulation window routed, optional
+	Accumulate bool `toml:"accumulate"`
+}
+
+// GroupConfig is a group of domains and CIDRs declared in the config file
+type GroupConfig struct {
+	// Name is the name of the group
+	Name string `toml:"name"`
+	// Enabled is the desired status of the group, the status is left to the CLI if it is not set
+	Enabled *bool `toml:"enabled"`
+	// Destinations are the domains and CIDRs that belong to the group
+	Destinations []string `toml:"destinations"`
+}
+
+// SubscriptionConfig is an external list of domains and CIDRs declared in the config file
+type SubscriptionConfig struct {
+	// Name is the unique name of the subscription
+	Name string `toml:"name"`
+	// URL is an HTTP(S) URL, a file:// URL or the absolute path of a local file
+	URL string `toml:"url"`
+	// Format is the format of the list such as text or json, it is detected from the URL and the content if it is
+	// empty
+	Format string `toml:"format"`
+	// Group is the managed group of the destinations of the list, defaults to the name of the subscription
+	Group string `toml:"group"`
+	// IntervalMin is the interval in minutes to sync the list, 0 means an hour
+	IntervalMin int `toml:"intervalmin"`
 }
 
 // GetRootOptions returns the pointer of RootOptions
@@ -43,44 +147,260 @@ func (opts *RootOptions) InitFlags(cmd *cobra.Command) error {
 		return errors.Wrap(err, "failed to set flags")
 	}
 
-	if err := viper.BindPFlags(cmd.Flags()); err != nil {
-		return errors.Wrap(err, "failed to bind flags")
-	}
+	opts.flags = cmd.PersistentFlags()
 
 	return nil
 }
 
 // setFlags sets the flags of the root command
 func (opts *RootOptions) setFlags(cmd *cobra.Command) error {
-	homeDir, err := os.UserHomeDir()
+	cmd.PersistentFlags().StringVarP(&opts.Workspace, "workspace", "w", "", "workspace directory path to keep config, state and sockets together, system-wide locations are used if not set")
+	cmd.PersistentFlags().StringVarP(&opts.ConfigFile, "config-file", "c", "", "config file path, system-wide and user config files are layered if not set")
+	cmd.PersistentFlags().StringVarP(&opts.SocketPath, "socket-path", "", "", "IPC socket path, defaults to "+filepath.Join(paths.RuntimeDir, constants.SocketFileName))
+	cmd.PersistentFlags().StringVarP(&opts.GrpcSocketPath, "grpc-socket-path", "", "", "gRPC socket path, defaults to "+filepath.Join(paths.RuntimeDir, constants.GrpcSocketFileName))
+	cmd.PersistentFlags().StringVarP(&opts.StatePath, "state-path", "", "", "state file path, defaults to "+filepath.Join(paths.StateDir, constants.StateFileName))
+	cmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "", false, "verbose logging output")
+	cmd.PersistentFlags().StringVarP(&opts.DnsServers, "dns-servers", "", "", "comma separated dns servers to be used for DNS resolving")
+	cmd.PersistentFlags().StringVarP(&opts.DnsStrategy, "dns-strategy", "", string(utils.ResolveStrategyFirst), "how the answers of the dns servers are combined, first uses the first answering server, union and majority query all of them")
+	cmd.PersistentFlags().IntVarP(&opts.DnsQueries, "dns-queries", "", 1, "number of the queries that are sent to each dns server on a single resolution, to collect the IPs of the rotating answers")
+	cmd.PersistentFlags().IntVarP(&opts.CheckIntervalMin, "check-interval-min", "", 5, "routing table check interval with collected state, in minutes")
+	cmd.PersistentFlags().IntVarP(&opts.GracePeriodMin, "grace-period-min", "", 10, "duration in minutes that the IPs of an entry are kept routed after they are last resolved, 0 disables it")
+	cmd.PersistentFlags().IntVarP(&opts.AccumulateWindowMin, "accumulate-window-min", "", 1440, "duration in minutes that the IPs of the accumulating entries are kept routed after they are last resolved")
+	cmd.PersistentFlags().IntVarP(&opts.AccumulateMaxIPs, "accumulate-max-ips", "", 64, "maximum number of the IPs that an accumulating entry keeps routed, 0 means no limit")
+	cmd.PersistentFlags().BoolVarP(&opts.GrpcReflection, "grpc-reflection", "", false, "register the gRPC server reflection service, for the tools such as grpcurl")
+	cmd.PersistentFlags().IntVarP(&opts.GrpcTimeoutSec, "grpc-timeout-sec", "", 30, "deadline in seconds of the gRPC calls that come without one, 0 disables it")
+	cmd.PersistentFlags().StringVarP(&opts.GatewaySocketPath, "gateway-socket-path", "", "", "unix socket path of the HTTP/JSON gateway, the gateway is not served on a socket if not set")
+	cmd.PersistentFlags().StringVarP(&opts.GatewayAddress, "gateway-address", "", "", "loopback host:port of the read-only endpoints of the HTTP/JSON gateway such as 127.0.0.1:8080, the gateway is not served on a port if not set")
+	cmd.PersistentFlags().StringVarP(&opts.RouteMode, "route-mode", "", string(state.RouteModeTransactional), "handling of the route failures of an entry, transactional rolls back all routes of the entry and best-effort keeps the ones that succeed")
+
+	return nil
+}
+
+// ReadConfig resolves the settings in the order of flags, STT_* environment variables, system-wide config file, user
+// config file and defaults, and unmarshalls them into RootOptions
+func (opts *RootOptions) ReadConfig() error {
+	next, err := opts.load()
 	if err != nil {
-		return errors.Wrap(err, "failed to get user home directory")
+		return err
 	}
 
-	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", filepath.Join(homeDir, ".split-the-tunnel"), "workspace directory path")
-	cmd.Flags().StringVarP(&opts.ConfigFile, "config-file", "c", "config.toml", "config file path, will search in workspace")
-	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "", false, "verbose logging output")
-	cmd.Flags().StringVarP(&opts.DnsServers, "dns-servers", "", "", "comma separated dns servers to be used for DNS resolving")
-	cmd.Flags().IntVarP(&opts.CheckIntervalMin, "check-interval-min", "", 5, "routing table check interval with collected state, in minutes")
+	*opts = *next
 
 	return nil
 }
 
-// ReadConfig reads the configuration file and unmarshalls it into RootOptions
-func (opts *RootOptions) ReadConfig() error {
-	viper.SetConfigType("toml")
-	viper.SetConfigFile(filepath.Join(opts.Workspace, opts.ConfigFile))
-	if err := viper.ReadInConfig(); err != nil {
-		return errors.Wrap(err, "failed to read config file")
+// Reload resolves the settings again into a copy of RootOptions and validates them. The receiver is not modified, so
+// that the running configuration stays active if the new one is invalid
+func (opts *RootOptions) Reload() (*RootOptions, error) {
+	return opts.load()
+}
+
+// ResolveConfigFiles returns the config files that are read by ReadConfig, in increasing precedence
+func (opts *RootOptions) ResolveConfigFiles() ([]string, error) {
+	v := opts.newViper()
+	next := *opts
+	next.resolvePaths(v)
+
+	return next.existingConfigFiles()
+}
+
+// load resolves the settings into a copy of RootOptions
+func (opts *RootOptions) load() (*RootOptions, error) {
+	v := opts.newViper()
+	next := *opts
+	next.resolvePaths(v)
+
+	configFiles, err := next.existingConfigFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	v.SetConfigType("toml")
+	for _, configFile := range configFiles {
+		if err := next.ValidateFile(configFile); err != nil {
+			return nil, errors.Wrapf(err, "invalid config file %s", configFile)
+		}
+
+		v.SetConfigFile(configFile)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read config file %s", configFile)
+		}
 	}
 
-	if err := viper.Unmarshal(opts); err != nil {
-		return errors.Wrap(err, "failed to unmarshal config file")
+	// declarations which are removed from the config file should not survive from the previous read
+	next.Routes, next.Groups, next.Subscriptions = nil, nil, nil
+	if err := v.Unmarshal(&next); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config file")
 	}
 
-	opts.ConfigFile = filepath.Join(opts.Workspace, opts.ConfigFile)
-	opts.StatePath = filepath.Join(opts.Workspace, constants.StateFileName)
-	opts.SocketPath = filepath.Join(opts.Workspace, constants.SocketFileName)
+	// flags and environment variables are not validated by ValidateFile
+	if verrs := next.Validate(); len(verrs) > 0 {
+		return nil, errors.Wrap(verrs, "invalid settings")
+	}
 
-	return nil
+	return &next, nil
+}
+
+// newViper returns a new viper instance which is bound to the flags and the environment variables of the settings.
+// A new instance is used on every load, so that the settings that are removed from the config files do not survive
+func (opts *RootOptions) newViper() *viper.Viper {
+	v := viper.New()
+	for key, flag := range settings {
+		if opts.flags != nil {
+			if f := opts.flags.Lookup(flag); f != nil {
+				_ = v.BindPFlag(key, f)
+			}
+		}
+
+		_ = v.BindEnv(key, paths.EnvName(flag))
+	}
+
+	return v
+}
+
+// resolvePaths resolves the paths of the files from the given viper instance and the defaults of the paths package
+func (opts *RootOptions) resolvePaths(v *viper.Viper) {
+	// fields are kept if they are set without flags, such as in tests
+	opts.Workspace = firstNonEmpty(v.GetString("workspace"), opts.Workspace)
+	opts.ConfigFile = firstNonEmpty(v.GetString("config-file"), opts.ConfigFile)
+
+	defaults := paths.Resolve(opts.Workspace)
+	opts.ConfigFiles = defaults.ConfigFiles
+	if opts.ConfigFile != "" {
+		configFile := opts.ConfigFile
+		if opts.Workspace != "" && !filepath.IsAbs(configFile) {
+			configFile = filepath.Join(opts.Workspace, configFile)
+		}
+
+		opts.ConfigFiles = []string{configFile}
+	}
+
+	opts.SocketPath = firstNonEmpty(v.GetString("socket-path"), opts.SocketPath, defaults.SocketPath)
+	opts.GrpcSocketPath = firstNonEmpty(v.GetString("grpc-socket-path"), opts.GrpcSocketPath, defaults.GrpcSocketPath)
+	opts.StatePath = firstNonEmpty(v.GetString("state-path"), opts.StatePath, defaults.StatePath)
+}
+
+// existingConfigFiles returns the resolved config files that exist. A config file that is given explicitly must exist,
+// the layered ones are optional
+func (opts *RootOptions) existingConfigFiles() ([]string, error) {
+	var existing []string
+	for _, configFile := range opts.ConfigFiles {
+		if _, err := os.Stat(configFile); err != nil {
+			if os.IsNotExist(err) && opts.ConfigFile == "" {
+				continue
+			}
+
+			return nil, errors.Wrap(err, "failed to read config file")
+		}
+
+		existing = append(existing, configFile)
+	}
+
+	return existing, nil
+}
+
+// DNSServerList returns the DNS servers as a list
+func (opts *RootOptions) DNSServerList() []string {
+	var servers []string
+	for _, server := range strings.Split(opts.DnsServers, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+
+	return servers
+}
+
+// Declaration returns the declared routes and groups as a state.Declaration. Groups that are referenced by the routes
+// are declared implicitly, a destination that is declared more than once takes the group of the last declaration
+func (opts *RootOptions) Declaration() *state.Declaration {
+	decl := new(state.Declaration)
+	groups := make(map[string]*state.DeclaredGroup)
+	entries := make(map[string]*state.RouteEntry)
+
+	declareGroup := func(name string, enabled *bool) {
+		if group, ok := groups[name]; ok {
+			if enabled != nil {
+				group.Enabled = enabled
+			}
+
+			return
+		}
+
+		groups[name] = &state.DeclaredGroup{Name: name, Enabled: enabled}
+		decl.Groups = append(decl.Groups, groups[name])
+	}
+
+	declareEntry := func(destination, group string) *state.RouteEntry {
+		if entry, ok := entries[destination]; ok {
+			entry.Group = group
+			return entry
+		}
+
+		entries[destination] = &state.RouteEntry{Domain: destination, Group: group}
+		decl.Entries = append(decl.Entries, entries[destination])
+
+		return entries[destination]
+	}
+
+	for _, group := range opts.Groups {
+		declareGroup(group.Name, group.Enabled)
+		for _, destination := range group.Destinations {
+			declareEntry(destination, group.Name)
+		}
+	}
+
+	for _, route := range opts.Routes {
+		if route.Group != "" {
+			declareGroup(route.Group, nil)
+		}
+
+		declareEntry(route.Destination, route.Group).Accumulate = route.Accumulate
+	}
+
+	return decl
+}
+
+// PresetFiles returns the override files of the preset catalog in the order of ConfigFiles, one next to every config
+// file. The files that do not exist are skipped when the catalog is loaded
+func (opts *RootOptions) PresetFiles() []string {
+	files := make([]string, 0, len(opts.ConfigFiles))
+	for _, configFile := range opts.ConfigFiles {
+		files = append(files, filepath.Join(filepath.Dir(configFile), constants.PresetsFileName))
+	}
+
+	return files
+}
+
+// SubscriptionConfigs returns the declared subscriptions as subscription.Configs, with their defaults applied
+func (opts *RootOptions) SubscriptionConfigs() []*subscription.Config {
+	configs := make([]*subscription.Config, 0, len(opts.Subscriptions))
+	for _, sub := range opts.Subscriptions {
+		cfg := &subscription.Config{
+			Name:     sub.Name,
+			URL:      sub.URL,
+			Format:   bypasslist.Format(sub.Format),
+			Group:    firstNonEmpty(sub.Group, sub.Name),
+			Interval: time.Duration(sub.IntervalMin) * time.Minute,
+		}
+
+		if cfg.Interval == 0 {
+			cfg.Interval = constants.DefaultSubscriptionInterval
+		}
+
+		configs = append(configs, cfg)
+	}
+
+	return configs
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
 }
@@ -1,9 +1,15 @@
 package options
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/subscription"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +23,238 @@ func TestRootOptions_InitFlags(t *testing.T) {
 	opts := GetRootOptions()
 	assert.NoError(t, opts.InitFlags(&cmd))
 }
+
+func TestRootOptions_ReadConfig_Layering(t *testing.T) {
+	workspace := t.TempDir()
+	config := `
+dnsservers = "8.8.8.8"
+checkintervalmin = 10
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "config.toml"), []byte(config), 0644))
+
+	t.Setenv("STT_WORKSPACE", workspace)
+	t.Setenv("STT_DNS_SERVERS", "1.1.1.1")
+	t.Setenv("STT_CHECK_INTERVAL_MIN", "3")
+
+	cmd := &cobra.Command{}
+	opts := &RootOptions{}
+	assert.NoError(t, opts.InitFlags(cmd))
+	assert.NoError(t, cmd.PersistentFlags().Set("check-interval-min", "7"))
+
+	assert.NoError(t, opts.ReadConfig())
+	assert.Equal(t, []string{filepath.Join(workspace, "config.toml")}, opts.ConfigFiles)
+	assert.Equal(t, []string{filepath.Join(workspace, "presets.toml")}, opts.PresetFiles())
+	assert.Equal(t, filepath.Join(workspace, "ipc.sock"), opts.SocketPath)
+	assert.Equal(t, filepath.Join(workspace, "grpc.sock"), opts.GrpcSocketPath)
+	// environment variables take precedence over the config file and flags over the environment variables
+	assert.Equal(t, "1.1.1.1", opts.DnsServers)
+	assert.Equal(t, 7, opts.CheckIntervalMin)
+}
+
+func TestRootOptions_Declaration(t *testing.T) {
+	viper.Reset()
+	workspace := t.TempDir()
+	config := `
+dnsservers = "8.8.8.8"
+checkintervalmin = 1
+
+[[routes]]
+destination = "example.com"
+
+[[routes]]
+destination = "10.0.0.0/8"
+
+[[routes]]
+destination = "slack.com"
+group = "chat"
+accumulate = true
+
+[[groups]]
+name = "meetings"
+enabled = false
+destinations = ["zoom.us", "example.com"]
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "config.toml"), []byte(config), 0644))
+
+	opts := &RootOptions{Workspace: workspace, ConfigFile: "config.toml"}
+	assert.NoError(t, opts.ReadConfig())
+	assert.Len(t, opts.Routes, 3)
+	assert.Len(t, opts.Groups, 1)
+
+	decl := opts.Declaration()
+	assert.Len(t, decl.Groups, 2)
+	assert.Equal(t, "meetings", decl.Groups[0].Name)
+	assert.False(t, *decl.Groups[0].Enabled)
+	assert.Equal(t, "chat", decl.Groups[1].Name)
+	assert.Nil(t, decl.Groups[1].Enabled)
+
+	domains := make(map[string]string)
+	for _, entry := range decl.Entries {
+		domains[entry.Domain] = entry.Group
+		assert.Equal(t, entry.Domain == "slack.com", entry.Accumulate, entry.Domain)
+	}
+
+	// example.com is declared twice, the last declaration wins
+	assert.Equal(t, map[string]string{"zoom.us": "meetings", "example.com": "", "10.0.0.0/8": "", "slack.com": "chat"}, domains)
+}
+
+func TestRootOptions_SubscriptionConfigs(t *testing.T) {
+	viper.Reset()
+	workspace := t.TempDir()
+	config := `
+checkintervalmin = 1
+
+[[subscriptions]]
+name = "microsoft"
+url = "https://example.com/m365.txt"
+
+[[subscriptions]]
+name = "office"
+url = "/etc/split-the-tunnel/office.json"
+format = "json"
+group = "office-ranges"
+intervalmin = 15
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "config.toml"), []byte(config), 0644))
+
+	opts := &RootOptions{Workspace: workspace, ConfigFile: "config.toml"}
+	assert.NoError(t, opts.ReadConfig())
+
+	configs := opts.SubscriptionConfigs()
+	if assert.Len(t, configs, 2) {
+		assert.Equal(t, &subscription.Config{Name: "microsoft", URL: "https://example.com/m365.txt", Group: "microsoft",
+			Interval: constants.DefaultSubscriptionInterval}, configs[0])
+		assert.Equal(t, &subscription.Config{Name: "office", URL: "/etc/split-the-tunnel/office.json", Format: "json",
+			Group: "office-ranges", Interval: 15 * time.Minute}, configs[1])
+	}
+}
+
+func TestRootOptions_Reload(t *testing.T) {
+	viper.Reset()
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, "config.toml")
+	assert.NoError(t, os.WriteFile(path, []byte("dnsservers = \"8.8.8.8\"\ncheckintervalmin = 5\n"), 0644))
+
+	opts := &RootOptions{Workspace: workspace, ConfigFile: "config.toml"}
+	assert.NoError(t, opts.ReadConfig())
+
+	assert.NoError(t, os.WriteFile(path, []byte("dnsservers = \"1.1.1.1, 9.9.9.9:5353\"\ncheckintervalmin = 1\nverbose = true\n"), 0644))
+	next, err := opts.Reload()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"1.1.1.1", "9.9.9.9:5353"}, next.DNSServerList())
+	assert.Equal(t, 1, next.CheckIntervalMin)
+	assert.True(t, next.Verbose)
+	// running config should not be touched by Reload
+	assert.Equal(t, 5, opts.CheckIntervalMin)
+
+	for _, invalid := range []string{
+		"checkintervalmin = 0\n",
+		"dnsservers = \"not-an-ip\"\ncheckintervalmin = 1\n",
+		"checkintervalmin = 1\n[[routes]]\ngroup = \"chat\"\n",
+		"checkintervalmin = ",
+	} {
+		assert.NoError(t, os.WriteFile(path, []byte(invalid), 0644))
+		_, err := opts.Reload()
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRootOptions_ValidateFile(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"valid", "dnsservers = \"8.8.8.8\"\ncheckintervalmin = 1\n[[routes]]\ndestination = \"www.example.com\"\n", ""},
+		{"missing keys take the current values", "verbose = true\n", ""},
+		{"unknown key", "dnsserver = \"8.8.8.8\"\ncheckintervalmin = 1\n", "line 1: dnsserver: unknown key"},
+		{"unknown nested key", "[[routes]]\ndestination = \"example.com\"\ngrop = \"chat\"\n", "line 3: routes.grop: unknown key"},
+		{"invalid dns server", "checkintervalmin = 1\ndnsservers = \"8.8.8.8,8.8.4\"\n", "line 2: dnsservers: invalid dns server \"8.8.4\""},
+		{"zero interval", "\ncheckintervalmin = 0\n", "line 2: checkintervalmin: must be a positive number of minutes, got 0"},
+		{"invalid dns strategy", "dnsstrategy = \"all\"\n", "line 1: dnsstrategy: must be \"first\", \"union\" or \"majority\", got \"all\""},
+		{"negative dns queries", "dnsqueries = -1\n", "line 1: dnsqueries: cannot be negative, got -1"},
+		{"negative grace period", "graceperiodmin = -1\n", "line 1: graceperiodmin: cannot be negative, got -1"},
+		{"negative accumulate max ips", "accumulatemaxips = -5\n", "line 1: accumulatemaxips: cannot be negative, got -5"},
+		{"negative grpc timeout", "grpctimeoutsec = -1\n", "line 1: grpctimeoutsec: cannot be negative, got -1"},
+		{"non-loopback gateway address", "gatewayaddress = \"0.0.0.0:8080\"\n", "line 1: gatewayaddress: must be a loopback host:port such as 127.0.0.1:8080, got \"0.0.0.0:8080\""},
+		{"invalid route mode", "routemode = \"strict\"\n", "line 1: routemode: must be \"transactional\" or \"best-effort\", got \"strict\""},
+		{
+			"invalid destination",
+			"[[routes]]\ndestination = \"example.com\"\n\n[[routes]]\ndestination = \"not a domain\"\n",
+			"line 5: routes[1].destination: invalid destination \"not a domain\", must be a domain, an IP address or a CIDR block",
+		},
+		{
+			"wildcard destination",
+			"[[routes]]\ndestination = \"*.example.com\"\n",
+			"line 2: routes[0].destination: invalid destination \"*.example.com\", must be a domain, an IP address or a CIDR block",
+		},
+		{"duplicate group", "[[groups]]\nname = \"chat\"\n[[groups]]\nname = \"chat\"\n", "line 4: groups[1].name: group \"chat\" is declared more than once"},
+		{"valid subscription", "[[subscriptions]]\nname = \"microsoft\"\nurl = \"file:///etc/m365.txt\"\nformat = \"text\"\n", ""},
+		{"invalid subscription url", "[[subscriptions]]\nname = \"microsoft\"\nurl = \"m365.txt\"\n", "line 3: subscriptions[0].url: invalid url \"m365.txt\""},
+		{"invalid subscription format", "[[subscriptions]]\nname = \"microsoft\"\nurl = \"https://example.com\"\nformat = \"xml\"\n", "line 4: subscriptions[0].format: unknown list format \"xml\""},
+		{
+			"duplicate subscription",
+			"[[subscriptions]]\nname = \"microsoft\"\nurl = \"https://example.com/a\"\n[[subscriptions]]\nname = \"microsoft\"\nurl = \"https://example.com/b\"\n",
+			"line 5: subscriptions[1].name: subscription \"microsoft\" is declared more than once",
+		},
+		{
+			"declared managed group",
+			"[[groups]]\nname = \"chat\"\n[[subscriptions]]\nname = \"slack\"\nurl = \"https://example.com\"\ngroup = \"chat\"\n",
+			"line 6: subscriptions[0].group: group \"chat\" is declared in the config file",
+		},
+		{"wrong type", "checkintervalmin = \"5\"\n", "line 1: "},
+		{"syntax error", "checkintervalmin = \n", "line 1: "},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.config), 0644))
+
+			opts := &RootOptions{CheckIntervalMin: 5}
+			err := opts.ValidateFile(path)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var verrs ValidationErrors
+			assert.ErrorAs(t, err, &verrs)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestRootOptions_WatchConfig(t *testing.T) {
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, "config.toml")
+	opts := &RootOptions{ConfigFiles: []string{path}}
+
+	changes := make(chan string, 10)
+	assert.NoError(t, opts.watchConfig(200*time.Millisecond, func(file string) {
+		changes <- file
+	}))
+
+	// an editor writes a temporary file and renames it over the config file
+	temp := filepath.Join(workspace, "config.toml.swp")
+	assert.NoError(t, os.WriteFile(path, []byte("checkintervalmin = 5\n"), 0644))
+	assert.NoError(t, os.WriteFile(temp, []byte("checkintervalmin = 1\n"), 0644))
+	assert.NoError(t, os.Rename(temp, path))
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "other.toml"), nil, 0644))
+
+	select {
+	case file := <-changes:
+		assert.Equal(t, path, file)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "config change is not reported")
+	}
+
+	select {
+	case file := <-changes:
+		assert.Fail(t, "config change is reported more than once", file)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,344 @@
+package options
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+)
+
+// ValidationError is a single problem of a configuration file
+type ValidationError struct {
+	// Line is the 1-indexed line of the problem in the file, 0 if the line is not known
+	Line int
+	// Key is the dotted path of the problematic key, such as routes[1].destination
+	Key     string
+	Message string
+}
+
+// Error returns the line-numbered description of the ValidationError
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Description())
+	}
+
+	return e.Description()
+}
+
+// Description returns the description of the ValidationError without its line number
+func (e *ValidationError) Description() string {
+	if e.Key != "" {
+		return e.Key + ": " + e.Message
+	}
+
+	return e.Message
+}
+
+// ValidationErrors is the list of all the problems of a configuration file
+type ValidationErrors []*ValidationError
+
+// Error returns the descriptions of all the problems, one per line
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// ValidateFile validates the configuration file at the given path without touching RootOptions. Unknown keys, values
+// of a wrong type and invalid values are reported as ValidationErrors with their line numbers. Keys that are missing
+// in the file are validated with the current values of RootOptions
+func (opts *RootOptions) ValidateFile(path string) error {
+	doc, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read config file")
+	}
+
+	candidate := *opts
+	candidate.Routes, candidate.Groups, candidate.Subscriptions = nil, nil, nil
+
+	// unknown keys do not stop the decoding, values of the known keys are still validated
+	var verrs ValidationErrors
+	decoder := toml.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&candidate); err != nil {
+		var strictErr *toml.StrictMissingError
+		if !errors.As(err, &strictErr) {
+			return decodeErrors(err)
+		}
+
+		verrs = decodeErrors(err)
+	}
+
+	lines := keyLines(doc)
+	for _, verr := range candidate.Validate() {
+		verr.Line = lines[verr.Key]
+		verrs = append(verrs, verr)
+	}
+
+	if len(verrs) == 0 {
+		return nil
+	}
+
+	return verrs
+}
+
+// Validate checks the values of RootOptions, it returns nil if there are no problems
+func (opts *RootOptions) Validate() ValidationErrors {
+	var verrs ValidationErrors
+	invalid := func(key, format string, args ...interface{}) {
+		verrs = append(verrs, &ValidationError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if opts.CheckIntervalMin <= 0 {
+		invalid("checkintervalmin", "must be a positive number of minutes, got %d", opts.CheckIntervalMin)
+	}
+
+	for _, server := range opts.DNSServerList() {
+		host, _, err := net.SplitHostPort(utils.DNSServerAddress(server))
+		if err != nil || net.ParseIP(host) == nil {
+			invalid("dnsservers", "invalid dns server %q", server)
+		}
+	}
+
+	if !utils.ResolveStrategy(opts.DnsStrategy).Valid() {
+		invalid("dnsstrategy", "must be %q, %q or %q, got %q", utils.ResolveStrategyFirst, utils.ResolveStrategyUnion,
+			utils.ResolveStrategyMajority, opts.DnsStrategy)
+	}
+
+	if opts.DnsQueries < 0 {
+		invalid("dnsqueries", "cannot be negative, got %d", opts.DnsQueries)
+	}
+
+	if opts.GracePeriodMin < 0 {
+		invalid("graceperiodmin", "cannot be negative, got %d", opts.GracePeriodMin)
+	}
+
+	if opts.AccumulateWindowMin < 0 {
+		invalid("accumulatewindowmin", "cannot be negative, got %d", opts.AccumulateWindowMin)
+	}
+
+	if opts.AccumulateMaxIPs < 0 {
+		invalid("accumulatemaxips", "cannot be negative, got %d", opts.AccumulateMaxIPs)
+	}
+
+	if opts.GrpcTimeoutSec < 0 {
+		invalid("grpctimeoutsec", "cannot be negative, got %d", opts.GrpcTimeoutSec)
+	}
+
+	if opts.GatewayAddress != "" && !isLoopbackAddress(opts.GatewayAddress) {
+		invalid("gatewayaddress", "must be a loopback host:port such as 127.0.0.1:8080, got %q", opts.GatewayAddress)
+	}
+
+	if !state.RouteMode(opts.RouteMode).Valid() {
+		invalid("routemode", "must be %q or %q, got %q", state.RouteModeTransactional, state.RouteModeBestEffort, opts.RouteMode)
+	}
+
+	for i, route := range opts.Routes {
+		key := fmt.Sprintf("routes[%d]", i)
+		if !utils.IsValidDestination(route.Destination) {
+			invalid(key+".destination", "invalid destination %q, must be a domain, an IP address or a CIDR block", route.Destination)
+		}
+	}
+
+	groups := make(map[string]bool)
+	for i, group := range opts.Groups {
+		key := fmt.Sprintf("groups[%d]", i)
+		if group.Name == "" {
+			invalid(key, "name cannot be empty")
+		} else if groups[group.Name] {
+			invalid(key+".name", "group %q is declared more than once", group.Name)
+		}
+
+		groups[group.Name] = true
+
+		for _, destination := range group.Destinations {
+			if !utils.IsValidDestination(destination) {
+				invalid(key+".destinations", "invalid destination %q, must be a domain, an IP address or a CIDR block", destination)
+			}
+		}
+	}
+
+	for _, route := range opts.Routes {
+		groups[route.Group] = true
+	}
+
+	// managed groups are synced by their subscriptions only, they cannot be declared or shared
+	names := make(map[string]bool)
+	managed := make(map[string]bool)
+	for i, sub := range opts.Subscriptions {
+		key := fmt.Sprintf("subscriptions[%d]", i)
+		if sub.Name == "" {
+			invalid(key, "name cannot be empty")
+		} else if names[sub.Name] {
+			invalid(key+".name", "subscription %q is declared more than once", sub.Name)
+		}
+
+		names[sub.Name] = true
+
+		if !isListURL(sub.URL) {
+			invalid(key+".url", "invalid url %q, must be an http(s) or file:// url or an absolute path", sub.URL)
+		}
+
+		if sub.Format != "" {
+			if _, err := bypasslist.ParseFormat(sub.Format); err != nil {
+				invalid(key+".format", "%s", err.Error())
+			}
+		}
+
+		if sub.IntervalMin < 0 {
+			invalid(key+".intervalmin", "cannot be negative, got %d", sub.IntervalMin)
+		}
+
+		group := sub.Group
+		if group == "" {
+			group = sub.Name
+		}
+
+		switch {
+		case group == "":
+		case groups[group]:
+			invalid(key+".group", "group %q is declared in the config file, a managed group cannot be declared", group)
+		case managed[group]:
+			invalid(key+".group", "group %q is managed by another subscription", group)
+		}
+
+		managed[group] = true
+	}
+
+	return verrs
+}
+
+// decodeErrors converts the errors of the strict TOML decoder into ValidationErrors
+func decodeErrors(err error) ValidationErrors {
+	var strictErr *toml.StrictMissingError
+	if errors.As(err, &strictErr) {
+		verrs := make(ValidationErrors, 0, len(strictErr.Errors))
+		for i := range strictErr.Errors {
+			line, _ := strictErr.Errors[i].Position()
+			verrs = append(verrs, &ValidationError{
+				Line:    line,
+				Key:     strings.Join(strictErr.Errors[i].Key(), "."),
+				Message: "unknown key",
+			})
+		}
+
+		return verrs
+	}
+
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		line, _ := decodeErr.Position()
+		return ValidationErrors{{Line: line, Message: strings.TrimPrefix(decodeErr.Error(), "toml: ")}}
+	}
+
+	return ValidationErrors{{Message: strings.TrimPrefix(err.Error(), "toml: ")}}
+}
+
+// keyLines returns the line numbers of the keys in the given document. Keys of the array tables are indexed, such as
+// routes[1].destination
+func keyLines(doc []byte) map[string]int {
+	lines := make(map[string]int)
+	arrayCounts := make(map[string]int)
+	lineOf := func(node *unstable.Node) int {
+		return bytes.Count(doc[:node.Raw.Offset], []byte("\n")) + 1
+	}
+
+	var table string
+	parser := unstable.Parser{}
+	parser.Reset(doc)
+	for parser.NextExpression() {
+		expr := parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			name, first := joinKey(expr.Key())
+			table = name
+			if expr.Kind == unstable.ArrayTable {
+				table = fmt.Sprintf("%s[%d]", name, arrayCounts[name])
+				arrayCounts[name]++
+			}
+
+			lines[table] = lineOf(first)
+		case unstable.KeyValue:
+			name, first := joinKey(expr.Key())
+			if table != "" {
+				name = table + "." + name
+			}
+
+			lines[name] = lineOf(first)
+		}
+	}
+
+	return lines
+}
+
+// joinKey returns the dotted form of the key and its first part
+func joinKey(it unstable.Iterator) (string, *unstable.Node) {
+	var parts []string
+	var first *unstable.Node
+	for it.Next() {
+		if first == nil {
+			first = it.Node()
+		}
+
+		parts = append(parts, string(it.Node().Data))
+	}
+
+	return strings.Join(parts, "."), first
+}
+
+// isListURL reports whether the given URL is an http(s) or file:// URL, or an absolute path of a local file
+func isListURL(rawURL string) bool {
+	if filepath.IsAbs(rawURL) {
+		return true
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return u.Host != ""
+	case "file":
+		return filepath.IsAbs(u.Path)
+	}
+
+	return false
+}
+
+// isLoopbackAddress reports whether the given address is a host:port on the loopback interface, the gateway has no
+// authentication of its own so it must not be reachable from the network
+func isLoopbackAddress(address string) bool {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,70 @@
+package options
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// WatchConfig calls onChange with the name of the changed file whenever one of the resolved config files or preset
+// override files is created, written, renamed or removed. Parent directories are watched instead of the files, so that
+// the files that are replaced atomically by the editors and the ones that do not exist yet are tracked too. Changes
+// are coalesced until the files are quiet for constants.ConfigDebounceInterval, onChange gets the last changed file
+func (opts *RootOptions) WatchConfig(onChange func(file string)) error {
+	return opts.watchConfig(constants.ConfigDebounceInterval, onChange)
+}
+
+// watchConfig is WatchConfig with the given quiet period
+func (opts *RootOptions) watchConfig(debounce time.Duration, onChange func(file string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create config watcher")
+	}
+
+	files := make(map[string]bool)
+	for _, file := range append(opts.PresetFiles(), opts.ConfigFiles...) {
+		file = filepath.Clean(file)
+		files[file] = true
+
+		// directories that do not exist are skipped, such as a missing /etc/split-the-tunnel on a desktop
+		_ = watcher.Add(filepath.Dir(file))
+	}
+
+	if len(watcher.WatchList()) == 0 {
+		return watcher.Close()
+	}
+
+	go func() {
+		var changed string
+		var quiet <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if !files[filepath.Clean(event.Name)] || event.Op == fsnotify.Chmod {
+					continue
+				}
+
+				changed = event.Name
+				quiet = time.After(debounce)
+			case _, ok := <-watcher.Errors:
+				// errors such as the overflows of the event queue are followed by the next events
+				if !ok {
+					return
+				}
+			case <-quiet:
+				quiet = nil
+				onChange(changed)
+			}
+		}
+	}()
+
+	return nil
+}
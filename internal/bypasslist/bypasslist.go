@@ -0,0 +1,405 @@
+// Package bypasslist reads and writes the lists of the destinations that bypass VPN, so that they can be moved between
+// machines and shared. JSON is the full form of the destinations, the text, CSV and hosts formats carry less of them
+package bypasslist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+)
+
+// Format is the format of a list
+type Format string
+
+const (
+	// FormatJSON is a document with the routes array, which carries every field of the Item
+	FormatJSON Format = "json"
+	// FormatText is one destination per line, an inline comment becomes the comment of the destination
+	FormatText Format = "text"
+	// FormatCSV has the destination, group, accumulate, tags and comment columns, the header picks their order
+	FormatCSV Format = "csv"
+	// FormatHosts is the format of /etc/hosts, the names are imported and the addresses are ignored. It is read-only
+	FormatHosts Format = "hosts"
+)
+
+// Formats are the supported formats of the lists
+var Formats = []Format{FormatJSON, FormatText, FormatCSV, FormatHosts}
+
+const (
+	// commentPrefix starts a comment in the text, CSV and hosts formats
+	commentPrefix = "#"
+	// tagSeparator separates the tags in a CSV cell
+	tagSeparator = ";"
+)
+
+// csvColumns are the columns of the exported CSV lists, the destination column is required on import
+var csvColumns = []string{"destination", "group", "accumulate", "tags", "comment"}
+
+// loopbackNames are the names of the hosts files that are never routed outside the host
+var loopbackNames = map[string]bool{
+	"localhost":             true,
+	"localhost.localdomain": true,
+	"ip6-localhost":         true,
+	"ip6-loopback":          true,
+	"ip6-localnet":          true,
+	"ip6-mcastprefix":       true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"broadcasthost":         true,
+}
+
+// ParseFormat returns the Format of the given name
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+
+	return "", errors.Errorf("unknown list format %q, one of json, text, csv and hosts is expected", name)
+}
+
+// Detect returns the format of a list by the extension of its file name, or by its content if the name does not tell,
+// such as for the lists that are read from stdin
+func Detect(name string, content []byte) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON
+	case ".csv":
+		return FormatCSV
+	case ".txt", ".list":
+		return FormatText
+	}
+
+	if filepath.Base(name) == "hosts" {
+		return FormatHosts
+	}
+
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return FormatJSON
+	}
+
+	// a hosts file starts its entries with an address, the text lists have a single destination per line
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) > 1 && net.ParseIP(fields[0]) != nil {
+			return FormatHosts
+		}
+
+		if strings.Contains(fields[0], ",") {
+			return FormatCSV
+		}
+
+		break
+	}
+
+	return FormatText
+}
+
+// Item is a destination of a list
+type Item struct {
+	Destination string     `json:"destination"`
+	Group       string     `json:"group,omitempty"`
+	Accumulate  bool       `json:"accumulate,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	// Line is the 1-indexed line of the item, or its position in the routes array of the JSON lists
+	Line int `json:"-"`
+}
+
+// Problem is a line of a list that is not imported
+type Problem struct {
+	Line int
+	// Text is the destination, or the line itself if it has no destination
+	Text   string
+	Reason string
+}
+
+// List is the parsed content of a list
+type List struct {
+	Items []*Item
+	// Duplicates are the destinations that are listed more than once, only the first of them is in Items
+	Duplicates []*Problem
+	// Invalid are the lines that cannot be parsed or whose destinations are invalid
+	Invalid []*Problem
+}
+
+// document is the JSON form of a list. The exported lists and the output of stt-cli list -o json have the routes, the
+// state file of the daemon has the entries
+type document struct {
+	Routes  []*Item `json:"routes"`
+	Entries []*struct {
+		Domain     string     `json:"domain"`
+		Group      string     `json:"group"`
+		Accumulate bool       `json:"accumulate"`
+		ExpiresAt  *time.Time `json:"expiresAt"`
+		Tags       []string   `json:"tags"`
+		Comment    string     `json:"comment"`
+	} `json:"entries,omitempty"`
+}
+
+// Parse reads the list in the given format, the invalid and the duplicate lines are reported in the List. It returns
+// an error only if the list cannot be read at all, such as a malformed JSON document
+func Parse(format Format, content []byte) (*List, error) {
+	list := &List{}
+	seen := make(map[string]int)
+
+	// add appends the given item unless it is invalid or listed before
+	add := func(item *Item) {
+		item.Destination = normalize(item.Destination)
+		switch {
+		case !utils.IsValidDestination(item.Destination):
+			list.Invalid = append(list.Invalid, &Problem{Line: item.Line, Text: item.Destination,
+				Reason: "invalid destination, must be a domain, an IP address or a CIDR block"})
+		case seen[item.Destination] > 0:
+			list.Duplicates = append(list.Duplicates, &Problem{Line: item.Line, Text: item.Destination,
+				Reason: fmt.Sprintf("duplicate of line %d", seen[item.Destination])})
+		default:
+			seen[item.Destination] = item.Line
+			list.Items = append(list.Items, item)
+		}
+	}
+
+	switch format {
+	case FormatJSON:
+		var doc document
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return nil, errors.Wrap(err, "failed to parse JSON list")
+		}
+
+		for i, item := range doc.Routes {
+			item.Line = i + 1
+			add(item)
+		}
+
+		for i, entry := range doc.Entries {
+			add(&Item{Destination: entry.Domain, Group: entry.Group, Accumulate: entry.Accumulate, ExpiresAt: entry.ExpiresAt,
+				Tags: entry.Tags, Comment: entry.Comment, Line: len(doc.Routes) + i + 1})
+		}
+	case FormatText, FormatHosts:
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			fields := strings.Fields(stripComment(text))
+			if len(fields) == 0 {
+				continue
+			}
+
+			comment := inlineComment(text)
+			if format == FormatText {
+				if len(fields) > 1 {
+					list.Invalid = append(list.Invalid, &Problem{Line: line, Text: text, Reason: "a single destination is expected per line"})
+					continue
+				}
+
+				add(&Item{Destination: fields[0], Comment: comment, Line: line})
+
+				continue
+			}
+
+			if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+				list.Invalid = append(list.Invalid, &Problem{Line: line, Text: text, Reason: "an address and at least one name are expected"})
+				continue
+			}
+
+			for _, name := range fields[1:] {
+				if !loopbackNames[strings.ToLower(name)] {
+					add(&Item{Destination: name, Comment: comment, Line: line})
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrap(err, "failed to read list")
+		}
+	case FormatCSV:
+		if err := parseCSV(content, list, add); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unknown list format %q", format)
+	}
+
+	return list, nil
+}
+
+// parseCSV reads the rows of a CSV list, the first row is the header if it has a destination column
+func parseCSV(content []byte, list *List, add func(item *Item)) error {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := csvColumns
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				list.Invalid = append(list.Invalid, &Problem{Line: parseErr.Line, Text: "", Reason: parseErr.Err.Error()})
+				continue
+			}
+
+			return errors.Wrap(err, "failed to read CSV list")
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if first && containsFold(record, "destination") {
+			columns = make([]string, len(record))
+			for i, column := range record {
+				columns[i] = strings.ToLower(strings.TrimSpace(column))
+			}
+
+			continue
+		}
+
+		item := &Item{Line: line}
+		for i, value := range record {
+			if i >= len(columns) {
+				break
+			}
+
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "destination":
+				item.Destination = value
+			case "group":
+				item.Group = value
+			case "accumulate":
+				item.Accumulate, _ = strconv.ParseBool(value)
+			case "tags":
+				item.Tags = splitTags(value)
+			case "comment":
+				item.Comment = value
+			}
+		}
+
+		add(item)
+	}
+}
+
+// Write writes the given items in the given format, the hosts format cannot be written since the destinations have
+// no single address
+func Write(w io.Writer, format Format, items []*Item) error {
+	switch format {
+	case FormatJSON:
+		if items == nil {
+			items = []*Item{}
+		}
+
+		out, err := json.MarshalIndent(document{Routes: items}, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to encode list")
+		}
+
+		_, err = fmt.Fprintln(w, string(out))
+
+		return err
+	case FormatText:
+		for _, item := range items {
+			line := item.Destination
+			if item.Comment != "" {
+				line += " " + commentPrefix + " " + item.Comment
+			}
+
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := writer.Write([]string{item.Destination, item.Group, strconv.FormatBool(item.Accumulate),
+				strings.Join(item.Tags, tagSeparator), item.Comment}); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+	default:
+		return errors.Errorf("lists cannot be exported in the %s format", format)
+	}
+}
+
+// normalize returns the given destination in the form that the daemon keeps it, the domains are case-insensitive
+func normalize(destination string) string {
+	destination = strings.TrimSpace(destination)
+	if net.ParseIP(destination) != nil {
+		return destination
+	}
+
+	return strings.ToLower(destination)
+}
+
+// stripComment returns the given line without its comment
+func stripComment(line string) string {
+	if i := strings.Index(line, commentPrefix); i >= 0 {
+		return line[:i]
+	}
+
+	return line
+}
+
+// inlineComment returns the comment of the given line, empty if it has none
+func inlineComment(line string) string {
+	if i := strings.Index(line, commentPrefix); i >= 0 {
+		return strings.TrimSpace(line[i+len(commentPrefix):])
+	}
+
+	return ""
+}
+
+// splitTags returns the non-empty tags of the given CSV cell
+func splitTags(cell string) []string {
+	var tags []string
+	for _, tag := range strings.Split(cell, tagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// containsFold returns true if one of the given values equals the given value, ignoring the case and the spaces
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+
+	return false
+}
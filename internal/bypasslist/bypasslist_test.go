@@ -0,0 +1,161 @@
+package bypasslist
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// destinations returns the destinations of the given items
+func destinations(items []*Item) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Destination)
+	}
+
+	return out
+}
+
+func TestParse_Text(t *testing.T) {
+	content := `# meetings
+zoom.us
+Slack.com # chat
+not a destination
+
+10.0.0.0/8
+slack.com
+-invalid-
+`
+
+	list, err := Parse(FormatText, []byte(content))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"zoom.us", "slack.com", "10.0.0.0/8"}, destinations(list.Items))
+	assert.Equal(t, "chat", list.Items[1].Comment)
+	assert.Equal(t, 3, list.Items[1].Line)
+
+	if assert.Len(t, list.Duplicates, 1) {
+		assert.Equal(t, &Problem{Line: 7, Text: "slack.com", Reason: "duplicate of line 3"}, list.Duplicates[0])
+	}
+
+	if assert.Len(t, list.Invalid, 2) {
+		assert.Equal(t, 4, list.Invalid[0].Line)
+		assert.Equal(t, 8, list.Invalid[1].Line)
+		assert.Equal(t, "-invalid-", list.Invalid[1].Text)
+	}
+}
+
+func TestParse_Hosts(t *testing.T) {
+	content := `127.0.0.1 localhost
+::1 ip6-localhost ip6-loopback
+10.1.2.3 intranet.example.com wiki.example.com # office
+broken
+`
+
+	list, err := Parse(FormatHosts, []byte(content))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"intranet.example.com", "wiki.example.com"}, destinations(list.Items))
+	assert.Equal(t, "office", list.Items[1].Comment)
+	assert.Empty(t, list.Duplicates)
+	if assert.Len(t, list.Invalid, 1) {
+		assert.Equal(t, 4, list.Invalid[0].Line)
+	}
+}
+
+func TestParse_CSV(t *testing.T) {
+	content := `# exported from the laptop
+comment,destination,tags
+video calls,zoom.us,work; meetings
+,slack.com,
+,zoom.us,
+`
+
+	list, err := Parse(FormatCSV, []byte(content))
+	if !assert.NoError(t, err) || !assert.Len(t, list.Items, 2) {
+		return
+	}
+
+	assert.Equal(t, &Item{Destination: "zoom.us", Tags: []string{"work", "meetings"}, Comment: "video calls", Line: 3}, list.Items[0])
+	assert.Equal(t, "slack.com", list.Items[1].Destination)
+	assert.Len(t, list.Duplicates, 1)
+
+	// the columns of the exported lists are assumed without a header
+	list, err = Parse(FormatCSV, []byte("zoom.us,meetings,true\n"))
+	if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "meetings", list.Items[0].Group)
+		assert.True(t, list.Items[0].Accumulate)
+	}
+}
+
+func TestParse_JSON(t *testing.T) {
+	list, err := Parse(FormatJSON, []byte(`{"routes": [{"destination": "zoom.us", "group": "meetings"}, {"destination": "zoom.us"}]}`))
+	if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "meetings", list.Items[0].Group)
+		assert.Len(t, list.Duplicates, 1)
+	}
+
+	// the state file of the daemon is accepted too
+	list, err = Parse(FormatJSON, []byte(`{"entries": [{"domain": "slack.com", "gateway": "192.168.1.1", "accumulate": true}]}`))
+	if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "slack.com", list.Items[0].Destination)
+		assert.True(t, list.Items[0].Accumulate)
+	}
+
+	_, err = Parse(FormatJSON, []byte(`{"routes": [`))
+	assert.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []*Item{
+		{Destination: "zoom.us", Group: "meetings", Tags: []string{"work", "video"}, Comment: "calls, mostly"},
+		{Destination: "10.0.0.0/8", Accumulate: true, ExpiresAt: &expiresAt},
+	}
+
+	// every format that can be written is read back the same, except for the fields that it does not carry
+	for _, format := range []Format{FormatJSON, FormatText, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if !assert.NoError(t, Write(&buf, format, items)) {
+				return
+			}
+
+			assert.Equal(t, format, Detect("", buf.Bytes()))
+
+			list, err := Parse(format, buf.Bytes())
+			if !assert.NoError(t, err) || !assert.Len(t, list.Items, 2) {
+				return
+			}
+
+			assert.Empty(t, list.Invalid)
+			assert.Equal(t, []string{"zoom.us", "10.0.0.0/8"}, destinations(list.Items))
+			assert.Equal(t, "calls, mostly", list.Items[0].Comment)
+
+			if format != FormatText {
+				assert.Equal(t, []string{"work", "video"}, list.Items[0].Tags)
+				assert.Equal(t, "meetings", list.Items[0].Group)
+				assert.True(t, list.Items[1].Accumulate)
+			}
+
+			if format == FormatJSON {
+				assert.Equal(t, expiresAt, *list.Items[1].ExpiresAt)
+			}
+		})
+	}
+
+	assert.Error(t, Write(&bytes.Buffer{}, FormatHosts, items))
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, FormatCSV, Detect("list.CSV", nil))
+	assert.Equal(t, FormatHosts, Detect("/etc/hosts", nil))
+	assert.Equal(t, FormatHosts, Detect("", []byte("# comment\n1.2.3.4 example.com\n")))
+	assert.Equal(t, FormatText, Detect("-", []byte("example.com\n")))
+}
@@ -8,6 +8,7 @@ const (
 	FailedToWriteState                = "failed to write state to file"
 	FailedToReadFromIPC               = "failed to read from IPC connection"
 	FailedToReloadState               = "failed to reload state"
+	FailedToRestoreRoutes             = "failed to restore routes of the state"
 	FailedToGetDefaultGateway         = "failed to get default gateway"
 	EmptyCommandReceived              = "empty command received"
 	FailedToResolveDomain             = "failed to resolve domain"
@@ -20,4 +21,38 @@ const (
 	FailedToCleanupIPC                = "failed to cleanup IPC"
 	FailedToInitializeIPC             = "failed to initialize IPC"
 	FailedToRemoveRouteEntry          = "failed to remove RouteEntry from state"
+	FailedToInitializeGrpc            = "failed to initialize gRPC listener"
+	FailedToServeGrpc                 = "failed to serve gRPC"
+	RecoveredGrpcPanic                = "recovered from panic in gRPC handler"
+	FailedToInitializeGateway         = "failed to initialize HTTP gateway listener"
+	FailedToServeGateway              = "failed to serve HTTP gateway"
+	FailedToWriteHTTPResponse         = "failed to write HTTP response"
+	FailedToConnectToDaemon           = "failed to connect to daemon"
+	FailedToRemoveExpiredEntries      = "failed to remove expired entries"
+	FailedToConvergeGroup             = "failed to converge declared group"
+	FailedToConvergeEntry             = "failed to converge declared route entry"
+	FailedToSyncEntry                 = "failed to sync route entry of managed group"
+	FailedToConvergeState             = "failed to converge state to the config file"
+	RejectedConfigReload              = "rejected invalid config, keeping the running config"
+	FailedToWatchConfig               = "failed to watch config files"
+	GroupNotFound                     = "group not found in state"
+	FailedToAddRouteEntry             = "failed to add RouteEntry to state"
+	FailedToAddRoute                  = "failed to add route to routing table"
+	FailedToRemoveRoute               = "failed to remove route from routing table"
+	MalformedRequest                  = "malformed request"
+	FailedToApplyRoutes               = "failed to apply routes"
+	FailedToRollbackRoute             = "failed to roll back route"
+	FailedToRefreshEntry              = "failed to refresh routes of entry"
+	FailedToUpdateRouteEntry          = "failed to update route entry"
+	FailedToImportRouteEntry          = "failed to import RouteEntry"
+	FailedToSyncSubscription          = "failed to sync subscribed list"
+	FailedToRecordSubscription        = "failed to record sync status of subscribed list"
+	FailedToRemoveSubscription        = "failed to remove list that is not subscribed anymore"
+	FailedToLoadPresets               = "failed to load preset catalog"
+	FailedToSyncPreset                = "failed to sync enabled preset to the catalog"
+	PresetNotFound                    = "preset not found in catalog"
+	PresetNotEnabled                  = "preset is not enabled"
+	UnknownCommand                    = "unknown command"
+	MissingArguments                  = "%s command requires at least one domain"
+	UnexpectedArguments               = "%s command takes no arguments"
 )
@@ -1,13 +1,35 @@
 package constants
 
 const (
-	SuccessfullyProcessed = "successfully processed command"
-	IPCInitialized        = "ipc is initialized"
-	DaemonRunning         = "daemon is running, waiting for requests over unix domain socket..."
-	TermSignalReceived    = "termination signal received"
-	ShuttingDownDaemon    = "shutting down daemon..."
-	AppStarted            = "split-the-tunnel is started!"
-	ProcessCommand        = "processing command"
-	CleaningUpIPC         = "cleaning up IPC socket"
-	PurgedAllRoutes       = "purged all routes"
+	SuccessfullyProcessed   = "successfully processed command"
+	IPCInitialized          = "ipc is initialized"
+	DaemonRunning           = "daemon is running, waiting for requests over unix domain socket and gRPC..."
+	TermSignalReceived      = "termination signal received"
+	ShuttingDownDaemon      = "shutting down daemon..."
+	AppStarted              = "split-the-tunnel is started!"
+	ProcessCommand          = "processing command"
+	HandledGrpcCall         = "handled gRPC call"
+	DaemonServing           = "restored routes, daemon is serving"
+	GatewayServing          = "HTTP gateway is serving"
+	CleaningUpIPC           = "cleaning up IPC socket"
+	RemovedExpiredEntry     = "removed expired route entry"
+	AddedDeclaredEntry      = "added route entry declared in config file"
+	RemovedUndeclaredEntry  = "removed route entry that is not declared in config file anymore"
+	ConvergedState          = "converged state to the config file"
+	ConfigReloaded          = "config is reloaded"
+	AppliedDNSServers       = "applied new dns servers"
+	AppliedDNSStrategy      = "applied new dns strategy"
+	AppliedCheckInterval    = "applied new check interval"
+	AppliedLogLevel         = "applied new log level"
+	AppliedRouteMode        = "applied new route mode"
+	AppliedGracePeriod      = "applied new grace period"
+	AppliedAccumulation     = "applied new accumulation settings"
+	StartedWatch            = "started watching route events"
+	StoppedWatch            = "stopped watching route events"
+	GatewayChanged          = "default non-vpn gateway is changed"
+	SyncedSubscription      = "synced subscribed list into its group"
+	SubscriptionNotModified = "subscribed list is not modified since the last sync"
+	RemovedSubscription     = "removed list that is not subscribed anymore"
+	LoadedPresets           = "loaded preset catalog"
+	SyncedPreset            = "synced enabled preset to the catalog"
 )
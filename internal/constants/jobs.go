@@ -4,4 +4,9 @@ const (
 	JobMain          = "main"
 	JobIpChangeCheck = "ip-change-check"
 	JobCleanup       = "cleanup"
+	JobExpiryCheck   = "expiry-check"
+	JobConfigReload  = "config-reload"
+	JobGrpc          = "grpc"
+	JobGateway       = "gateway"
+	JobSubscription  = "subscription-sync"
 )
@@ -1,11 +1,47 @@
 package constants
 
+import "time"
+
 const (
-	StateFileName  = "state.json"
-	SocketFileName = "ipc.sock"
+	StateFileName      = "state.json"
+	SocketFileName     = "ipc.sock"
+	GrpcSocketFileName = "grpc.sock"
+	ConfigFileName     = "config.toml"
+	// PresetsFileName is the local override file of the preset catalog, it is read next to every config file
+	PresetsFileName = "presets.toml"
+	// SourceConfig is the source of the entries and groups that are declared in the config file
+	SourceConfig = "config"
+	// SourceImport is the source of the entries that are added by importing a list
+	SourceImport = "import"
+	// SourceSubscription is the source of the entries and the groups that are synced from a subscribed list
+	SourceSubscription = "subscription"
+	// SourcePreset is the source of the entries and the groups that are expanded from an enabled preset
+	SourcePreset = "preset"
 )
 
+// ExpiryCheckInterval is the interval to look for the expired temporary entries in the state
+const ExpiryCheckInterval = 30 * time.Second
+
+// DefaultSubscriptionInterval is the interval to sync the subscribed lists that do not set one
+const DefaultSubscriptionInterval = time.Hour
+
+// SubscriptionFetchTimeout is the timeout of fetching a subscribed list over HTTP(S)
+const SubscriptionFetchTimeout = 30 * time.Second
+
+// ConfigDebounceInterval is the quiet period that the changes of the config files are coalesced within, since a single
+// save of an editor writes, renames and creates the file
+const ConfigDebounceInterval = 500 * time.Millisecond
+
+// EventHistorySize is the number of the last route events that are kept to be replayed to the new watchers
+const EventHistorySize = 256
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of the gRPC errors, their reasons are the names of the
+// StatusCode values of the proto
+const ErrorDomain = "split-the-tunnel"
+
 type (
-	LoggerKey     struct{}
-	SocketPathKey struct{}
+	LoggerKey         struct{}
+	SocketPathKey     struct{}
+	GrpcSocketPathKey struct{}
+	OutputFormatKey   struct{}
 )
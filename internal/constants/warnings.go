@@ -1,6 +1,14 @@
 package constants
 
 const (
-	EntryAlreadyExists = "route entry already exists in state"
-	NoRoutesToPurge    = "no routes to purge"
+	EntryAlreadyExists     = "route entry already exists in state"
+	NoRoutesToPurge        = "no routes to purge"
+	GroupAlreadyExists     = "group already exists in state"
+	GroupAlreadyEnabled    = "group is already enabled"
+	GroupAlreadyDisabled   = "group is already disabled"
+	EntryAlreadyInGroup    = "route entry is already in the group"
+	AppliedRoutesPartially = "some routes of the entry failed, keeping the ones that succeeded"
+	DroppedSlowWatcher     = "watcher cannot keep up with the route events, dropping it"
+	SkippedInvalidListLine = "skipped invalid line of subscribed list"
+	EnabledPresetRemoved   = "enabled preset is not in the catalog anymore, keeping its group as it is"
 )
@@ -0,0 +1,309 @@
+// Package doctor diagnoses why the traffic of the routed destinations does not bypass VPN, by comparing the routes
+// that the daemon reports with the routing table of the kernel
+package doctor
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// Severity is the outcome of a check
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityWarning Severity = "warning"
+	SeverityFailed  Severity = "failed"
+	SeveritySkipped Severity = "skipped"
+)
+
+const (
+	CheckSocket    = "socket"
+	CheckDaemon    = "daemon"
+	CheckGateway   = "gateway"
+	CheckRoutes    = "routes"
+	CheckShadowing = "shadowing"
+	CheckDNS       = "dns"
+)
+
+// accessWrite is the mode of access(2) that checks the write permission, which connecting to a unix socket requires
+const accessWrite = 0x2
+
+// defaultProbeDomain is the domain that the DNS servers are queried with if none of the destinations is a domain
+const defaultProbeDomain = "example.com"
+
+// Finding is the outcome of a single check, the failed ones have a hint that tells how to fix them
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Hint     string   `json:"hint,omitempty"`
+}
+
+// newFinding returns a Finding with the formatted message
+func newFinding(check string, severity Severity, hint, format string, args ...any) Finding {
+	return Finding{Check: check, Severity: severity, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+// Healthy returns true if none of the given findings failed, the warnings do not make them unhealthy
+func Healthy(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckSocketAccess checks that the gRPC socket of the daemon at the given path exists and that the current user can
+// connect to it
+func CheckSocketAccess(path string) Finding {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return newFinding(CheckSocket, SeverityFailed,
+			"start the daemon, or give the CLI the same --workspace or --grpc-socket-path as the daemon",
+			"gRPC socket %s does not exist", path)
+	}
+
+	if err != nil {
+		return newFinding(CheckSocket, SeverityFailed, "check the permissions of the directory of the socket",
+			"gRPC socket %s cannot be accessed: %v", path, err)
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return newFinding(CheckSocket, SeverityFailed, "remove the file and restart the daemon",
+			"%s is not a socket", path)
+	}
+
+	owner := ""
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		owner = fmt.Sprintf(", owned by %d:%d", stat.Uid, stat.Gid)
+	}
+
+	if err := syscall.Access(path, accessWrite); err != nil {
+		return newFinding(CheckSocket, SeverityFailed,
+			"run the CLI as root, or as a member of the group that owns the socket",
+			"gRPC socket %s is not writable by the current user (mode %s%s)", path, info.Mode().Perm(), owner)
+	}
+
+	return newFinding(CheckSocket, SeverityOK, "", "gRPC socket %s is accessible (mode %s%s)", path, info.Mode().Perm(), owner)
+}
+
+// CheckDaemonStatus reports the version and the uptime of the daemon, or why it cannot be reached
+func CheckDaemonStatus(status *client.Status, err error) Finding {
+	if err != nil {
+		return newFinding(CheckDaemon, SeverityFailed, "check the logs of the daemon, such as with journalctl -u split-the-tunnel",
+			"daemon cannot be reached: %v", err)
+	}
+
+	return newFinding(CheckDaemon, SeverityOK, "", "daemon %s is up for %s with the %s backend", status.Version,
+		status.Uptime.Round(time.Second), status.Backend)
+}
+
+// CheckGatewayReachable checks that the daemon detects a non-VPN gateway, that the kernel routes it over the same
+// interface and that it has answered recently, according to the neighbor table
+func CheckGatewayReachable(status *client.Status, table []*utils.KernelRoute, resolved func(ip string) (bool, error)) Finding {
+	if status.Gateway == "" {
+		return newFinding(CheckGateway, SeverityFailed,
+			"connect to a network, the default route outside the VPN should stay in the routing table",
+			"daemon cannot detect a default gateway outside the VPN")
+	}
+
+	gw := net.ParseIP(status.Gateway)
+	route := utils.LookupRoute(table, gw)
+	if route == nil {
+		return newFinding(CheckGateway, SeverityFailed, "check the network configuration of "+status.GatewayInterface,
+			"gateway %s is not on any network that the kernel routes", status.Gateway)
+	}
+
+	// the gateway is expected on a directly connected network of its interface
+	if route.Gateway != "" || (status.GatewayInterface != "" && route.Interface != status.GatewayInterface) {
+		return newFinding(CheckGateway, SeverityWarning,
+			"the VPN may capture the traffic to the gateway, check its routes with ip route get "+status.Gateway,
+			"gateway %s is reached over %s instead of directly over %s", status.Gateway, route, status.GatewayInterface)
+	}
+
+	ok, err := resolved(status.Gateway)
+	if err != nil {
+		return newFinding(CheckGateway, SeverityWarning, "", "neighbor table cannot be read: %v", err)
+	}
+
+	if !ok {
+		return newFinding(CheckGateway, SeverityWarning,
+			fmt.Sprintf("check the link of %s, such as with ping -I %s %s", route.Interface, route.Interface, status.Gateway),
+			"gateway %s has not answered on %s recently, its hardware address is not resolved", status.Gateway, route.Interface)
+	}
+
+	return newFinding(CheckGateway, SeverityOK, "", "gateway %s is reachable on %s", status.Gateway, route.Interface)
+}
+
+// destinationNet returns the network of the given routed IP or CIDR block of a destination
+func destinationNet(ip string) *net.IPNet {
+	if _, ipNet, err := net.ParseCIDR(ip); err == nil {
+		return ipNet
+	}
+
+	if parsed := net.ParseIP(ip).To4(); parsed != nil {
+		return &net.IPNet{IP: parsed, Mask: net.CIDRMask(32, 32)}
+	}
+
+	return nil
+}
+
+// sameNet returns true if both networks are the same
+func sameNet(a, b *net.IPNet) bool {
+	return a.IP.Equal(b.IP) && a.Mask.String() == b.Mask.String()
+}
+
+// CheckKernelRoutes checks that the routes of every routed IP of the given active routes are in the routing table of
+// the kernel with the gateway of their destination, and reports the IPs whose routes could not be installed
+func CheckKernelRoutes(routes []*client.Route, table []*utils.KernelRoute) []Finding {
+	var findings []Finding
+	var checked int
+	for _, route := range routes {
+		if !route.Active {
+			continue
+		}
+
+		for _, ip := range route.RoutedIPs {
+			dst := destinationNet(ip)
+			if dst == nil {
+				continue
+			}
+
+			checked++
+
+			// the same network may be routed by the VPN too, that is reported by CheckShadowedRoutes
+			var found *utils.KernelRoute
+			for _, kr := range table {
+				if !sameNet(kr.Destination, dst) {
+					continue
+				}
+
+				if found == nil || kr.Gateway == route.Gateway {
+					found = kr
+				}
+			}
+
+			hint := fmt.Sprintf("the routing table may have been flushed, such as by the VPN client, restart the daemon to restore the routes of %s", route.Destination)
+			switch {
+			case found == nil:
+				findings = append(findings, newFinding(CheckRoutes, SeverityFailed, hint,
+					"route of %s of %s via %s is missing from the kernel", ip, route.Destination, route.Gateway))
+			case found.Gateway != route.Gateway:
+				findings = append(findings, newFinding(CheckRoutes, SeverityWarning, hint,
+					"route of %s of %s is %s instead of via %s", ip, route.Destination, found, route.Gateway))
+			}
+		}
+
+		var failed []string
+		for _, ip := range route.IPs {
+			if ip.Status == client.IPStatusFailed {
+				failed = append(failed, fmt.Sprintf("%s (%s)", ip.IP, ip.LastError))
+			}
+		}
+
+		if len(failed) > 0 {
+			findings = append(findings, newFinding(CheckRoutes, SeverityWarning,
+				"see stt-cli get "+route.Destination+", the daemon retries them on every IP check",
+				"routes of %d IPs of %s could not be installed: %s", len(failed), route.Destination, strings.Join(failed, ", ")))
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, newFinding(CheckRoutes, SeverityOK, "", "all the %d routes of %d destinations are in the kernel", checked, len(routes)))
+	}
+
+	return findings
+}
+
+// CheckShadowedRoutes checks that the kernel picks the routes of the destinations for their traffic, rather than the
+// more specific routes that the VPN installs. The policy routing rules, such as the ones of wg-quick, are not checked
+func CheckShadowedRoutes(routes []*client.Route, table []*utils.KernelRoute) []Finding {
+	var findings []Finding
+	for _, route := range routes {
+		if !route.Active {
+			continue
+		}
+
+		for _, ip := range route.RoutedIPs {
+			dst := destinationNet(ip)
+			if dst == nil {
+				continue
+			}
+
+			dstOnes, _ := dst.Mask.Size()
+			for _, kr := range table {
+				ones, _ := kr.Destination.Mask.Size()
+				if ones <= dstOnes || !dst.Contains(kr.Destination.IP) || kr.Gateway == route.Gateway {
+					continue
+				}
+
+				findings = append(findings, newFinding(CheckShadowing, SeverityFailed,
+					"the VPN routes a more specific network, add the destinations inside it separately or exclude them in the VPN client",
+					"traffic of %s of %s to %s goes over %s instead of via %s", ip, route.Destination, kr.Destination, kr, route.Gateway))
+			}
+
+			// equally specific routes win with a lower metric
+			if best := utils.LookupRoute(table, dst.IP); best != nil && sameNet(best.Destination, dst) && best.Gateway != route.Gateway {
+				findings = append(findings, newFinding(CheckShadowing, SeverityFailed,
+					"the VPN installs the same route with a lower metric, exclude the destination in the VPN client",
+					"traffic of %s of %s goes over %s instead of via %s", ip, route.Destination, best, route.Gateway))
+			}
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, newFinding(CheckShadowing, SeverityOK, "", "none of the routes is shadowed by a more specific route"))
+	}
+
+	return findings
+}
+
+// CheckDNSServers queries every given DNS server with a domain of the given routes concurrently, and reports the
+// ones that do not answer
+func CheckDNSServers(servers []string, routes []*client.Route, query func(server, domain string) ([]string, error)) []Finding {
+	domain := defaultProbeDomain
+	for _, route := range routes {
+		if destinationNet(route.Destination) == nil {
+			domain = route.Destination
+			break
+		}
+	}
+
+	findings := make([]Finding, len(servers))
+
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+
+			ips, err := query(server, domain)
+			switch {
+			case err != nil:
+				findings[i] = newFinding(CheckDNS, SeverityFailed,
+					"check that the server is reachable outside the VPN, or change the dnsservers setting of the daemon",
+					"DNS server %s does not answer for %s: %v", server, domain, err)
+			case len(ips) == 0:
+				findings[i] = newFinding(CheckDNS, SeverityWarning, "the server may filter the domain, compare it with the other servers",
+					"DNS server %s answers for %s without any IPv4 addresses", server, domain)
+			default:
+				findings[i] = newFinding(CheckDNS, SeverityOK, "", "DNS server %s answers for %s", server, domain)
+			}
+		}(i, server)
+	}
+
+	wg.Wait()
+
+	return findings
+}
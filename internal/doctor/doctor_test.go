@@ -0,0 +1,160 @@
+package doctor
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/pkg/client"
+)
+
+// kernelRoute returns a utils.KernelRoute of the given CIDR block
+func kernelRoute(cidr, gateway, iface string, metric int) *utils.KernelRoute {
+	_, dst, _ := net.ParseCIDR(cidr)
+	return &utils.KernelRoute{Interface: iface, Destination: dst, Gateway: gateway, Metric: metric}
+}
+
+// table is a routing table with a VPN on tun0 that routes 10.8.0.0/16 and the default traffic, and the bypass routes
+// of 1.1.1.1 and 10.0.0.0/8 over eth0
+var table = []*utils.KernelRoute{
+	kernelRoute("0.0.0.0/0", "", "tun0", 50),
+	kernelRoute("0.0.0.0/0", "192.168.1.1", "eth0", 100),
+	kernelRoute("192.168.1.0/24", "", "eth0", 100),
+	kernelRoute("10.8.0.0/16", "", "tun0", 50),
+	kernelRoute("1.1.1.1/32", "192.168.1.1", "eth0", 0),
+	kernelRoute("10.0.0.0/8", "192.168.1.1", "eth0", 0),
+}
+
+func TestCheckKernelRoutes(t *testing.T) {
+	routes := []*client.Route{
+		{Destination: "one.one", Gateway: "192.168.1.1", Active: true, RoutedIPs: []string{"1.1.1.1"}},
+		{Destination: "10.0.0.0/8", Gateway: "192.168.1.1", Active: true, RoutedIPs: []string{"10.0.0.0/8"}},
+		// routes of the inactive destinations are not expected in the kernel
+		{Destination: "disabled.test", Gateway: "192.168.1.1", RoutedIPs: []string{"3.3.3.3"}},
+	}
+
+	findings := CheckKernelRoutes(routes, table)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, SeverityOK, findings[0].Severity)
+	}
+
+	routes = append(routes, &client.Route{
+		Destination: "example.com", Gateway: "192.168.1.1", Active: true, RoutedIPs: []string{"2.2.2.2"},
+		IPs: []*client.RouteIP{{IP: "2.2.2.3", Status: client.IPStatusFailed, LastError: "exit status 2"}},
+	})
+
+	findings = CheckKernelRoutes(routes, table)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, SeverityFailed, findings[0].Severity)
+		assert.Contains(t, findings[0].Message, "2.2.2.2")
+		assert.Equal(t, SeverityWarning, findings[1].Severity)
+		assert.Contains(t, findings[1].Message, "exit status 2")
+	}
+
+	assert.False(t, Healthy(findings))
+}
+
+func TestCheckShadowedRoutes(t *testing.T) {
+	routes := []*client.Route{
+		{Destination: "one.one", Gateway: "192.168.1.1", Active: true, RoutedIPs: []string{"1.1.1.1"}},
+		{Destination: "10.0.0.0/8", Gateway: "192.168.1.1", Active: true, RoutedIPs: []string{"10.0.0.0/8"}},
+	}
+
+	findings := CheckShadowedRoutes(routes, table)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, SeverityFailed, findings[0].Severity)
+		assert.Contains(t, findings[0].Message, "10.8.0.0/16")
+	}
+
+	// same route with a lower metric
+	shadowed := append([]*utils.KernelRoute{kernelRoute("1.1.1.1/32", "", "tun0", -1)}, table[:4]...)
+	shadowed = append(shadowed, table[4])
+	findings = CheckShadowedRoutes(routes[:1], shadowed)
+	if assert.Len(t, findings, 1) {
+		assert.Contains(t, findings[0].Message, "dev tun0")
+	}
+
+	findings = CheckShadowedRoutes(routes[:1], table)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, SeverityOK, findings[0].Severity)
+	}
+}
+
+func TestCheckGatewayReachable(t *testing.T) {
+	resolved := func(string) (bool, error) { return true, nil }
+	unresolved := func(string) (bool, error) { return false, nil }
+
+	cases := []struct {
+		name     string
+		status   *client.Status
+		resolved func(string) (bool, error)
+		severity Severity
+	}{
+		{"reachable", &client.Status{Gateway: "192.168.1.1", GatewayInterface: "eth0"}, resolved, SeverityOK},
+		{"not answering", &client.Status{Gateway: "192.168.1.1", GatewayInterface: "eth0"}, unresolved, SeverityWarning},
+		{"routed over vpn", &client.Status{Gateway: "10.8.0.1", GatewayInterface: "eth0"}, resolved, SeverityWarning},
+		{"not detected", &client.Status{}, resolved, SeverityFailed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.severity, CheckGatewayReachable(c.status, table, c.resolved).Severity)
+		})
+	}
+}
+
+func TestCheckDNSServers(t *testing.T) {
+	routes := []*client.Route{{Destination: "10.0.0.0/8"}, {Destination: "example.org"}}
+	var queried []string
+	query := func(server, domain string) ([]string, error) {
+		if server == "192.0.2.53:53" {
+			return nil, errors.New("i/o timeout")
+		}
+
+		queried = append(queried, domain)
+
+		return []string{"1.2.3.4"}, nil
+	}
+
+	findings := CheckDNSServers([]string{"system", "192.0.2.53:53"}, routes, query)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, SeverityOK, findings[0].Severity)
+		assert.Equal(t, SeverityFailed, findings[1].Severity)
+	}
+
+	// first domain among the destinations is queried
+	assert.Equal(t, []string{"example.org"}, queried)
+}
+
+func TestCheckSocketAccess(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "grpc.sock")
+
+	assert.Equal(t, SeverityFailed, CheckSocketAccess(path).Severity)
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer lis.Close()
+	assert.Equal(t, SeverityOK, CheckSocketAccess(path).Severity)
+
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0600))
+	assert.Equal(t, SeverityFailed, CheckSocketAccess(file).Severity)
+}
+
+func TestCheckDaemonStatus(t *testing.T) {
+	assert.Equal(t, SeverityFailed, CheckDaemonStatus(nil, errors.New("connection refused")).Severity)
+
+	finding := CheckDaemonStatus(&client.Status{Version: "v1.0.0", Uptime: 90 * time.Second, Backend: utils.RouteBackend}, nil)
+	assert.Equal(t, SeverityOK, finding.Severity)
+	assert.Equal(t, "daemon v1.0.0 is up for 1m30s with the iproute2 backend", finding.Message)
+}
@@ -0,0 +1,171 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type is the type of an Event
+type Type string
+
+const (
+	// EntryAdded is published when a new entry is added to the state, or when its routes are installed by enabling its
+	// group
+	EntryAdded Type = "entry-added"
+	// EntryRemoved is published when an entry is removed from the state, or when its routes are removed by disabling
+	// its group
+	EntryRemoved Type = "entry-removed"
+	// IPsChanged is published when the routed IPs of an entry change
+	IPsChanged Type = "ips-changed"
+	// RouteFailed is published when the routes of some IPs of an entry cannot be changed
+	RouteFailed Type = "route-failed"
+	// GatewayChanged is published when the default non-VPN gateway changes
+	GatewayChanged Type = "gateway-changed"
+	// ConfigReloaded is published when the config is reloaded
+	ConfigReloaded Type = "config-reloaded"
+)
+
+// subscriptionBuffer is the number of the live events that a Subscription can fall behind before it is dropped
+const subscriptionBuffer = 64
+
+// Event is a change on the routes or the state of the daemon
+type Event struct {
+	// Sequence is the increasing number of the Event, it is assigned by the Bus
+	Sequence uint64
+	Type     Type
+	// Time is the time that the Event is published at, it is assigned by the Bus if it is not set
+	Time time.Time
+	// Domain is the domain of the entry that the Event is about, empty for the events that are not about an entry
+	Domain string
+	// IPs are the routed IPs of the entry, or the failed ones for RouteFailed
+	IPs []string
+	// AddedIPs and RemovedIPs are the changes on the routed IPs of the entry for IPsChanged
+	AddedIPs   []string
+	RemovedIPs []string
+	// Gateway is the gateway of the entry, or the new gateway for GatewayChanged
+	Gateway string
+	// PreviousGateway is the old gateway for GatewayChanged
+	PreviousGateway string
+	// Error is the error of the failed routes for RouteFailed
+	Error string
+}
+
+// Filter returns true for the events that are delivered to a Subscription, nil Filter matches all the events
+type Filter func(e *Event) bool
+
+// Bus delivers the published events to the subscriptions and keeps the last events to replay them to the new ones
+type Bus struct {
+	mu            sync.Mutex
+	sequence      uint64
+	history       []*Event
+	size          int
+	subscriptions map[*Subscription]bool
+}
+
+// Subscription receives the events that match its Filter from the Bus
+type Subscription struct {
+	// C delivers the events, it is closed when the Subscription is closed or when it falls behind the live events
+	C      <-chan *Event
+	c      chan *Event
+	filter Filter
+	bus    *Bus
+	// dropped is true if the Subscription is closed because it fell behind
+	dropped bool
+}
+
+// NewBus creates a new Bus that keeps the last size events for replay
+func NewBus(size int) *Bus {
+	return &Bus{
+		size:          size,
+		subscriptions: make(map[*Subscription]bool),
+	}
+}
+
+// Publish assigns the sequence of the given Event and delivers it to the matching subscriptions, it never blocks.
+// Subscriptions that cannot keep up are closed. Publishing to a nil Bus is a no-op
+func (b *Bus) Publish(e *Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	e.Sequence = b.sequence
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.size {
+		b.history = b.history[len(b.history)-b.size:]
+	}
+
+	for sub := range b.subscriptions {
+		if !sub.match(e) {
+			continue
+		}
+
+		select {
+		case sub.c <- e:
+		default:
+			sub.dropped = true
+			b.unsubscribe(sub)
+		}
+	}
+}
+
+// Subscribe returns a Subscription that receives the events matching the given Filter. Up to replay of the last
+// matching events are delivered first
+func (b *Bus) Subscribe(filter Filter, replay int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replayed []*Event
+	for i := len(b.history) - 1; i >= 0 && len(replayed) < replay; i-- {
+		if filter == nil || filter(b.history[i]) {
+			replayed = append(replayed, b.history[i])
+		}
+	}
+
+	c := make(chan *Event, len(replayed)+subscriptionBuffer)
+	for i := len(replayed) - 1; i >= 0; i-- {
+		c <- replayed[i]
+	}
+
+	sub := &Subscription{C: c, c: c, filter: filter, bus: b}
+	b.subscriptions[sub] = true
+
+	return sub
+}
+
+// Close stops the delivery of the events to the Subscription, it is safe to call it more than once
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.unsubscribe(s)
+}
+
+// Dropped returns true if the Subscription is closed because it fell behind the live events
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	return s.dropped
+}
+
+func (s *Subscription) match(e *Event) bool {
+	return s.filter == nil || s.filter(e)
+}
+
+// unsubscribe removes the given Subscription and closes its channel, the lock of the Bus is held by the caller
+func (b *Bus) unsubscribe(sub *Subscription) {
+	if !b.subscriptions[sub] {
+		return
+	}
+
+	delete(b.subscriptions, sub)
+	close(sub.c)
+}
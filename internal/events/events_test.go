@@ -0,0 +1,82 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// drain returns the events that are buffered in the Subscription without blocking
+func drain(sub *Subscription) []*Event {
+	var events []*Event
+	for {
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return events
+			}
+
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestBus_Replay(t *testing.T) {
+	bus := NewBus(3)
+	for _, domain := range []string{"a.com", "b.com", "c.com", "d.com"} {
+		bus.Publish(&Event{Type: EntryAdded, Domain: domain})
+	}
+
+	// only the last 3 events are kept, the replayed ones come in order before the live ones
+	sub := bus.Subscribe(nil, 10)
+	defer sub.Close()
+	bus.Publish(&Event{Type: EntryRemoved, Domain: "b.com"})
+
+	events := drain(sub)
+	if !assert.Len(t, events, 4) {
+		return
+	}
+
+	assert.Equal(t, []uint64{2, 3, 4, 5}, []uint64{events[0].Sequence, events[1].Sequence, events[2].Sequence, events[3].Sequence})
+	assert.Equal(t, EntryRemoved, events[3].Type)
+	assert.False(t, events[3].Time.IsZero())
+}
+
+func TestBus_Filter(t *testing.T) {
+	bus := NewBus(10)
+	bus.Publish(&Event{Type: EntryAdded, Domain: "a.com"})
+	bus.Publish(&Event{Type: IPsChanged, Domain: "a.com"})
+	bus.Publish(&Event{Type: EntryAdded, Domain: "b.com"})
+
+	sub := bus.Subscribe(func(e *Event) bool { return e.Domain == "a.com" }, 1)
+	defer sub.Close()
+	bus.Publish(&Event{Type: EntryRemoved, Domain: "b.com"})
+	bus.Publish(&Event{Type: EntryRemoved, Domain: "a.com"})
+
+	events := drain(sub)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+
+	assert.Equal(t, IPsChanged, events[0].Type)
+	assert.Equal(t, EntryRemoved, events[1].Type)
+}
+
+func TestBus_DropsSlowSubscriptions(t *testing.T) {
+	bus := NewBus(10)
+	sub := bus.Subscribe(nil, 0)
+	for i := 0; i <= subscriptionBuffer; i++ {
+		bus.Publish(&Event{Type: ConfigReloaded})
+	}
+
+	assert.True(t, sub.Dropped())
+	assert.Len(t, drain(sub), subscriptionBuffer)
+
+	_, ok := <-sub.C
+	assert.False(t, ok)
+
+	// closing a dropped subscription is a no-op
+	sub.Close()
+}
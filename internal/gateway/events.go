@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/server"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventTypePrefix is the prefix of the names of the RouteEventType values, the events are named without it in
+// kebab-case such as ips-changed
+const eventTypePrefix = "ROUTE_EVENT_TYPE_"
+
+// eventName returns the kebab-case name of the given event type
+func eventName(typ pb.RouteEventType) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(typ.String(), eventTypePrefix), "_", "-"))
+}
+
+// newWatchRequest returns the WatchRoutesRequest of the query of the given request, the type and destination
+// parameters can be repeated
+func newWatchRequest(r *http.Request) (*pb.WatchRoutesRequest, error) {
+	query := r.URL.Query()
+	req := &pb.WatchRoutesRequest{Destinations: query["destination"]}
+
+	for _, name := range query["type"] {
+		typ, ok := pb.RouteEventType_value[eventTypePrefix+strings.ToUpper(strings.ReplaceAll(name, "-", "_"))]
+		if !ok || typ == int32(pb.RouteEventType_ROUTE_EVENT_TYPE_UNSPECIFIED) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown event type %q", name)
+		}
+
+		req.Types = append(req.Types, pb.RouteEventType(typ))
+	}
+
+	if replay := query.Get("replay"); replay != "" {
+		n, err := strconv.ParseUint(replay, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid replay %q, must be a non-negative number", replay)
+		}
+
+		req.Replay = uint32(n)
+	}
+
+	return req, nil
+}
+
+// watch streams the route events as server-sent events, the ID of an event is its sequence and its data is the JSON
+// of the RouteEvent. The stream ends when the client disconnects
+func (g *Gateway) watch(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		g.writeError(w, status.Error(codes.Internal, "streaming is not supported"))
+		return
+	}
+
+	req, err := newWatchRequest(r)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := g.client.WatchRoutes(ctx, req)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	// header is sent once the subscription is established, the errors before that are returned as usual
+	header, err := stream.Header()
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	if ids := header.Get(server.RequestIDHeader); len(ids) > 0 {
+		w.Header().Set(RequestIDHeader, ids[0])
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			// the status of a stream that ends on the server side is sent as the last event
+			if ctx.Err() == nil {
+				body, _ := marshaller.Marshal(status.Convert(err).Proto())
+				_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", body)
+				flusher.Flush()
+			}
+
+			return
+		}
+
+		data, err := marshaller.Marshal(e)
+		if err != nil {
+			g.logger.Error().Err(err).Msg(constants.FailedToWriteHTTPResponse)
+			return
+		}
+
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.GetSequence(), eventName(e.GetType()), data); err != nil {
+			return
+		}
+
+		flusher.Flush()
+	}
+}
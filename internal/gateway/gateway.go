@@ -0,0 +1,450 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/server"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDHeader is the HTTP header of the request ID, it is forwarded to the gRPC server and sent back in the response
+const RequestIDHeader = "X-Request-Id"
+
+// destinationParam is the path parameter of the endpoints of a single destination, CIDRs must be percent-encoded
+const destinationParam = "{destination}"
+
+// httpStatuses maps the gRPC codes to the HTTP statuses, the codes that are not in the map are 500
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// marshaller emits the unset fields too, so that the clients see the same fields on every response
+var marshaller = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// connKey is the context key of the connection of an HTTP request
+type connKey struct{}
+
+// handler handles a request of an endpoint, destination is the decoded path parameter of the endpoint if it has one
+type handler func(g *Gateway, ctx context.Context, w http.ResponseWriter, r *http.Request, destination string)
+
+// endpoint is an HTTP endpoint of the Gateway that is mapped onto an RPC of the RouteManager service
+type endpoint struct {
+	method string
+	path   string
+	// rpc is the name of the method of the RouteManager service
+	rpc     string
+	summary string
+	handle  handler
+}
+
+// endpoints are the endpoints of the Gateway, they are also the paths of the OpenAPI document
+var endpoints = []endpoint{
+	{http.MethodGet, "/v1/routes", "ListRoutes", "List the routes", (*Gateway).listRoutes},
+	{http.MethodPost, "/v1/routes", "AddRoute", "Add a destination", (*Gateway).addRoute},
+	{http.MethodPost, "/v1/routes:purge", "Purge", "Remove every destination", (*Gateway).purge},
+	{http.MethodGet, "/v1/routes:export", "ExportRoutes", "Export the destinations as a list", (*Gateway).exportRoutes},
+	{http.MethodPost, "/v1/routes:import", "ImportRoutes", "Import the destinations of a list", (*Gateway).importRoutes},
+	{http.MethodGet, "/v1/routes/" + destinationParam, "GetRoute", "Get a destination", (*Gateway).getRoute},
+	{http.MethodPatch, "/v1/routes/" + destinationParam, "UpdateRoute", "Update the expiry or the accumulate mode of a destination", (*Gateway).updateRoute},
+	{http.MethodDelete, "/v1/routes/" + destinationParam, "RemoveRoute", "Remove a destination", (*Gateway).removeRoute},
+	{http.MethodGet, "/v1/status", "Status", "Get the status of the daemon", (*Gateway).status},
+	{http.MethodGet, "/v1/trace/" + destinationParam, "TraceRoute", "Explain whether the traffic to a host bypasses VPN", (*Gateway).traceRoute},
+	{http.MethodGet, "/v1/events", "WatchRoutes", "Stream the route events as server-sent events", (*Gateway).watch},
+}
+
+// openAPIPath is the path of the OpenAPI document of the Gateway
+const openAPIPath = "/v1/openapi.json"
+
+// match reports whether the given escaped path matches the path of the endpoint, destination is the decoded path
+// parameter
+func (e endpoint) match(path string) (destination string, ok bool) {
+	prefix, isParam := strings.CutSuffix(e.path, destinationParam)
+	if !isParam {
+		return "", path == e.path
+	}
+
+	escaped, found := strings.CutPrefix(path, prefix)
+	if !found || escaped == "" || strings.Contains(escaped, "/") {
+		return "", false
+	}
+
+	destination, err := url.PathUnescape(escaped)
+
+	return destination, err == nil
+}
+
+// Gateway is the HTTP/JSON gateway of the RouteManager service. Every request is translated into a call of the gRPC
+// server of the daemon, so that the calls are logged, recovered and attributed to their callers the same way
+type Gateway struct {
+	client pb.RouteManagerClient
+	logger zerolog.Logger
+}
+
+// NewGateway returns a Gateway that calls the RouteManager service with the given client
+func NewGateway(client pb.RouteManagerClient, logger zerolog.Logger) *Gateway {
+	return &Gateway{client: client, logger: logger}
+}
+
+// Dial returns a connection to the gRPC socket of the daemon for the Gateway
+func Dial(grpcSocketPath string) (*grpc.ClientConn, error) {
+	return grpc.NewClient("unix://"+grpcSocketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUserAgent("stt-gateway/"+version.Get().GitVersion))
+}
+
+// NewHTTPServer returns an http.Server that serves the given Gateway, the credentials of the clients on a unix socket
+// are forwarded to the gRPC server as their Caller. The event streams are ended when the server is shut down, they
+// would keep it from shutting down otherwise
+func NewHTTPServer(g *Gateway) *http.Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	httpServer := &http.Server{
+		Handler:           g,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connKey{}, c)
+		},
+	}
+
+	httpServer.RegisterOnShutdown(cancel)
+
+	return httpServer
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if httpStatus, st := checkOrigin(r); st != nil {
+		g.writeStatus(w, httpStatus, st)
+		return
+	}
+
+	path := r.URL.EscapedPath()
+	if path == openAPIPath {
+		g.openAPI(w, r)
+		return
+	}
+
+	var allowed []string
+	for _, e := range endpoints {
+		destination, ok := e.match(path)
+		if !ok {
+			continue
+		}
+
+		if e.method != r.Method {
+			allowed = append(allowed, e.method)
+			continue
+		}
+
+		if httpStatus, st := checkMutation(r); st != nil {
+			g.writeStatus(w, httpStatus, st)
+			return
+		}
+
+		e.handle(g, g.outgoingContext(r), w, r, destination)
+
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		g.writeStatus(w, http.StatusMethodNotAllowed, status.Newf(codes.Unimplemented, "method %s is not allowed", r.Method))
+
+		return
+	}
+
+	g.writeStatus(w, http.StatusNotFound, status.Newf(codes.NotFound, "no endpoint at %s", path))
+}
+
+// isUnixSocket reports whether the given request is received on the unix socket of the Gateway
+func isUnixSocket(r *http.Request) bool {
+	conn, ok := r.Context().Value(connKey{}).(net.Conn)
+
+	return ok && conn.LocalAddr().Network() == "unix"
+}
+
+// checkOrigin rejects the requests that the browsers send on behalf of the web pages, a web page can reach the loopback
+// port of the Gateway but not its unix socket. Requests with an Origin header are cross-origin ones, and the requests
+// on the port with a Host other than a loopback one are the ones of a DNS rebinding. The clients on the unix socket may
+// use any Host, such as http://unix
+func checkOrigin(r *http.Request) (int, *status.Status) {
+	if r.Header.Get("Origin") != "" {
+		return http.StatusForbidden, status.New(codes.PermissionDenied, "cross-origin requests are not allowed")
+	}
+
+	if isUnixSocket(r) {
+		return 0, nil
+	}
+
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return http.StatusForbidden, status.Newf(codes.PermissionDenied, "host %q is not a loopback host", r.Host)
+	}
+
+	return 0, nil
+}
+
+// checkMutation rejects the requests of the endpoints that change the routes unless they are received on the unix
+// socket, which is protected by its permissions and attributes the calls to their callers unlike the loopback port.
+// Their bodies must be JSON, so that they cannot be sent as the simple requests of HTML forms either
+func checkMutation(r *http.Request) (int, *status.Status) {
+	if r.Method == http.MethodGet {
+		return 0, nil
+	}
+
+	if !isUnixSocket(r) {
+		return http.StatusForbidden, status.Newf(codes.PermissionDenied,
+			"%s %s is served only on the unix socket of the gateway", r.Method, r.URL.Path)
+	}
+
+	if r.Method != http.MethodDelete {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			return http.StatusUnsupportedMediaType, status.Newf(codes.InvalidArgument,
+				"content type %q is not supported, must be application/json", r.Header.Get("Content-Type"))
+		}
+	}
+
+	return 0, nil
+}
+
+// outgoingContext returns the context of the gRPC call of the given request, with its Caller and its request ID
+func (g *Gateway) outgoingContext(r *http.Request) context.Context {
+	caller := server.Caller{PID: -1, UID: -1, GID: -1, Agent: r.UserAgent()}
+	if conn, ok := r.Context().Value(connKey{}).(net.Conn); ok {
+		caller = server.CallerOfConn(conn, r.UserAgent())
+	}
+
+	ctx := server.ForwardCaller(r.Context(), caller)
+	if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, server.RequestIDHeader, requestID)
+	}
+
+	return ctx
+}
+
+// readBody unmarshals the JSON body of the given request into the given message, an empty body leaves it as it is
+func readBody(r *http.Request, m proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read body: %v", err)
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := protojson.Unmarshal(body, m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid body: %v", err)
+	}
+
+	return nil
+}
+
+// listFormatPrefix is the prefix of the names of the RouteListFormat values, the format query parameter is named
+// without it such as csv
+const listFormatPrefix = "ROUTE_LIST_FORMAT_"
+
+// newExportRequest returns the ExportRoutesRequest of the format query parameter of the given request
+func newExportRequest(r *http.Request) (*pb.ExportRoutesRequest, error) {
+	req := &pb.ExportRoutesRequest{}
+	if name := r.URL.Query().Get("format"); name != "" {
+		format, ok := pb.RouteListFormat_value[listFormatPrefix+strings.ToUpper(name)]
+		if !ok || format == int32(pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown list format %q", name)
+		}
+
+		req.Format = pb.RouteListFormat(format)
+	}
+
+	return req, nil
+}
+
+// reply writes the response of a unary call, or its error. header is the response header of the call
+func (g *Gateway) reply(w http.ResponseWriter, header metadata.MD, resp proto.Message, err error) {
+	if ids := header.Get(server.RequestIDHeader); len(ids) > 0 {
+		w.Header().Set(RequestIDHeader, ids[0])
+	}
+
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	body, err := marshaller.Marshal(resp)
+	if err != nil {
+		g.writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		g.logger.Debug().Err(err).Msg(constants.FailedToWriteHTTPResponse)
+	}
+}
+
+// writeError writes the given error of a gRPC call as a google.rpc.Status with the HTTP status of its code
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	httpStatus, ok := httpStatuses[st.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	g.writeStatus(w, httpStatus, st)
+}
+
+// writeStatus writes the given status with the given HTTP status
+func (g *Gateway) writeStatus(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	body, err := protojson.Marshal(st.Proto())
+	if err != nil {
+		body = []byte(`{"code":13,"message":"failed to marshal error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	if _, err := w.Write(body); err != nil {
+		g.logger.Debug().Err(err).Msg(constants.FailedToWriteHTTPResponse)
+	}
+}
+
+func (g *Gateway) listRoutes(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	var header metadata.MD
+	resp, err := g.client.ListRoutes(ctx, &pb.ListRoutesRequest{}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) addRoute(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	req := &pb.AddRouteRequest{}
+	if err := readBody(r, req); err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	var header metadata.MD
+	resp, err := g.client.AddRoute(ctx, req, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) purge(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	var header metadata.MD
+	resp, err := g.client.Purge(ctx, &pb.PurgeRequest{}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) exportRoutes(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	req, err := newExportRequest(r)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	var header metadata.MD
+	resp, err := g.client.ExportRoutes(ctx, req, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) importRoutes(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	req := &pb.ImportRoutesRequest{}
+	if err := readBody(r, req); err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	var header metadata.MD
+	resp, err := g.client.ImportRoutes(ctx, req, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) getRoute(ctx context.Context, w http.ResponseWriter, r *http.Request, destination string) {
+	var header metadata.MD
+	resp, err := g.client.GetRoute(ctx, &pb.GetRouteRequest{Destination: destination}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) updateRoute(ctx context.Context, w http.ResponseWriter, r *http.Request, destination string) {
+	req := &pb.UpdateRouteRequest{}
+	if err := readBody(r, req); err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	// destination of the path takes precedence over the one in the body
+	req.Destination = destination
+
+	var header metadata.MD
+	resp, err := g.client.UpdateRoute(ctx, req, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) removeRoute(ctx context.Context, w http.ResponseWriter, r *http.Request, destination string) {
+	var header metadata.MD
+	resp, err := g.client.RemoveRoute(ctx, &pb.RemoveRouteRequest{Destination: destination}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) status(ctx context.Context, w http.ResponseWriter, r *http.Request, _ string) {
+	var header metadata.MD
+	resp, err := g.client.Status(ctx, &pb.StatusRequest{}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) traceRoute(ctx context.Context, w http.ResponseWriter, r *http.Request, host string) {
+	var header metadata.MD
+	resp, err := g.client.TraceRoute(ctx, &pb.TraceRouteRequest{Host: host}, grpc.Header(&header))
+	g.reply(w, header, resp, err)
+}
+
+func (g *Gateway) openAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		g.writeStatus(w, http.StatusMethodNotAllowed, status.Newf(codes.Unimplemented, "method %s is not allowed", r.Method))
+
+		return
+	}
+
+	doc, err := OpenAPI()
+	if err != nil {
+		g.writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(doc); err != nil {
+		g.logger.Debug().Err(err).Msg(constants.FailedToWriteHTTPResponse)
+	}
+}
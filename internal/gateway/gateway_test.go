@@ -0,0 +1,311 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/server"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGateway serves a Gateway of a gRPC server with the given state.State and events.Bus on a unix socket, and
+// returns an HTTP client of it. The URLs of the client can have any host
+func newTestGateway(t *testing.T, st *state.State, bus *events.Bus) *http.Client {
+	path := filepath.Join(t.TempDir(), "gateway.sock")
+	serveTestGateway(t, "unix", path, st, bus)
+
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+// serveTestGateway serves a Gateway of a gRPC server with the given state.State and events.Bus on the given address,
+// and returns the address that it listens on
+func serveTestGateway(t *testing.T, network, address string, st *state.State, bus *events.Bus) string {
+	lis := bufconn.Listen(1 << 20)
+	grpcServer, _ := server.NewGRPCServer(server.NewServer(st, bus, logging.GetLogger()), server.GRPCOptions{}, logging.GetLogger())
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	httpLis, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := NewHTTPServer(NewGateway(pb.NewRouteManagerClient(conn), logging.GetLogger()))
+	go func() { _ = httpServer.Serve(httpLis) }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	return httpLis.Addr().String()
+}
+
+// do sends a request with the given JSON body and returns the response with its body decoded into a map
+func do(t *testing.T, client *http.Client, method, url, body string) (*http.Response, map[string]any) {
+	t.Helper()
+
+	return doWithHeader(t, client, method, "http://stt"+url, body, http.Header{"Content-Type": {"application/json"}})
+}
+
+// doWithHeader sends a request with the given body and header to the given absolute URL and returns the response with
+// its body decoded into a map
+func doWithHeader(t *testing.T, client *http.Client, method, url, body string, header http.Header) (*http.Response, map[string]any) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header = header.Clone()
+	req.Header.Set(RequestIDHeader, "abc123")
+	if host := header.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, decoded
+}
+
+// errorReason returns the reason of the google.rpc.ErrorInfo detail of the given decoded google.rpc.Status
+func errorReason(body map[string]any) string {
+	details, _ := body["details"].([]any)
+	for _, detail := range details {
+		if d, ok := detail.(map[string]any); ok && d["@type"] == "type.googleapis.com/google.rpc.ErrorInfo" {
+			return d["reason"].(string)
+		}
+	}
+
+	return ""
+}
+
+func TestGateway_Routes(t *testing.T) {
+	client := newTestGateway(t, testutil.NewState(t,
+		state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}),
+		state.NewRouteEntry("10.0.0.0/8", "192.168.1.1", []string{"10.0.0.0/8"}),
+	), nil)
+
+	resp, body := do(t, client, http.MethodGet, "/v1/routes", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "abc123", resp.Header.Get(RequestIDHeader))
+	assert.Len(t, body["payload"].(map[string]any)["routes"], 2)
+
+	// slash of a CIDR is percent-encoded in the path
+	resp, body = do(t, client, http.MethodGet, "/v1/routes/10.0.0.0%2F8", "")
+	if assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		assert.Equal(t, "10.0.0.0/8", body["payload"].(map[string]any)["route"].(map[string]any)["domain"])
+	}
+
+	resp, body = do(t, client, http.MethodPatch, "/v1/routes/example.com", `{"ttl": "3600s", "accumulate": true}`)
+	if assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		route := body["payload"].(map[string]any)["route"].(map[string]any)
+		assert.Equal(t, true, route["accumulate"])
+		assert.NotNil(t, route["expiresAt"])
+	}
+
+	resp, body = do(t, client, http.MethodGet, "/v1/routes:export?format=text", "")
+	if assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		assert.Equal(t, "example.com\n10.0.0.0/8\n", body["payload"].(map[string]any)["content"])
+	}
+
+	resp, body = do(t, client, http.MethodPost, "/v1/routes:import", `{"content": "example.com\nexample.org\n", "dryRun": true}`)
+	if assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		assert.Len(t, body["payload"].(map[string]any)["routes"], 2)
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		status int
+		reason string
+	}{
+		{"unknown destination", http.MethodGet, "/v1/routes/example.org", "", http.StatusNotFound, "ROUTE_NOT_FOUND"},
+		{"unknown destination to remove", http.MethodDelete, "/v1/routes/example.org", "", http.StatusNotFound, "ROUTE_NOT_FOUND"},
+		{"negative ttl", http.MethodPost, "/v1/routes", `{"destination": "example.org", "ttl": "-60s"}`, http.StatusBadRequest, "INVALID_TTL"},
+		{"invalid body", http.MethodPatch, "/v1/routes/example.com", `{"ttl": 3600}`, http.StatusBadRequest, ""},
+		{"unknown list format", http.MethodGet, "/v1/routes:export?format=xml", "", http.StatusBadRequest, ""},
+		{"hosts export", http.MethodGet, "/v1/routes:export?format=hosts", "", http.StatusBadRequest, "INVALID_ROUTE_LIST"},
+		{"unknown endpoint", http.MethodGet, "/v1/groups", "", http.StatusNotFound, ""},
+		{"wrong method", http.MethodPut, "/v1/routes", "", http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, body := do(t, client, c.method, c.url, c.body)
+			assert.Equal(t, c.status, resp.StatusCode)
+			assert.NotEmpty(t, body["message"])
+			assert.Equal(t, c.reason, errorReason(body))
+		})
+	}
+}
+
+func TestGateway_Rejections(t *testing.T) {
+	st := testutil.NewState(t, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}))
+	client := newTestGateway(t, st, nil)
+	address := serveTestGateway(t, "tcp", "127.0.0.1:0", st, nil)
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+
+	cases := []struct {
+		name   string
+		client *http.Client
+		method string
+		url    string
+		header http.Header
+		status int
+	}{
+		{"origin on socket", client, http.MethodGet, "http://stt/v1/routes", http.Header{"Origin": {"http://example.com"}}, http.StatusForbidden},
+		{"origin on port", http.DefaultClient, http.MethodGet, "http://" + address + "/v1/routes", http.Header{"Origin": {"http://" + address}}, http.StatusForbidden},
+		{"rebound host", http.DefaultClient, http.MethodGet, "http://" + address + "/v1/routes", http.Header{"Host": {"attacker.example.com"}}, http.StatusForbidden},
+		{"mutation on port", http.DefaultClient, http.MethodDelete, "http://" + address + "/v1/routes/example.com", jsonHeader, http.StatusForbidden},
+		{"purge on port", http.DefaultClient, http.MethodPost, "http://" + address + "/v1/routes:purge", jsonHeader, http.StatusForbidden},
+		{"missing content type", client, http.MethodPost, "http://stt/v1/routes:purge", http.Header{}, http.StatusUnsupportedMediaType},
+		{"form content type", client, http.MethodPost, "http://stt/v1/routes", http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}, http.StatusUnsupportedMediaType},
+		{"text content type", client, http.MethodPatch, "http://stt/v1/routes/example.com", http.Header{"Content-Type": {"text/plain"}}, http.StatusUnsupportedMediaType},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, body := doWithHeader(t, c.client, c.method, c.url, "", c.header)
+			assert.Equal(t, c.status, resp.StatusCode)
+			assert.NotEmpty(t, body["message"])
+		})
+	}
+
+	// the routes are left as they are
+	assert.NotNil(t, st.GetEntry("example.com"))
+
+	// reads are served on the port, with a loopback host
+	for _, host := range []string{address, "localhost:8080", "[::1]:8080"} {
+		resp, _ := doWithHeader(t, http.DefaultClient, http.MethodGet, "http://"+address+"/v1/routes", "", http.Header{"Host": {host}})
+		assert.Equal(t, http.StatusOK, resp.StatusCode, host)
+	}
+
+	resp, _ := doWithHeader(t, client, http.MethodPost, "http://stt/v1/routes:import", `{"content": "example.org\n", "dryRun": true}`,
+		http.Header{"Content-Type": {"application/json; charset=utf-8"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGateway_Events(t *testing.T) {
+	bus := events.NewBus(10)
+	client := newTestGateway(t, nil, bus)
+
+	bus.Publish(&events.Event{Type: events.EntryAdded, Domain: "example.com"})
+	bus.Publish(&events.Event{Type: events.IPsChanged, Domain: "example.com", AddedIPs: []string{"1.1.1.1"}})
+
+	resp, body := do(t, client, http.MethodGet, "/v1/events?type=dns-changed", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, body["message"], "dns-changed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://stt/v1/events?type=ips-changed&type=gateway-changed&replay=5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = client.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if line == "\n" {
+				return lines
+			}
+
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+
+	// replayed event is the only matching one in the history
+	lines := readEvent()
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "id: 2", lines[0])
+		assert.Equal(t, "event: ips-changed", lines[1])
+		assert.Contains(t, lines[2], `"addedIps":["1.1.1.1"]`)
+	}
+
+	bus.Publish(&events.Event{Type: events.EntryRemoved, Domain: "example.com"})
+	bus.Publish(&events.Event{Type: events.GatewayChanged, Gateway: "10.0.0.2", PreviousGateway: "10.0.0.1"})
+
+	lines = readEvent()
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "id: 4", lines[0])
+		assert.Equal(t, "event: gateway-changed", lines[1])
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc, err := OpenAPI()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// document is shipped next to the proto, it is regenerated with make protogen
+	shipped, err := os.ReadFile(filepath.Join("..", "..", "proto", "routemanager.openapi.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, string(shipped), string(doc), "proto/routemanager.openapi.json is out of date, run make protogen")
+
+	resp, body := do(t, newTestGateway(t, nil, nil), http.MethodGet, openAPIPath, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "3.0.3", body["openapi"])
+
+	paths := body["paths"].(map[string]any)
+	for _, e := range endpoints {
+		assert.Contains(t, paths[e.path], strings.ToLower(e.method))
+	}
+}
@@ -0,0 +1,253 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// schemaRef returns the reference of the schema of the given name in the components of the OpenAPI document
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// wellKnownSchemas are the schemas of the well-known types, in the forms that they take in JSON
+var wellKnownSchemas = map[protoreflect.FullName]map[string]any{
+	"google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":  {"type": "string", "description": "duration in seconds with the s suffix, such as 3600s"},
+	"google.protobuf.BoolValue": {"type": "boolean", "nullable": true},
+}
+
+// statusSchema is the schema of the google.rpc.Status that is returned on errors, see the API errors section of the
+// README for its details
+var statusSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"code":    map[string]any{"type": "integer", "format": "int32", "description": "gRPC code of the error"},
+		"message": map[string]any{"type": "string"},
+		"details": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type":                 "object",
+				"properties":           map[string]any{"@type": map[string]any{"type": "string"}},
+				"additionalProperties": true,
+			},
+			"description": "google.rpc.ErrorInfo with the StatusCode as its reason, and google.rpc.BadRequest for invalid arguments",
+		},
+	},
+}
+
+// schemas collects the schemas of the messages and the enums of the proto in the components of the OpenAPI document
+type schemas map[string]any
+
+// field returns the schema of the given field, the messages and enums that it refers are added to the schemas
+func (s schemas) field(fd protoreflect.FieldDescriptor) map[string]any {
+	var schema map[string]any
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		schema = map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		schema = map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		schema = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are strings in JSON, they do not fit in the numbers of JavaScript
+		schema = map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema = map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		schema = map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = map[string]any{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		schema = schemaRef(s.enum(fd.Enum()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if wellKnown, ok := wellKnownSchemas[fd.Message().FullName()]; ok {
+			schema = wellKnown
+		} else {
+			schema = schemaRef(s.message(fd.Message()))
+		}
+	}
+
+	if fd.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+
+	return schema
+}
+
+// enum adds the schema of the given enum and returns its name, enums are the strings of the names of their values
+func (s schemas) enum(ed protoreflect.EnumDescriptor) string {
+	name := string(ed.Name())
+	if _, ok := s[name]; ok {
+		return name
+	}
+
+	values := make([]string, 0, ed.Values().Len())
+	for i := 0; i < ed.Values().Len(); i++ {
+		values = append(values, string(ed.Values().Get(i).Name()))
+	}
+
+	s[name] = map[string]any{"type": "string", "enum": values}
+
+	return name
+}
+
+// message adds the schema of the given message and the ones that it refers, and returns its name
+func (s schemas) message(md protoreflect.MessageDescriptor) string {
+	name := string(md.Name())
+	if _, ok := s[name]; ok {
+		return name
+	}
+
+	properties := make(map[string]any, md.Fields().Len())
+	schema := map[string]any{"type": "object", "properties": properties}
+
+	// added before its fields, so that the messages that refer themselves do not recurse forever
+	s[name] = schema
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		properties[fd.JSONName()] = s.field(fd)
+	}
+
+	return name
+}
+
+// jsonContent returns the content of an OpenAPI request or response with the given schema
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// operation returns the OpenAPI operation of the given endpoint, the schemas of its messages are added to the schemas
+func (s schemas) operation(e endpoint, method protoreflect.MethodDescriptor) map[string]any {
+	op := map[string]any{
+		"operationId": e.rpc,
+		"summary":     e.summary,
+		"responses": map[string]any{
+			"default": map[string]any{
+				"description": "error of the call, its HTTP status is derived from its gRPC code",
+				"content":     jsonContent(schemaRef("Status")),
+			},
+		},
+	}
+
+	var parameters []any
+	if strings.HasSuffix(e.path, destinationParam) {
+		parameters = append(parameters, map[string]any{
+			"name":        "destination",
+			"in":          "path",
+			"required":    true,
+			"description": "domain, IP address or CIDR block, the slash of a CIDR block must be percent-encoded as %2F",
+			"schema":      map[string]any{"type": "string"},
+		})
+	}
+
+	if e.rpc == "ExportRoutes" {
+		var formats []string
+		values := pb.RouteListFormat(0).Descriptor().Values()
+		for i := 0; i < values.Len(); i++ {
+			if format := pb.RouteListFormat(values.Get(i).Number()); format != pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED {
+				formats = append(formats, strings.ToLower(strings.TrimPrefix(format.String(), listFormatPrefix)))
+			}
+		}
+
+		parameters = append(parameters, map[string]any{"name": "format", "in": "query",
+			"description": "format of the list, json if it is not set. hosts is accepted by the import only",
+			"schema":      map[string]any{"type": "string", "enum": formats}})
+	}
+
+	output := s.message(method.Output())
+	if method.IsStreamingServer() {
+		var types []string
+		values := pb.RouteEventType(0).Descriptor().Values()
+		for i := 0; i < values.Len(); i++ {
+			if typ := pb.RouteEventType(values.Get(i).Number()); typ != pb.RouteEventType_ROUTE_EVENT_TYPE_UNSPECIFIED {
+				types = append(types, eventName(typ))
+			}
+		}
+
+		parameters = append(parameters,
+			map[string]any{"name": "type", "in": "query", "description": "filters the events by their types, can be repeated",
+				"schema": map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": types}},
+				"style":  "form", "explode": true},
+			map[string]any{"name": "destination", "in": "query", "description": "filters the events by their destinations, can be repeated",
+				"schema": map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, "style": "form", "explode": true},
+			map[string]any{"name": "replay", "in": "query", "description": "number of the last matching events to send before the live ones",
+				"schema": map[string]any{"type": "integer", "format": "int64", "minimum": 0}},
+		)
+
+		op["responses"].(map[string]any)["200"] = map[string]any{
+			"description": "server-sent events, the id of an event is its sequence, its name is its type such as " +
+				"ips-changed and its data is a " + output + ". A stream that ends on the daemon side ends with an " +
+				"error event whose data is a Status",
+			"content": map[string]any{"text/event-stream": map[string]any{"schema": schemaRef(output)}},
+		}
+	} else {
+		op["responses"].(map[string]any)["200"] = map[string]any{"description": "OK", "content": jsonContent(schemaRef(output))}
+	}
+
+	if e.method == http.MethodPost || e.method == http.MethodPatch {
+		if input := method.Input(); input.Fields().Len() > 0 {
+			op["requestBody"] = map[string]any{"required": true, "content": jsonContent(schemaRef(s.message(input)))}
+		}
+	}
+
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	return op
+}
+
+// OpenAPI returns the OpenAPI 3 document of the Gateway, built from the descriptors of proto/routemanager.proto. The
+// document is shipped as proto/routemanager.openapi.json
+func OpenAPI() ([]byte, error) {
+	service := pb.File_routemanager_proto.Services().ByName("RouteManager")
+	s := schemas{"Status": statusSchema}
+
+	paths := make(map[string]map[string]any)
+	for _, e := range endpoints {
+		method := service.Methods().ByName(protoreflect.Name(e.rpc))
+		if method == nil {
+			return nil, errors.Errorf("unknown rpc %s of endpoint %s %s", e.rpc, e.method, e.path)
+		}
+
+		if paths[e.path] == nil {
+			paths[e.path] = make(map[string]any)
+		}
+
+		paths[e.path][strings.ToLower(e.method)] = s.operation(e, method)
+	}
+
+	paths[openAPIPath] = map[string]any{"get": map[string]any{
+		"operationId": "OpenAPI",
+		"summary":     "Get this document",
+		"responses":   map[string]any{"200": map[string]any{"description": "OK", "content": jsonContent(map[string]any{"type": "object"})}},
+	}}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "split-the-tunnel",
+			"version":     "v1",
+			"description": "HTTP/JSON gateway of the " + string(service.FullName()) + " gRPC service of the split-the-tunnel daemon",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": s},
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal OpenAPI document")
+	}
+
+	return append(out, '\n'), nil
+}
@@ -2,8 +2,6 @@ package ipc
 
 import (
 	"bufio"
-	"encoding/json"
-	"fmt"
 	"io"
 	"net"
 	"strings"
@@ -65,303 +63,235 @@ func handleConnection(st *state.State, conn net.Conn, logger zerolog.Logger) {
 			break
 		}
 
-		command := strings.TrimSpace(message)
-		logger.Info().Str("command", command).Msg("received command")
+		req, err := parseRequest(message)
+		if err != nil {
+			logger.Error().Err(err).Msg(constants.MalformedRequest)
+
+			// the ID of a malformed request is unknown, the error is reported without an ID and the connection is kept
+			w := &responseWriter{w: conn}
+			if err := w.writeError(err); err != nil || w.end() != nil {
+				break
+			}
 
+			continue
+		}
+
+		if req.ID == "" {
+			req.ID = newRequestID()
+		}
+
+		logger := logger.With().Str("requestId", req.ID).Logger()
+		logger.Info().Str("command", req.Command).Msg("received command")
+
+		w := &responseWriter{id: req.ID, w: conn}
+
+		st.Lock()
 		if err := st.Reload(); err != nil {
 			logger.Error().Err(err).Msg(constants.FailedToReloadState)
-			continue
+		} else {
+			processCommand(logger, req.Command, w, st)
 		}
+		st.Unlock()
 
-		processCommand(logger, command, conn, st)
+		// the end marker is written for every request, so that the client never waits for a response that never comes
+		if err := w.end(); err != nil {
+			logger.Error().Err(err).Msg(constants.FailedToWriteToUnixDomainSocket)
+			break
+		}
 	}
 }
 
-// processCommand processes the given command and calls the appropriate handler
-func processCommand(logger zerolog.Logger, command string, conn net.Conn, st *state.State) {
+// processCommand processes the given command and calls the appropriate handler, every command gets exactly one
+// response
+func processCommand(logger zerolog.Logger, command string, w *responseWriter, st *state.State) {
 	parts := strings.Fields(command)
 	if len(parts) == 0 {
 		logger.Error().Msg(constants.EmptyCommandReceived)
+		writeError(logger, w, errors.New(constants.EmptyCommandReceived))
+
 		return
 	}
 
-	switch parts[0] {
-	case "add":
-		logger = logger.With().Str("operation", "add").Logger()
+	name, args := parts[0], parts[1:]
+	logger = logger.With().Str("operation", name).Logger()
 
+	switch name {
+	case "add", "remove":
+		if len(args) == 0 {
+			writeError(logger, w, errors.Errorf(constants.MissingArguments, name))
+			return
+		}
+	case "list", "purge":
+		if len(args) > 0 {
+			writeError(logger, w, errors.Errorf(constants.UnexpectedArguments, name))
+			return
+		}
+	}
+
+	switch name {
+	case "add":
 		// get default gateway
 		gw, err := utils.GetDefaultNonVPNGateway()
 		if err != nil {
 			logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+			writeError(logger, w, errors.Wrap(err, constants.FailedToGetDefaultGateway))
+
 			return
 		}
 
-		handleAddCommand(logger, gw, parts[1:], conn, st)
+		writeItems(logger, w, handleAddCommand(logger, gw, args, st))
 	case "remove":
-		logger = logger.With().Str("operation", "remove").Logger()
-
-		handleRemoveCommand(logger, parts[1:], conn, st)
+		writeItems(logger, w, handleRemoveCommand(logger, args, st))
 	case "list":
-		logger = logger.With().Str("operation", "list").Logger()
-
-		handleListCommand(logger, conn, st)
+		handleListCommand(logger, w, st)
 	case "purge":
-		logger = logger.With().Str("operation", "purge").Logger()
+		if len(st.Entries) == 0 {
+			writeError(logger, w, errors.New(constants.NoRoutesToPurge))
+			return
+		}
 
-		handlePurgeCommand(logger, conn, st)
+		writeItems(logger, w, handlePurgeCommand(logger, st))
+	default:
+		logger.Error().Str("command", name).Msg(constants.UnknownCommand)
+		writeError(logger, w, errors.Errorf("%s: %s", constants.UnknownCommand, name))
 	}
 }
 
 // handleAddCommand handles the add command and adds the given domains to the routing table
-func handleAddCommand(logger zerolog.Logger, gw string, domains []string, conn net.Conn, st *state.State) {
-	logger = logger.With().Str("operation", "add").Logger()
-	resp := new(DaemonResponse)
-
+func handleAddCommand(logger zerolog.Logger, gw string, domains []string, st *state.State) []*ItemResult {
+	items := make([]*ItemResult, 0, len(domains))
 	for _, domain := range domains {
-		ips, err := utils.ResolveDomain(domain)
+		item := &ItemResult{Domain: domain}
+		items = append(items, item)
+
+		res, err := utils.Resolve(domain)
 		if err != nil {
 			logger.Error().Err(err).Str("domain", domain).Msg(constants.FailedToResolveDomain)
-
-			resp.Success = false
-			resp.Response = ""
-			resp.Error = errors.Wrap(err, constants.FailedToResolveDomain).Error()
-
-			if err := writeResponse(&DaemonResponse{
-				Success:  false,
-				Response: "",
-				Error:    errors.Wrap(err, constants.FailedToResolveDomain).Error(),
-			}, conn); err != nil {
-				logger.Error().
-					Err(err).
-					Str("domain", domain).
-					Msg(constants.FailedToWriteToUnixDomainSocket)
-			}
+			item.fail(StatusResolveFailed, errors.Wrap(err, constants.FailedToResolveDomain))
 
 			continue
 		}
 
-		re := state.NewRouteEntry(domain, gw, ips)
-
-		if err := st.AddEntry(re); err != nil {
-			logger.Error().Err(err).Str("domain", domain).Msg("failed to add route to state")
-
-			if err := writeResponse(&DaemonResponse{
-				Success:  false,
-				Response: "",
-				Error:    errors.Wrapf(err, "failed to write RouteEntry to state for domain %s", domain).Error(),
-			}, conn); err != nil {
-				logger.Error().
-					Err(err).
-					Str("domain", domain).
-					Msg(constants.FailedToWriteToUnixDomainSocket)
-			}
-
-			continue
-		}
-
-		for _, ip := range re.ResolvedIPs {
-			if err := utils.AddRoute(ip, re.Gateway); err != nil {
-				logger.Error().Err(err).Str("domain", domain).Str("ip", ip).Msg("failed to add route to routing table")
-
-				if err := writeResponse(&DaemonResponse{
-					Success:  false,
-					Response: "",
-					Error:    errors.Wrapf(err, "failed to add route for domain %s to routing table", domain).Error(),
-				}, conn); err != nil {
-					logger.Error().
-						Err(err).
-						Str("domain", domain).
-						Msg(constants.FailedToWriteToUnixDomainSocket)
-				}
+		item.IPs = res.IPs
 
+		if err := st.InstallEntry(state.NewResolvedEntry(domain, gw, res)); err != nil {
+			if errors.Cause(err).Error() == constants.EntryAlreadyExists {
+				item.Status = StatusAlreadyExists
 				continue
 			}
 
+			failEntry(logger, item, st, err, constants.FailedToAddRouteEntry)
+			continue
 		}
 
 		logger.Info().Str("domain", domain).Msg("successfully added route to routing table")
-
-		if err := writeResponse(&DaemonResponse{
-			Success:  true,
-			Response: fmt.Sprintf("added route for " + domain),
-			Error:    "",
-		}, conn); err != nil {
-			logger.Error().
-				Err(err).
-				Str("domain", domain).
-				Msg(constants.FailedToWriteToUnixDomainSocket)
-		}
+		item.Status = StatusAdded
 	}
+
+	return items
 }
 
 // handlePurgeCommand removes all the routes from the routing table by looking at the state
-func handlePurgeCommand(logger zerolog.Logger, conn net.Conn, st *state.State) {
-	logger = logger.With().Str("operation", "purge").Logger()
-	resp := new(DaemonResponse)
-
-	if len(st.Entries) == 0 {
-		resp.Success = false
-		resp.Response = ""
-		resp.Error = errors.New(constants.NoRoutesToPurge).Error()
-
-		if err := writeResponse(resp, conn); err != nil {
-			logger.Error().
-				Err(err).
-				Msg(constants.FailedToWriteToUnixDomainSocket)
-		}
-
-		return
-	}
-
-	for _, entry := range st.Entries {
-		for _, ip := range entry.ResolvedIPs {
-			if err := utils.RemoveRoute(ip); err != nil {
-				logger.Error().Err(err).Str("domain", entry.Domain).Str("ip", ip).Msg("failed to remove route from routing table")
-
-				resp.Success = false
-				resp.Response = ""
-				resp.Error = errors.Wrapf(err, "failed to remove route for domain %s from routing table", entry.Domain).Error()
+func handlePurgeCommand(logger zerolog.Logger, st *state.State) []*ItemResult {
+	// entries are removed from the State one by one, so the list is copied before
+	entries := append([]*state.RouteEntry{}, st.Entries...)
 
-				if err := writeResponse(resp, conn); err != nil {
-					logger.Error().
-						Err(err).
-						Str("domain", entry.Domain).
-						Msg(constants.FailedToWriteToUnixDomainSocket)
-				}
+	items := make([]*ItemResult, 0, len(entries))
+	for _, entry := range entries {
+		item := &ItemResult{Domain: entry.Domain, IPs: entry.ResolvedIPs, Status: StatusRemoved}
+		items = append(items, item)
 
-				continue
-			}
+		if _, err := st.UninstallEntry(entry.Domain); err != nil {
+			failEntry(logger, item, st, err, constants.FailedToRemoveRouteEntry)
+			continue
 		}
 
 		logger.Info().Str("domain", entry.Domain).Msg("successfully removed route from routing table")
 	}
 
-	st.Entries = make([]*state.RouteEntry, 0)
-	if err := st.Write(); err != nil {
-		logger.Error().Err(err).Msg(constants.FailedToWriteState)
-
-		resp.Success = false
-		resp.Response = ""
-		resp.Error = errors.Wrap(err, constants.FailedToWriteState).Error()
-
-		if err := writeResponse(resp, conn); err != nil {
-			logger.Error().
-				Err(err).
-				Msg(constants.FailedToWriteToUnixDomainSocket)
-		}
-
-		return
-	}
-
-	resp.Success = true
-	resp.Response = constants.PurgedAllRoutes
-	resp.Error = ""
-
-	if err := writeResponse(resp, conn); err != nil {
-		logger.Error().
-			Err(err).
-			Msg(constants.FailedToWriteToUnixDomainSocket)
-	}
+	return items
 }
 
 // handleRemoveCommand removes the given domains from the routing table
-func handleRemoveCommand(logger zerolog.Logger, domains []string, conn net.Conn, st *state.State) {
-	logger = logger.With().Str("operation", "remove").Logger()
-	resp := new(DaemonResponse)
-
+func handleRemoveCommand(logger zerolog.Logger, domains []string, st *state.State) []*ItemResult {
+	items := make([]*ItemResult, 0, len(domains))
 	for _, domain := range domains {
+		item := &ItemResult{Domain: domain}
+		items = append(items, item)
+
 		entry := st.GetEntry(domain)
 		if entry == nil {
-			resp.Success = false
-			resp.Response = ""
-			resp.Error = errors.Wrap(errors.New(constants.EntryNotFound), constants.FailedToRemoveRouteEntry).Error()
-
-			if err := writeResponse(resp, conn); err != nil {
-				logger.Error().
-					Err(err).
-					Str("domain", domain).
-					Msg(constants.FailedToWriteToUnixDomainSocket)
-			}
-
+			item.fail(StatusNotFound, errors.New(constants.EntryNotFound))
 			continue
 		}
 
-		if err := st.RemoveEntry(domain); err != nil {
-			logger.Error().Err(err).Str("domain", domain).Msg(constants.FailedToRemoveRouteEntry)
-
-			resp.Success = false
-			resp.Response = ""
-			resp.Error = errors.Wrap(err, constants.FailedToRemoveRouteEntry).Error()
-
-			if err := writeResponse(resp, conn); err != nil {
-				logger.Error().
-					Err(err).
-					Str("domain", domain).
-					Msg(constants.FailedToWriteToUnixDomainSocket)
-			}
+		item.IPs = entry.ResolvedIPs
 
+		if _, err := st.UninstallEntry(domain); err != nil {
+			failEntry(logger, item, st, err, constants.FailedToRemoveRouteEntry)
 			continue
 		}
 
-		for _, ip := range entry.ResolvedIPs {
-			if err := utils.RemoveRoute(ip); err != nil {
-				logger.Error().Err(err).Str("domain", domain).Str("ip", ip).Msg("failed to remove route from routing table")
-
-				resp.Success = false
-				resp.Response = ""
-				resp.Error = errors.Wrapf(err, "failed to remove route for domain %s from routing table", domain).Error()
-
-				if err := writeResponse(resp, conn); err != nil {
-					logger.Error().
-						Err(err).
-						Str("domain", domain).
-						Msg(constants.FailedToWriteToUnixDomainSocket)
-				}
-
-				continue
-			}
-		}
-
 		logger.Info().Str("domain", domain).Msg("successfully removed route from routing table")
-
-		resp.Success = true
-		resp.Response = fmt.Sprintf("removed route for " + domain)
-		resp.Error = ""
-
-		if err := writeResponse(resp, conn); err != nil {
-			logger.Error().
-				Err(err).
-				Str("domain", domain).
-				Msg(constants.FailedToWriteToUnixDomainSocket)
-		}
+		item.Status = StatusRemoved
 	}
-}
 
-func handleListCommand(logger zerolog.Logger, conn net.Conn, st *state.State) {
-	logger = logger.With().Str("operation", "list").Logger()
+	return items
+}
 
+// handleListCommand writes the entries in the state as the response
+func handleListCommand(logger zerolog.Logger, w *responseWriter, st *state.State) {
 	str, err := state.ToStringSlice(st.Entries)
 	if err != nil {
 		logger.Error().
 			Err(err).
 			Msg(constants.FailedToMarshalResponse)
+		writeError(logger, w, errors.Wrap(err, constants.FailedToMarshalResponse))
+
 		return
 	}
 
-	response := new(DaemonResponse)
-	response.Response = str
-	responseJson, err := json.Marshal(response)
-	if err != nil {
+	if err := w.writeResponse(&DaemonResponse{Success: true, Response: str}); err != nil {
 		logger.Error().
 			Err(err).
-			Msg(constants.FailedToMarshalResponse)
+			Msg(constants.FailedToWriteToUnixDomainSocket)
+	}
+}
+
+// failEntry marks the item as failed by looking at the error of the State. Failures of the routes report the IPs
+// that are routed after the operation
+func failEntry(logger zerolog.Logger, item *ItemResult, st *state.State, err error, msg string) {
+	var routeErr *state.RouteError
+	if !errors.As(err, &routeErr) {
+		logger.Error().Err(err).Str("domain", item.Domain).Msg(msg)
+		item.fail(StatusStateFailed, errors.Wrap(err, msg))
+
 		return
 	}
 
-	if _, err := conn.Write(responseJson); err != nil {
+	logger.Error().Err(err).Str("domain", item.Domain).Msg(constants.FailedToApplyRoutes)
+	item.fail(StatusRouteFailed, err)
+
+	item.IPs = nil
+	if entry := st.GetEntry(item.Domain); entry != nil {
+		item.IPs = entry.ResolvedIPs
+	}
+}
+
+// writeItems writes the aggregated response of a batch operation
+func writeItems(logger zerolog.Logger, w *responseWriter, items []*ItemResult) {
+	if err := w.writeResponse(NewItemsResponse(items)); err != nil {
+		logger.Error().
+			Err(err).
+			Msg(constants.FailedToWriteToUnixDomainSocket)
+	}
+}
+
+// writeError writes a response that reports the failure of the whole command
+func writeError(logger zerolog.Logger, w *responseWriter, err error) {
+	if err := w.writeError(err); err != nil {
 		logger.Error().
 			Err(err).
 			Msg(constants.FailedToWriteToUnixDomainSocket)
-		return
 	}
 }
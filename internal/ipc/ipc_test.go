@@ -0,0 +1,167 @@
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendCommand sends the given command to the IPC on socketPath like the NDJSON clients do and reads its response
+func sendCommand(t *testing.T, socketPath, command string) []*DaemonResponse {
+	conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	defer conn.Close()
+
+	assert.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	req := &Request{ID: newRequestID(), Command: command}
+	assert.NoError(t, writeFrame(conn, req))
+
+	responses, err := readResponses(bufio.NewReader(conn), req.ID)
+	assert.NoError(t, err)
+
+	return responses
+}
+
+// readResponses reads the frames of the response of the request with the given ID until the end marker
+func readResponses(reader *bufio.Reader, id string) ([]*DaemonResponse, error) {
+	var responses []*DaemonResponse
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return responses, err
+		}
+
+		response := new(DaemonResponse)
+		if err := json.Unmarshal(line, response); err != nil {
+			return responses, err
+		}
+
+		if response.ID != id {
+			return responses, fmt.Errorf("unexpected response id %s, expected %s", response.ID, id)
+		}
+
+		if response.End {
+			return responses, nil
+		}
+
+		responses = append(responses, response)
+	}
+}
+
+// startIPC starts the IPC on a temporary socket with a state that has the given number of entries
+func startIPC(t *testing.T, entries int) string {
+	dir := t.TempDir()
+	st := state.NewState(logging.GetLogger(), filepath.Join(dir, constants.StateFileName))
+	for i := 0; i < entries; i++ {
+		st.Entries = append(st.Entries, state.NewRouteEntry(fmt.Sprintf("domain-%d.example.com", i), "10.0.0.1", []string{"1.1.1.1", "1.0.0.1"}))
+	}
+	assert.NoError(t, st.Write())
+
+	socketPath := filepath.Join(dir, constants.SocketFileName)
+	assert.NoError(t, InitIPC(st, socketPath, logging.GetLogger()))
+
+	return socketPath
+}
+
+func TestHandleConnection_LargeList(t *testing.T) {
+	socketPath := startIPC(t, 100)
+
+	responses := sendCommand(t, socketPath, "list")
+	assert.Len(t, responses, 1)
+	assert.Greater(t, len(responses[0].Response), 1024)
+
+	entries, err := state.FromStringSlice(responses[0].Response)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 100)
+}
+
+func TestHandleConnection_BatchResults(t *testing.T) {
+	socketPath := startIPC(t, 0)
+
+	responses := sendCommand(t, socketPath, "remove a.example.com b.example.com")
+	assert.Len(t, responses, 1)
+	assert.False(t, responses[0].Success)
+	assert.Equal(t, "2 of 2 items failed", responses[0].Error)
+	assert.Equal(t, "2 not-found", responses[0].Response)
+	assert.Len(t, responses[0].Items, 2)
+	assert.Equal(t, "a.example.com", responses[0].Items[0].Domain)
+	assert.Equal(t, "b.example.com", responses[0].Items[1].Domain)
+
+	for _, item := range responses[0].Items {
+		assert.Equal(t, StatusNotFound, item.Status)
+		assert.Equal(t, constants.EntryNotFound, item.Error)
+	}
+}
+
+func TestHandleConnection_InvalidCommands(t *testing.T) {
+	socketPath := startIPC(t, 0)
+
+	cases := map[string]string{
+		"foo example.com": constants.UnknownCommand + ": foo",
+		"remove":          "remove command requires at least one domain",
+		"list all":        "list command takes no arguments",
+		"purge":           constants.NoRoutesToPurge,
+	}
+
+	for command, expected := range cases {
+		responses := sendCommand(t, socketPath, command)
+		assert.Len(t, responses, 1)
+		assert.False(t, responses[0].Success)
+		assert.Equal(t, expected, responses[0].Error)
+	}
+}
+
+func TestHandleConnection_PlainCommands(t *testing.T) {
+	socketPath := startIPC(t, 1)
+
+	conn, err := net.Dial("unix", socketPath)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for i := 0; i < 2; i++ {
+		_, err = conn.Write([]byte("list\n"))
+		assert.NoError(t, err)
+
+		line, err := reader.ReadBytes('\n')
+		assert.NoError(t, err)
+
+		// the daemon assigns an ID to the plain commands and ends the response with a frame of the same ID
+		response := new(DaemonResponse)
+		assert.NoError(t, json.Unmarshal(line, response))
+		assert.NotEmpty(t, response.ID)
+		assert.True(t, response.Success)
+
+		rest, err := readResponses(reader, response.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, rest)
+	}
+}
+
+func TestHandleConnection_MalformedRequest(t *testing.T) {
+	socketPath := startIPC(t, 0)
+
+	conn, err := net.Dial("unix", socketPath)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("{\"id\": 1}\n"))
+	assert.NoError(t, err)
+
+	responses, err := readResponses(bufio.NewReader(conn), "")
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Contains(t, responses[0].Error, constants.MalformedRequest)
+}
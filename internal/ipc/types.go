@@ -1,8 +1,115 @@
 package ipc
 
-// DaemonResponse is the struct that holds the response of the daemon to the client
+import (
+	"fmt"
+	"strings"
+)
+
+// Request is a single frame that is sent by the client to the daemon. Plain text lines such as "list" are accepted
+// too for the tools like socat, the daemon assigns an ID to them
+type Request struct {
+	// ID is the identifier of the request, it is echoed back in every frame of the response
+	ID string `json:"id"`
+	// Command is the command line, such as "add example.com example.org"
+	Command string `json:"command"`
+}
+
+// DaemonResponse is the struct that holds the response of the daemon to the client. A response is a single frame,
+// followed by a frame that has End set
 type DaemonResponse struct {
+	// ID is the identifier of the request that the response belongs to
+	ID       string `json:"id"`
 	Success  bool   `json:"success"`
 	Response string `json:"response"`
 	Error    string `json:"error"`
+	// Items are the results of the batch operations such as add, remove and purge, one per domain
+	Items []*ItemResult `json:"items,omitempty"`
+	// End marks the end of the response, the frame that has it set carries no result
+	End bool `json:"end,omitempty"`
+}
+
+// ItemStatus is the outcome of a single item of a batch operation
+type ItemStatus string
+
+const (
+	StatusAdded         ItemStatus = "added"
+	StatusAlreadyExists ItemStatus = "already-exists"
+	StatusRemoved       ItemStatus = "removed"
+	StatusUpdated       ItemStatus = "updated"
+	StatusNotFound      ItemStatus = "not-found"
+	StatusResolveFailed ItemStatus = "resolve-failed"
+	StatusRouteFailed   ItemStatus = "route-failed"
+	StatusStateFailed   ItemStatus = "state-failed"
+	StatusInvalid       ItemStatus = "invalid"
+	// StatusPermissionDenied means the daemon is not permitted to change the routing table or to write its state
+	StatusPermissionDenied ItemStatus = "permission-denied"
+)
+
+// Failed returns true if the status reports a failure. An item that already exists is not a failure, since adding it
+// again leaves it in the requested state
+func (s ItemStatus) Failed() bool {
+	switch s {
+	case StatusAdded, StatusAlreadyExists, StatusRemoved, StatusUpdated:
+		return false
+	default:
+		return true
+	}
+}
+
+// ItemResult is the result of a single domain of a batch operation
+type ItemResult struct {
+	Domain string     `json:"domain"`
+	Status ItemStatus `json:"status"`
+	// IPs are the IPs that are affected by the operation
+	IPs   []string `json:"ips,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// fail marks the item as failed with the given status and error
+func (i *ItemResult) fail(status ItemStatus, err error) {
+	i.Status = status
+	i.Error = err.Error()
+}
+
+// NewItemsResponse aggregates the given results into a single DaemonResponse, it succeeds only if every item succeeds
+func NewItemsResponse(items []*ItemResult) *DaemonResponse {
+	resp := &DaemonResponse{Success: true, Response: Summarize(items), Items: items}
+
+	var failed int
+	for _, item := range items {
+		if item.Status.Failed() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		resp.Success = false
+		resp.Error = fmt.Sprintf("%d of %d items failed", failed, len(items))
+	}
+
+	return resp
+}
+
+// Summarize returns a summary of the given results such as "2 added, 1 already-exists"
+func Summarize(items []*ItemResult) string {
+	if len(items) == 0 {
+		return "no items"
+	}
+
+	var statuses []ItemStatus
+	counts := make(map[ItemStatus]int)
+	for _, item := range items {
+		if counts[item.Status] == 0 {
+			statuses = append(statuses, item.Status)
+		}
+
+		counts[item.Status]++
+	}
+
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+	}
+
+	return strings.Join(parts, ", ")
 }
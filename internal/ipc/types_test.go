@@ -0,0 +1,26 @@
+package ipc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewItemsResponse(t *testing.T) {
+	resp := NewItemsResponse([]*ItemResult{
+		{Domain: "a.example.com", Status: StatusAdded},
+		{Domain: "b.example.com", Status: StatusAlreadyExists},
+		{Domain: "c.example.com", Status: StatusAdded},
+	})
+	assert.True(t, resp.Success)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, "2 added, 1 already-exists", resp.Response)
+
+	resp = NewItemsResponse([]*ItemResult{
+		{Domain: "a.example.com", Status: StatusAdded},
+		{Domain: "b.example.com", Status: StatusResolveFailed, Error: "no such host"},
+	})
+	assert.False(t, resp.Success)
+	assert.Equal(t, "1 of 2 items failed", resp.Error)
+	assert.Equal(t, "1 added, 1 resolve-failed", resp.Response)
+}
@@ -1,9 +1,12 @@
 package ipc
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
-	"net"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
 	"github.com/pkg/errors"
@@ -16,14 +19,61 @@ func Cleanup(path string) error {
 	return os.Remove(path)
 }
 
-// writeResponse writes the response to the connection
-func writeResponse(response *DaemonResponse, conn net.Conn) error {
-	responseJson, err := json.Marshal(response)
+// responseWriter writes the frames of the response of a single request, frames are newline-delimited JSON objects
+type responseWriter struct {
+	id string
+	w  io.Writer
+}
+
+// writeResponse writes a single frame of the response
+func (rw *responseWriter) writeResponse(response *DaemonResponse) error {
+	response.ID = rw.id
+
+	return writeFrame(rw.w, response)
+}
+
+// writeError writes a frame that reports the failure of the whole request
+func (rw *responseWriter) writeError(err error) error {
+	return rw.writeResponse(&DaemonResponse{Success: false, Error: err.Error()})
+}
+
+// end writes the frame that marks the end of the response
+func (rw *responseWriter) end() error {
+	return writeFrame(rw.w, &DaemonResponse{ID: rw.id, End: true})
+}
+
+// writeFrame writes the given value as a single line of JSON
+func writeFrame(w io.Writer, v any) error {
+	frame, err := json.Marshal(v)
 	if err != nil {
 		return errors.Wrap(err, constants.FailedToMarshalResponse)
 	}
 
-	_, err = conn.Write(responseJson)
+	_, err = w.Write(append(frame, '\n'))
 
 	return err
 }
+
+// parseRequest parses a single line that is received from the client, lines that are not JSON are treated as plain
+// commands
+func parseRequest(line string) (*Request, error) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return &Request{Command: line}, nil
+	}
+
+	req := new(Request)
+	if err := json.Unmarshal([]byte(line), req); err != nil {
+		return nil, errors.Wrap(err, constants.MalformedRequest)
+	}
+
+	return req, nil
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"io"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -12,9 +13,16 @@ var (
 )
 
 func init() {
-	consoleWriter := zerolog.ConsoleWriter{Out: os.Stdout}
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	logger = zerolog.New(consoleWriter).With().Timestamp().Logger().Level(Level)
+	// level is controlled globally, so that it can be changed for the loggers that are already derived
+	zerolog.SetGlobalLevel(Level)
+	SetOutput(os.Stdout)
+}
+
+// SetOutput makes the loggers that are returned afterwards write to the given writer, such as os.Stderr for the CLI
+// whose results are written to stdout
+func SetOutput(w io.Writer) {
+	logger = zerolog.New(zerolog.ConsoleWriter{Out: w}).With().Timestamp().Logger()
 }
 
 func GetLogger() zerolog.Logger {
@@ -22,9 +30,15 @@ func GetLogger() zerolog.Logger {
 }
 
 func WithVerbose() zerolog.Logger {
-	return logger.Level(zerolog.DebugLevel)
+	EnableDebugLogging()
+	return logger
 }
 
 func EnableDebugLogging() {
-	logger = logger.Level(zerolog.DebugLevel)
+	SetLevel(zerolog.DebugLevel)
+}
+
+// SetLevel changes the level of all the loggers, including the ones that are derived before the call
+func SetLevel(level zerolog.Level) {
+	zerolog.SetGlobalLevel(level)
 }
@@ -1,8 +1,11 @@
 package logging
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,3 +18,21 @@ func TestGetLogger(t *testing.T) {
 func TestEnableDebugLogging(t *testing.T) {
 	EnableDebugLogging()
 }
+
+func TestSetLevel(t *testing.T) {
+	SetLevel(zerolog.WarnLevel)
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+
+	SetLevel(Level)
+	assert.Equal(t, Level, zerolog.GlobalLevel())
+}
+
+func TestSetOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(os.Stdout)
+
+	logger := GetLogger()
+	logger.Info().Msg("written to the buffer")
+	assert.Contains(t, buf.String(), "written to the buffer")
+}
@@ -0,0 +1,86 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables, such as STT_WORKSPACE
+	EnvPrefix = "STT"
+	// SystemConfigDir is the directory of the system-wide config file
+	SystemConfigDir = "/etc/split-the-tunnel"
+	// RuntimeDir is the directory of the sockets of the daemon
+	RuntimeDir = "/run/split-the-tunnel"
+	// StateDir is the directory of the state file of the daemon
+	StateDir = "/var/lib/split-the-tunnel"
+	// appDirName is the name of the user config directory under XDG_CONFIG_HOME
+	appDirName = "split-the-tunnel"
+)
+
+// Paths is the set of the file paths that are shared by the daemon and the CLI
+type Paths struct {
+	// ConfigFiles are the candidate config files in increasing precedence
+	ConfigFiles []string
+	// SocketPath is the path of the unix domain socket of the IPC
+	SocketPath string
+	// GrpcSocketPath is the path of the unix domain socket of the gRPC server
+	GrpcSocketPath string
+	// StatePath is the path of the state file
+	StatePath string
+}
+
+// Resolve returns the default Paths. If the workspace is given, every file is kept in it like the earlier versions.
+// Otherwise the user config file is layered under the system-wide config file, sockets live in RuntimeDir and the
+// state lives in StateDir, so that a daemon started by systemd and an unprivileged CLI find the same sockets
+func Resolve(workspace string) *Paths {
+	if workspace != "" {
+		return &Paths{
+			ConfigFiles:    []string{filepath.Join(workspace, constants.ConfigFileName)},
+			SocketPath:     filepath.Join(workspace, constants.SocketFileName),
+			GrpcSocketPath: filepath.Join(workspace, constants.GrpcSocketFileName),
+			StatePath:      filepath.Join(workspace, constants.StateFileName),
+		}
+	}
+
+	var configFiles []string
+	if userConfigDir, err := os.UserConfigDir(); err == nil {
+		configFiles = append(configFiles, filepath.Join(userConfigDir, appDirName, constants.ConfigFileName))
+	}
+
+	return &Paths{
+		ConfigFiles:    append(configFiles, filepath.Join(SystemConfigDir, constants.ConfigFileName)),
+		SocketPath:     filepath.Join(RuntimeDir, constants.SocketFileName),
+		GrpcSocketPath: filepath.Join(RuntimeDir, constants.GrpcSocketFileName),
+		StatePath:      filepath.Join(StateDir, constants.StateFileName),
+	}
+}
+
+// EnvName returns the environment variable of the given flag, such as STT_SOCKET_PATH for socket-path
+func EnvName(flag string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Setting returns the value of the given flag if it is set explicitly, then the value of its environment variable and
+// then the default value of the flag
+func Setting(flags *pflag.FlagSet, name string) string {
+	flag := flags.Lookup(name)
+	if flag != nil && flag.Changed {
+		return flag.Value.String()
+	}
+
+	if value, ok := os.LookupEnv(EnvName(name)); ok {
+		return value
+	}
+
+	if flag != nil {
+		return flag.Value.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,35 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	p := Resolve("/tmp/workspace")
+	assert.Equal(t, []string{"/tmp/workspace/config.toml"}, p.ConfigFiles)
+	assert.Equal(t, "/tmp/workspace/ipc.sock", p.SocketPath)
+	assert.Equal(t, "/tmp/workspace/state.json", p.StatePath)
+
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	p = Resolve("")
+	assert.Equal(t, []string{"/tmp/xdg/split-the-tunnel/config.toml", filepath.Join(SystemConfigDir, "config.toml")}, p.ConfigFiles)
+	assert.Equal(t, filepath.Join(RuntimeDir, "grpc.sock"), p.GrpcSocketPath)
+	assert.Equal(t, filepath.Join(StateDir, "state.json"), p.StatePath)
+}
+
+func TestSetting(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("socket-path", "default.sock", "")
+
+	assert.Equal(t, "default.sock", Setting(flags, "socket-path"))
+
+	t.Setenv("STT_SOCKET_PATH", "env.sock")
+	assert.Equal(t, "env.sock", Setting(flags, "socket-path"))
+
+	assert.NoError(t, flags.Set("socket-path", "flag.sock"))
+	assert.Equal(t, "flag.sock", Setting(flags, "socket-path"))
+}
@@ -0,0 +1,173 @@
+// Package preset is the catalog of the named presets of the destinations that the common services need, such as the
+// domains and the published CIDR blocks of Zoom or of the OS update servers. The built-in catalog is embedded in the
+// binary and versioned, local override files extend it with new presets or patch the built-in ones
+package preset
+
+import (
+	"bytes"
+	_ "embed"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+)
+
+//go:embed catalog.toml
+var builtin []byte
+
+// namePattern matches the names of the presets, they are also the names of their managed groups
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Preset is a named set of destinations that is expanded into a managed group when it is enabled
+type Preset struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	// Destinations are the hostnames, IP addresses and CIDR blocks of the preset. Wildcard patterns are rejected since
+	// they cannot be resolved to the IPs to route, the subdomains are listed by name instead
+	Destinations []string `toml:"destinations"`
+	// Overridden is set if the preset is added or patched by an override file
+	Overridden bool `toml:"-"`
+}
+
+// Catalog is the set of the presets that can be enabled
+type Catalog struct {
+	// Version is the version of the built-in catalog, the override files do not change it
+	Version int       `toml:"version"`
+	Presets []*Preset `toml:"presets"`
+}
+
+// Patch is a preset of an override file. A patch of a built-in preset replaces its description and its destinations
+// if they are set, then adds and removes the given destinations. Other patches add new presets
+type Patch struct {
+	Name         string   `toml:"name"`
+	Description  string   `toml:"description"`
+	Destinations []string `toml:"destinations"`
+	Add          []string `toml:"add"`
+	Remove       []string `toml:"remove"`
+}
+
+// override is the document of an override file
+type override struct {
+	Presets []*Patch `toml:"presets"`
+}
+
+// Builtin returns the built-in catalog without any override
+func Builtin() *Catalog {
+	catalog := new(Catalog)
+	if err := decode(builtin, catalog); err != nil {
+		panic(errors.Wrap(err, "invalid built-in preset catalog"))
+	}
+
+	return catalog
+}
+
+// Load returns the built-in catalog with the given override files applied in order, the files that do not exist are
+// skipped
+func Load(overrideFiles ...string) (*Catalog, error) {
+	catalog := Builtin()
+	for _, name := range overrideFiles {
+		doc, err := os.ReadFile(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, errors.Wrap(err, "failed to read preset overrides")
+		}
+
+		var o override
+		if err := decode(doc, &o); err != nil {
+			return nil, errors.Wrapf(err, "invalid preset overrides %s", name)
+		}
+
+		for _, patch := range o.Presets {
+			if err := catalog.apply(patch); err != nil {
+				return nil, errors.Wrapf(err, "invalid preset overrides %s", name)
+			}
+		}
+	}
+
+	sort.SliceStable(catalog.Presets, func(i, j int) bool { return catalog.Presets[i].Name < catalog.Presets[j].Name })
+
+	return catalog, nil
+}
+
+// Get returns the preset with the given name, nil if the catalog does not have it
+func (c *Catalog) Get(name string) *Preset {
+	for _, preset := range c.Presets {
+		if preset.Name == name {
+			return preset
+		}
+	}
+
+	return nil
+}
+
+// apply adds or patches the preset of the given Patch
+func (c *Catalog) apply(patch *Patch) error {
+	if !namePattern.MatchString(patch.Name) {
+		return errors.Errorf("invalid preset name %q, must consist of lowercase letters, digits and hyphens", patch.Name)
+	}
+
+	for _, destination := range append(append(append([]string{}, patch.Destinations...), patch.Add...), patch.Remove...) {
+		if strings.Contains(destination, "*") {
+			return errors.Errorf("preset %s: wildcard pattern %q is not supported, list the subdomains or the CIDR "+
+				"blocks of the service instead", patch.Name, destination)
+		}
+
+		if !utils.IsValidDestination(destination) {
+			return errors.Errorf("preset %s: invalid destination %q, must be a domain, an IP address or a CIDR block",
+				patch.Name, destination)
+		}
+	}
+
+	preset := c.Get(patch.Name)
+	if preset == nil {
+		preset = &Preset{Name: patch.Name}
+		c.Presets = append(c.Presets, preset)
+	}
+
+	preset.Overridden = true
+	if patch.Description != "" {
+		preset.Description = patch.Description
+	}
+
+	if patch.Destinations != nil {
+		preset.Destinations = nil
+	}
+
+	removed := make(map[string]bool, len(patch.Remove))
+	for _, destination := range patch.Remove {
+		removed[destination] = true
+	}
+
+	destinations := make([]string, 0, len(preset.Destinations)+len(patch.Destinations)+len(patch.Add))
+	seen := make(map[string]bool)
+	for _, destination := range append(append(preset.Destinations, patch.Destinations...), patch.Add...) {
+		if !removed[destination] && !seen[destination] {
+			seen[destination] = true
+			destinations = append(destinations, destination)
+		}
+	}
+
+	if len(destinations) == 0 {
+		return errors.Errorf("preset %s has no destination", patch.Name)
+	}
+
+	preset.Destinations = destinations
+
+	return nil
+}
+
+// decode strictly decodes the given TOML document, so that the typos of the keys are not ignored
+func decode(doc []byte, v interface{}) error {
+	decoder := toml.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
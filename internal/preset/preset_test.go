@@ -0,0 +1,104 @@
+package preset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltin(t *testing.T) {
+	catalog := Builtin()
+	assert.Positive(t, catalog.Version)
+	assert.NotNil(t, catalog.Get("zoom"))
+	assert.Nil(t, catalog.Get("missing"))
+
+	names := make(map[string]bool)
+	for _, preset := range catalog.Presets {
+		assert.Regexp(t, namePattern, preset.Name)
+		assert.False(t, names[preset.Name], "preset %s is declared more than once", preset.Name)
+		assert.NotEmpty(t, preset.Description, preset.Name)
+		assert.NotEmpty(t, preset.Destinations, preset.Name)
+		assert.False(t, preset.Overridden)
+		names[preset.Name] = true
+
+		// every destination is routed, so it must be a CIDR block, an IP address or a hostname that can be resolved
+		for _, destination := range preset.Destinations {
+			assert.True(t, utils.IsValidDestination(destination), "preset %s: %s", preset.Name, destination)
+			assert.NotContains(t, destination, "*", "preset %s", preset.Name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	system := filepath.Join(dir, "system.toml")
+	user := filepath.Join(dir, "user.toml")
+
+	assert.NoError(t, os.WriteFile(system, []byte(`
+[[presets]]
+name = "zoom"
+add = ["zoomgov.com"]
+remove = ["zoom.com", "www.zoom.com"]
+
+[[presets]]
+name = "jira"
+description = "Jira Cloud"
+destinations = ["atlassian.net", "atlassian.com"]
+`), 0644))
+	assert.NoError(t, os.WriteFile(user, []byte(`
+[[presets]]
+name = "jira"
+destinations = ["example.atlassian.net"]
+`), 0644))
+
+	catalog, err := Load(system, filepath.Join(dir, "missing.toml"), user)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, Builtin().Version, catalog.Version)
+
+	zoom := catalog.Get("zoom")
+	assert.True(t, zoom.Overridden)
+	assert.Contains(t, zoom.Destinations, "zoomgov.com")
+	assert.NotContains(t, zoom.Destinations, "zoom.com")
+	assert.Equal(t, Builtin().Get("zoom").Description, zoom.Description)
+
+	// the later files patch the presets of the earlier ones
+	assert.Equal(t, &Preset{Name: "jira", Description: "Jira Cloud", Destinations: []string{"example.atlassian.net"},
+		Overridden: true}, catalog.Get("jira"))
+	assert.False(t, catalog.Get("slack").Overridden)
+
+	for i := 1; i < len(catalog.Presets); i++ {
+		assert.Less(t, catalog.Presets[i-1].Name, catalog.Presets[i].Name)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      string
+		expected string
+	}{
+		{"unknown key", "[[presets]]\nname = \"zoom\"\nappend = [\"zoomgov.com\"]\n", "strict mode"},
+		{"invalid name", "[[presets]]\nname = \"My Preset\"\ndestinations = [\"example.com\"]\n", "invalid preset name \"My Preset\""},
+		{"invalid destination", "[[presets]]\nname = \"zoom\"\nadd = [\"not a domain\"]\n", "preset zoom: invalid destination \"not a domain\""},
+		{"wildcard destination", "[[presets]]\nname = \"jira\"\ndestinations = [\"*.atlassian.net\"]\n", "preset jira: wildcard pattern \"*.atlassian.net\" is not supported"},
+		{"no destination", "[[presets]]\nname = \"jira\"\ndescription = \"Jira Cloud\"\n", "preset jira has no destination"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "presets.toml")
+			assert.NoError(t, os.WriteFile(name, []byte(tc.doc), 0644))
+
+			_, err := Load(name)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expected)
+			}
+		})
+	}
+}
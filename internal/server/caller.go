@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Caller is the identity of the process that made a gRPC call
+type Caller struct {
+	// PID, UID and GID are the credentials of the calling process from the unix socket, they are -1 if they are not
+	// known, such as for the calls that are not made over a unix socket
+	PID int
+	UID int
+	GID int
+	// Agent is the user agent that the client declares, such as stt-cli/v1.2.3
+	Agent string
+}
+
+// callerKey is the context key of the Caller
+type callerKey struct{}
+
+// metadata keys of the Caller that is forwarded by ForwardCaller
+const (
+	forwardedPIDHeader   = "x-forwarded-caller-pid"
+	forwardedUIDHeader   = "x-forwarded-caller-uid"
+	forwardedGIDHeader   = "x-forwarded-caller-gid"
+	forwardedAgentHeader = "x-forwarded-caller-agent"
+)
+
+// CallerFromContext returns the Caller of the call that the given context belongs to, ok is false outside a call
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// peerCredentialsInfo is the credentials.AuthInfo of the connections that are accepted by peerCredentials
+type peerCredentialsInfo struct {
+	credentials.CommonAuthInfo
+	ucred *syscall.Ucred
+}
+
+func (peerCredentialsInfo) AuthType() string {
+	return "peercred"
+}
+
+// peerCredentials are the insecure transport credentials of the unix socket that read the credentials of the
+// connecting process on the server handshake
+type peerCredentials struct {
+	credentials.TransportCredentials
+}
+
+// newPeerCredentials returns the transport credentials of the gRPC server on the unix socket
+func newPeerCredentials() credentials.TransportCredentials {
+	return &peerCredentials{TransportCredentials: insecure.NewCredentials()}
+}
+
+func (c *peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	// credentials are best-effort, the connection is accepted without them
+	return conn, peerCredentialsInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		ucred:          unixCredentials(conn),
+	}, nil
+}
+
+// unixCredentials returns the credentials of the process on the other end of the given unix socket connection, nil
+// for the other connections
+func unixCredentials(conn net.Conn) *syscall.Ucred {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil
+	}
+
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return nil
+	}
+
+	var ucred *syscall.Ucred
+	_ = raw.Control(func(fd uintptr) {
+		ucred, _ = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+
+	return ucred
+}
+
+// CallerOfConn returns the Caller on the other end of the given connection with the given user agent, the
+// credentials are -1 if the connection is not a unix socket connection
+func CallerOfConn(conn net.Conn, agent string) Caller {
+	caller := Caller{PID: -1, UID: -1, GID: -1, Agent: agent}
+	if ucred := unixCredentials(conn); ucred != nil {
+		caller.PID, caller.UID, caller.GID = int(ucred.Pid), int(ucred.Uid), int(ucred.Gid)
+	}
+
+	return caller
+}
+
+// ForwardCaller returns a copy of the given outgoing context that forwards the given Caller to the Server. It is used
+// by the proxies in the daemon such as the HTTP gateway, the forwarded Caller is trusted only if the call is made by
+// the daemon itself
+func ForwardCaller(ctx context.Context, caller Caller) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		forwardedPIDHeader, strconv.Itoa(caller.PID),
+		forwardedUIDHeader, strconv.Itoa(caller.UID),
+		forwardedGIDHeader, strconv.Itoa(caller.GID),
+		forwardedAgentHeader, caller.Agent)
+}
+
+func (c *peerCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// callerOf returns the Caller of the call that the given incoming context belongs to
+func callerOf(ctx context.Context) Caller {
+	caller := Caller{PID: -1, UID: -1, GID: -1}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(peerCredentialsInfo); ok && info.ucred != nil {
+			caller.PID, caller.UID, caller.GID = int(info.ucred.Pid), int(info.ucred.Uid), int(info.ucred.Gid)
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	// grpc-go appends its own version to the user agent of the client, only the declared part is kept
+	if agents := md.Get("user-agent"); len(agents) > 0 {
+		if fields := strings.Fields(agents[0]); len(fields) > 0 {
+			caller.Agent = fields[0]
+		}
+	}
+
+	// any client could claim to be someone else, so only the callers that are forwarded by the daemon are trusted
+	if caller.PID != os.Getpid() || len(md.Get(forwardedAgentHeader)) == 0 {
+		return caller
+	}
+
+	forwarded := Caller{PID: forwardedID(md, forwardedPIDHeader), UID: forwardedID(md, forwardedUIDHeader),
+		GID: forwardedID(md, forwardedGIDHeader), Agent: md.Get(forwardedAgentHeader)[0]}
+
+	return forwarded
+}
+
+// forwardedID returns the ID in the given forwarded metadata key, -1 if it is missing or invalid
+func forwardedID(md metadata.MD, key string) int {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return -1
+	}
+
+	id, err := strconv.Atoi(values[0])
+	if err != nil {
+		return -1
+	}
+
+	return id
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ipStatuses maps the statuses of the routes in state.State to their protobuf counterparts
+var ipStatuses = map[state.IPStatus]pb.RouteIPStatus{
+	state.IPStatusInstalled: pb.RouteIPStatus_ROUTE_IP_STATUS_INSTALLED,
+	state.IPStatusPending:   pb.RouteIPStatus_ROUTE_IP_STATUS_PENDING,
+	state.IPStatusFailed:    pb.RouteIPStatus_ROUTE_IP_STATUS_FAILED,
+	state.IPStatusRemoved:   pb.RouteIPStatus_ROUTE_IP_STATUS_REMOVED,
+}
+
+// syncStatuses maps the outcomes of the subscription syncs to their protobuf counterparts
+var syncStatuses = map[state.SyncStatus]pb.SubscriptionSyncStatus{
+	state.SyncStatusOK:          pb.SubscriptionSyncStatus_SUBSCRIPTION_SYNC_STATUS_OK,
+	state.SyncStatusNotModified: pb.SubscriptionSyncStatus_SUBSCRIPTION_SYNC_STATUS_NOT_MODIFIED,
+	state.SyncStatusFailed:      pb.SubscriptionSyncStatus_SUBSCRIPTION_SYNC_STATUS_FAILED,
+}
+
+// eventTypes maps the types of the events to their protobuf counterparts
+var eventTypes = map[events.Type]pb.RouteEventType{
+	events.EntryAdded:     pb.RouteEventType_ROUTE_EVENT_TYPE_ENTRY_ADDED,
+	events.EntryRemoved:   pb.RouteEventType_ROUTE_EVENT_TYPE_ENTRY_REMOVED,
+	events.IPsChanged:     pb.RouteEventType_ROUTE_EVENT_TYPE_IPS_CHANGED,
+	events.RouteFailed:    pb.RouteEventType_ROUTE_EVENT_TYPE_ROUTE_FAILED,
+	events.GatewayChanged: pb.RouteEventType_ROUTE_EVENT_TYPE_GATEWAY_CHANGED,
+	events.ConfigReloaded: pb.RouteEventType_ROUTE_EVENT_TYPE_CONFIG_RELOADED,
+}
+
+// newRouteEvent converts the given events.Event into a pb.RouteEvent
+func newRouteEvent(e *events.Event) *pb.RouteEvent {
+	return &pb.RouteEvent{
+		Sequence:        e.Sequence,
+		Type:            eventTypes[e.Type],
+		Time:            timestamppb.New(e.Time),
+		Destination:     e.Domain,
+		Ips:             e.IPs,
+		AddedIps:        e.AddedIPs,
+		RemovedIps:      e.RemovedIPs,
+		Gateway:         e.Gateway,
+		PreviousGateway: e.PreviousGateway,
+		Error:           e.Error,
+	}
+}
+
+// newRoute converts the given state.RouteEntry into a pb.Route, active tells if the routes of the entry are installed
+func newRoute(entry *state.RouteEntry, active bool) *pb.Route {
+	ips := make([]*pb.RouteIP, 0, len(entry.Routes))
+	for _, route := range entry.Routes {
+		ips = append(ips, &pb.RouteIP{
+			Ip:        route.IP,
+			Status:    ipStatuses[route.Status],
+			LastError: route.LastError,
+			Attempts:  int32(route.Attempts),
+			FirstSeen: timestamppb.New(route.FirstSeen),
+			LastSeen:  timestamppb.New(route.LastSeen),
+			Resolvers: route.Resolvers,
+		})
+	}
+
+	route := &pb.Route{
+		Domain:     entry.Domain,
+		Gateway:    entry.Gateway,
+		Ips:        ips,
+		Accumulate: entry.Accumulate,
+		Group:      entry.Group,
+		Source:     entry.Source,
+		RoutedIps:  entry.ResolvedIPs,
+		Active:     active,
+		Tags:       entry.Tags,
+		Comment:    entry.Comment,
+	}
+
+	if entry.ExpiresAt != nil {
+		route.ExpiresAt = timestamppb.New(*entry.ExpiresAt)
+	}
+
+	return route
+}
+
+// newSubscriptionStatus converts the given state.Subscription into a pb.SubscriptionStatus
+func newSubscriptionStatus(sub *state.Subscription) *pb.SubscriptionStatus {
+	status := &pb.SubscriptionStatus{
+		Name:         sub.Name,
+		Url:          sub.URL,
+		Group:        sub.Group,
+		Status:       syncStatuses[sub.Status],
+		LastError:    sub.LastError,
+		Destinations: int32(sub.Destinations),
+		Invalid:      int32(sub.Invalid),
+	}
+
+	if sub.LastSync != nil {
+		status.LastSync = timestamppb.New(*sub.LastSync)
+	}
+
+	if sub.LastSuccess != nil {
+		status.LastSuccess = timestamppb.New(*sub.LastSuccess)
+	}
+
+	return status
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"os"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// grpcCodes maps the business error codes to the standard gRPC codes
+var grpcCodes = map[pb.StatusCode]codes.Code{
+	pb.StatusCode_INVALID_DESTINATION:  codes.InvalidArgument,
+	pb.StatusCode_ROUTE_NOT_FOUND:      codes.NotFound,
+	pb.StatusCode_ROUTE_ALREADY_EXISTS: codes.AlreadyExists,
+	pb.StatusCode_GROUP_NOT_FOUND:      codes.NotFound,
+	pb.StatusCode_GROUP_ALREADY_EXISTS: codes.AlreadyExists,
+	pb.StatusCode_INVALID_GROUP:        codes.FailedPrecondition,
+	pb.StatusCode_INTERNAL_ERROR:       codes.Internal,
+	pb.StatusCode_RESOLVE_FAILED:       codes.Unavailable,
+	pb.StatusCode_ROUTE_FAILED:         codes.Internal,
+	pb.StatusCode_GATEWAY_NOT_FOUND:    codes.Unavailable,
+	pb.StatusCode_PERMISSION_DENIED:    codes.PermissionDenied,
+	pb.StatusCode_STATE_WRITE_FAILED:   codes.Internal,
+	pb.StatusCode_INVALID_ROUTE_LIST:   codes.InvalidArgument,
+	pb.StatusCode_PRESET_NOT_FOUND:     codes.NotFound,
+	pb.StatusCode_INVALID_TTL:          codes.InvalidArgument,
+}
+
+// codedError is an error that carries its business error code, for the failures that cannot be told apart by their
+// root cause such as DNS and routing table errors
+type codedError struct {
+	code pb.StatusCode
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// errorCode returns the business error code of the given error by looking at its code or its root cause. Missing
+// privileges take precedence over the other codes, since they are the ones that the user can fix
+func errorCode(err error) pb.StatusCode {
+	if errors.Is(err, os.ErrPermission) {
+		return pb.StatusCode_PERMISSION_DENIED
+	}
+
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	var writeErr *state.WriteError
+	if errors.As(err, &writeErr) {
+		return pb.StatusCode_STATE_WRITE_FAILED
+	}
+
+	switch errors.Cause(err).Error() {
+	case constants.EntryAlreadyExists, constants.EntryAlreadyInGroup:
+		return pb.StatusCode_ROUTE_ALREADY_EXISTS
+	case constants.EntryNotFound:
+		return pb.StatusCode_ROUTE_NOT_FOUND
+	case constants.GroupNotFound:
+		return pb.StatusCode_GROUP_NOT_FOUND
+	case constants.GroupAlreadyExists:
+		return pb.StatusCode_GROUP_ALREADY_EXISTS
+	case constants.GroupAlreadyEnabled, constants.GroupAlreadyDisabled:
+		return pb.StatusCode_INVALID_GROUP
+	case constants.PresetNotFound, constants.PresetNotEnabled:
+		return pb.StatusCode_PRESET_NOT_FOUND
+	}
+
+	return pb.StatusCode_INTERNAL_ERROR
+}
+
+// newError converts the given error into the business error of a single item of a batch RPC
+func newError(err error) *pb.Error {
+	return &pb.Error{
+		Code:        errorCode(err),
+		Description: err.Error(),
+	}
+}
+
+// newStatusError converts the given error into a gRPC status error with the standard code of its business error code
+// and a google.rpc.ErrorInfo detail, metadata is the subject of the failed request such as the destination
+func newStatusError(err error, metadata map[string]string) error {
+	code := errorCode(err)
+
+	return withDetails(status.New(grpcCodes[code], err.Error()), &errdetails.ErrorInfo{
+		Reason:   code.String(),
+		Domain:   constants.ErrorDomain,
+		Metadata: metadata,
+	})
+}
+
+// newInvalidArgumentError returns the gRPC status error of a request with an invalid field, it carries a
+// google.rpc.BadRequest detail with the field besides the google.rpc.ErrorInfo detail of the given code
+func newInvalidArgumentError(code pb.StatusCode, field, description string) error {
+	return withDetails(status.New(codes.InvalidArgument, description),
+		&errdetails.ErrorInfo{Reason: code.String(), Domain: constants.ErrorDomain},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+		})
+}
+
+// withDetails returns the given status as an error with the given details, the status is returned without them if
+// they cannot be encoded
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// wrapStateError wraps the given error of a state.State operation with the given message, failures of the routes are
+// reported with pb.StatusCode_ROUTE_FAILED
+func wrapStateError(err error, msg string) error {
+	var routeErr *state.RouteError
+	if errors.As(err, &routeErr) {
+		return &codedError{code: pb.StatusCode_ROUTE_FAILED, err: err}
+	}
+
+	return errors.Wrap(err, msg)
+}
+
+// newGatewayError returns the error of the requests that fail since the default non-VPN gateway cannot be detected
+func newGatewayError(err error) error {
+	return &codedError{code: pb.StatusCode_GATEWAY_NOT_FOUND, err: errors.Wrap(err, constants.FailedToGetDefaultGateway)}
+}
+
+// newInvalidDestinationError returns the error of the requests without a destination
+func newInvalidDestinationError() error {
+	return newInvalidArgumentError(pb.StatusCode_INVALID_DESTINATION, "destination", "Destination cannot be empty")
+}
+
+// newInvalidGroupError returns the error of the requests without a group name
+func newInvalidGroupError() error {
+	return newInvalidArgumentError(pb.StatusCode_INVALID_GROUP, "name", "Group name cannot be empty")
+}
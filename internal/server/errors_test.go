@@ -0,0 +1,93 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assertStatusError asserts that the given error is a gRPC status error with the given code and a
+// google.rpc.ErrorInfo detail with the given reason
+func assertStatusError(t *testing.T, err error, grpcCode codes.Code, code pb.StatusCode) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !assert.True(t, ok, "not a status error: %v", err) {
+		return
+	}
+
+	assert.Equal(t, grpcCode, st.Code())
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, constants.ErrorDomain, info.GetDomain())
+			assert.Equal(t, code.String(), info.GetReason())
+
+			return
+		}
+	}
+
+	t.Errorf("status error without ErrorInfo detail: %v", err)
+}
+
+func TestNewError(t *testing.T) {
+	permissionErr := &state.RouteError{
+		Domain: "example.com",
+		Failed: map[string]error{"1.1.1.1": errors.Wrap(fmt.Errorf("RTNETLINK answers: Operation not permitted: %w", os.ErrPermission), "failed to add route")},
+	}
+
+	cases := []struct {
+		err  error
+		code pb.StatusCode
+	}{
+		{errors.Wrap(errors.New(constants.EntryAlreadyExists), constants.FailedToAddRouteEntry), pb.StatusCode_ROUTE_ALREADY_EXISTS},
+		{errors.New(constants.GroupNotFound), pb.StatusCode_GROUP_NOT_FOUND},
+		{&codedError{code: pb.StatusCode_RESOLVE_FAILED, err: errors.Wrap(errors.New("no such host"), constants.FailedToResolveDomain)}, pb.StatusCode_RESOLVE_FAILED},
+		{errors.Wrap(&codedError{code: pb.StatusCode_ROUTE_FAILED, err: errors.New("exit status 2")}, "failed"), pb.StatusCode_ROUTE_FAILED},
+		{newGatewayError(errors.New(constants.NonVPNGatewayNotFound)), pb.StatusCode_GATEWAY_NOT_FOUND},
+		{wrapStateError(permissionErr, constants.FailedToAddRouteEntry), pb.StatusCode_PERMISSION_DENIED},
+		{&state.WriteError{Err: &fs.PathError{Op: "open", Path: "state.json", Err: os.ErrPermission}}, pb.StatusCode_PERMISSION_DENIED},
+		{errors.Wrap(&state.WriteError{Err: &fs.PathError{Op: "write", Path: "state.json", Err: errors.New("no space left on device")}}, constants.FailedToAddRouteEntry), pb.StatusCode_STATE_WRITE_FAILED},
+		// the files that are only read, such as the subscribed lists, are not the state
+		{&fs.PathError{Op: "open", Path: "list.txt", Err: fs.ErrNotExist}, pb.StatusCode_INTERNAL_ERROR},
+		{errors.New("unexpected"), pb.StatusCode_INTERNAL_ERROR},
+	}
+
+	for _, c := range cases {
+		pbErr := newError(c.err)
+		assert.Equal(t, c.code, pbErr.GetCode())
+		assert.Equal(t, c.err.Error(), pbErr.GetDescription())
+	}
+}
+
+func TestNewStatusError(t *testing.T) {
+	err := newStatusError(errors.New(constants.EntryNotFound), destinationMetadata("example.com"))
+	assertStatusError(t, err, codes.NotFound, pb.StatusCode_ROUTE_NOT_FOUND)
+
+	st := status.Convert(err)
+	assert.Equal(t, constants.EntryNotFound, st.Message())
+	assert.Equal(t, map[string]string{"destination": "example.com"}, st.Details()[0].(*errdetails.ErrorInfo).GetMetadata())
+
+	err = newInvalidDestinationError()
+	assertStatusError(t, err, codes.InvalidArgument, pb.StatusCode_INVALID_DESTINATION)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "destination", violations[0].GetField())
+	}
+}
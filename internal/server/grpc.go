@@ -0,0 +1,45 @@
+package server
+
+import (
+	"time"
+
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// GRPCOptions are the options of the gRPC server of the daemon
+type GRPCOptions struct {
+	// Reflection registers the server reflection service, for the tools such as grpcurl
+	Reflection bool
+	// Timeout is the deadline of the unary calls that come without one, 0 disables it
+	Timeout time.Duration
+}
+
+// NewGRPCServer returns a grpc.Server that serves the given Server with the interceptors, the standard
+// grpc.health.v1 service and optionally the server reflection service. The returned health.Server reports
+// NOT_SERVING until it is resumed, so that the daemon can report its readiness after restoring its routes
+func NewGRPCServer(srv *Server, opts GRPCOptions, logger zerolog.Logger) (*grpc.Server, *health.Server) {
+	i := &interceptors{logger: logger, timeout: opts.Timeout}
+	grpcServer := grpc.NewServer(
+		grpc.Creds(newPeerCredentials()),
+		grpc.ChainUnaryInterceptor(i.unary),
+		grpc.ChainStreamInterceptor(i.stream),
+	)
+
+	pb.RegisterRouteManagerServer(grpcServer, srv)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(pb.RouteManager_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	if opts.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	return grpcServer, healthServer
+}
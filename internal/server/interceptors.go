@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key of the request ID, the ID of the client is used if it is sent, otherwise a new
+// one is generated. The ID is sent back in the response header
+const RequestIDHeader = "x-request-id"
+
+// loggerKey is the context key of the logger of a call
+type loggerKey struct{}
+
+// interceptors are the unary and stream server interceptors of the RouteManager service
+type interceptors struct {
+	logger zerolog.Logger
+	// timeout is the deadline of the unary calls that come without one, 0 disables it
+	timeout time.Duration
+}
+
+// loggerFromContext returns the logger of the call that the given context belongs to, or the given logger outside a call
+func loggerFromContext(ctx context.Context, logger zerolog.Logger) zerolog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(zerolog.Logger); ok {
+		return l
+	}
+
+	return logger
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// begin prepares the context of a call with its Caller and its logger that carries the request ID, the request ID is
+// sent back to the client
+func (i *interceptors) begin(ctx context.Context, method string) (context.Context, zerolog.Logger) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	caller := callerOf(ctx)
+	logger := i.logger.With().
+		Str("requestId", requestID).
+		Str("method", method).
+		Int("callerPid", caller.PID).
+		Int("callerUid", caller.UID).
+		Str("callerAgent", caller.Agent).
+		Logger()
+
+	ctx = context.WithValue(ctx, callerKey{}, caller)
+	ctx = context.WithValue(ctx, loggerKey{}, logger)
+
+	return ctx, logger
+}
+
+// end logs the outcome of a call
+func (i *interceptors) end(logger zerolog.Logger, start time.Time, err error) {
+	event := logger.Debug()
+	if status.Code(err) == codes.Internal || status.Code(err) == codes.Unknown {
+		event = logger.Error().Err(err)
+	}
+
+	event.Str("code", status.Code(err).String()).Dur("duration", time.Since(start)).Msg(constants.HandledGrpcCall)
+}
+
+// recoverPanic converts a panic of a handler into a codes.Internal error, so that a single bad call does not bring
+// the daemon down
+func recoverPanic(logger zerolog.Logger, err *error) {
+	if r := recover(); r != nil {
+		logger.Error().Any("panic", r).Str("stack", string(debug.Stack())).Msg(constants.RecoveredGrpcPanic)
+		*err = status.Error(codes.Internal, constants.RecoveredGrpcPanic)
+	}
+}
+
+// unary is the unary server interceptor, it applies the default deadline to the calls that come without one
+func (i *interceptors) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	ctx, logger := i.begin(ctx, info.FullMethod)
+	defer func() { i.end(logger, start, err) }()
+	defer recoverPanic(logger, &err)
+
+	if _, ok := ctx.Deadline(); !ok && i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+
+	return handler(ctx, req)
+}
+
+// stream is the stream server interceptor, streams such as WatchRoutes are long-lived so they get no default deadline
+func (i *interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx, logger := i.begin(ss.Context(), info.FullMethod)
+	defer func() { i.end(logger, start, err) }()
+	defer recoverPanic(logger, &err)
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream is a grpc.ServerStream with the context that is prepared by the stream interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
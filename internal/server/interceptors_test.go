@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptors_Unary(t *testing.T) {
+	i := &interceptors{logger: logging.GetLogger(), timeout: time.Minute}
+	info := &grpc.UnaryServerInfo{FullMethod: "/routemanager.RouteManager/AddRoute"}
+
+	// the calls without a deadline get the default one
+	_, err := i.unary(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+		_, ok = CallerFromContext(ctx)
+		assert.True(t, ok)
+
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	// a panic of the handler is returned as an internal error
+	_, err = i.unary(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestNewGRPCServer_Health(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpc.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer, healthServer := NewGRPCServer(NewServer(nil, nil, logging.GetLogger()), GRPCOptions{}, logging.GetLogger())
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	checkHealth := func() healthpb.HealthCheckResponse_ServingStatus {
+		r, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if !assert.NoError(t, err) {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+
+		return r.GetStatus()
+	}
+
+	// the daemon is not serving until its routes are restored
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth())
+	healthServer.Resume()
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth())
+
+	// the request ID of the client is sent back
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDHeader, "abc123")
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"abc123"}, header.Get(RequestIDHeader))
+	}
+}
+
+func TestPeerCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpc.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var caller Caller
+	grpcServer := grpc.NewServer(grpc.Creds(newPeerCredentials()), grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			caller = callerOf(ctx)
+			return handler(ctx, req)
+		}))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUserAgent("stt-cli/test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, Caller{PID: os.Getpid(), UID: os.Getuid(), GID: os.Getgid(), Agent: "stt-cli/test"}, caller)
+
+	// the call is made by the process itself, so the forwarded caller is trusted
+	forwarded := Caller{PID: 42, UID: 1000, GID: 1000, Agent: "curl/8.5.0"}
+	_, err = healthpb.NewHealthClient(conn).Check(ForwardCaller(context.Background(), forwarded), &healthpb.HealthCheckRequest{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, forwarded, caller)
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+)
+
+// listFormats maps the formats of the proto to the bypasslist.Formats
+var listFormats = map[pb.RouteListFormat]bypasslist.Format{
+	pb.RouteListFormat_ROUTE_LIST_FORMAT_JSON:  bypasslist.FormatJSON,
+	pb.RouteListFormat_ROUTE_LIST_FORMAT_TEXT:  bypasslist.FormatText,
+	pb.RouteListFormat_ROUTE_LIST_FORMAT_CSV:   bypasslist.FormatCSV,
+	pb.RouteListFormat_ROUTE_LIST_FORMAT_HOSTS: bypasslist.FormatHosts,
+}
+
+// newListFormat returns the proto format of the given bypasslist.Format
+func newListFormat(format bypasslist.Format) pb.RouteListFormat {
+	for pbFormat, f := range listFormats {
+		if f == format {
+			return pbFormat
+		}
+	}
+
+	return pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED
+}
+
+// newInvalidListError returns the error of the lists that cannot be read or written, field is the invalid field
+func newInvalidListError(field, description string) error {
+	return newInvalidArgumentError(pb.StatusCode_INVALID_ROUTE_LIST, field, description)
+}
+
+// ExportRoutes returns the destinations in the state as a list in the requested format, JSON if it is not set
+func (s *Server) ExportRoutes(ctx context.Context, req *pb.ExportRoutesRequest) (*pb.ExportRoutesResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	format := bypasslist.FormatJSON
+	if req.GetFormat() != pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED {
+		var ok bool
+		if format, ok = listFormats[req.GetFormat()]; !ok {
+			return nil, newInvalidListError("format", fmt.Sprintf("unknown list format %s", req.GetFormat()))
+		}
+	}
+
+	items := make([]*bypasslist.Item, 0, len(s.st.Entries))
+	for _, entry := range s.st.Entries {
+		items = append(items, &bypasslist.Item{
+			Destination: entry.Domain,
+			Group:       entry.Group,
+			Accumulate:  entry.Accumulate,
+			ExpiresAt:   entry.ExpiresAt,
+			Tags:        entry.Tags,
+			Comment:     entry.Comment,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := bypasslist.Write(&buf, format, items); err != nil {
+		return nil, newInvalidListError("format", err.Error())
+	}
+
+	return &pb.ExportRoutesResponse{
+		Payload: &pb.ExportRoutesPayload{Format: newListFormat(format), Content: buf.String(), Routes: int32(len(items))},
+	}, nil
+}
+
+// ImportRoutes adds the destinations of the given list that are not in the state yet, and removes the ones that are
+// not in the list with pb.ImportMode_IMPORT_MODE_REPLACE. Failures of the single destinations are reported in their
+// results, a dry run reports the planned actions without applying them
+func (s *Server) ImportRoutes(ctx context.Context, req *pb.ImportRoutesRequest) (*pb.ImportRoutesResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "import").Bool("dryRun", req.GetDryRun()).Logger()
+
+	content := []byte(req.GetContent())
+	format := bypasslist.Detect("", content)
+	if req.GetFormat() != pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED {
+		var ok bool
+		if format, ok = listFormats[req.GetFormat()]; !ok {
+			return nil, newInvalidListError("format", fmt.Sprintf("unknown list format %s", req.GetFormat()))
+		}
+	}
+
+	list, err := bypasslist.Parse(format, content)
+	if err != nil {
+		return nil, newInvalidListError("content", err.Error())
+	}
+
+	now := time.Now()
+	var routes []*pb.ImportedRoute
+	var added []*bypasslist.Item
+	// results are the routes of the listed destinations, the duplicates are reported separately
+	results := make(map[string]*pb.ImportedRoute, len(list.Items))
+	for _, item := range list.Items {
+		route := &pb.ImportedRoute{Destination: item.Destination, Line: int32(item.Line), Action: pb.ImportAction_IMPORT_ACTION_ADD}
+		switch {
+		case s.st.GetEntry(item.Destination) != nil:
+			route.Action = pb.ImportAction_IMPORT_ACTION_EXISTS
+		case item.ExpiresAt != nil && !item.ExpiresAt.After(now):
+			route.Action = pb.ImportAction_IMPORT_ACTION_INVALID
+			route.Reason = fmt.Sprintf("expired at %s", item.ExpiresAt.Format(time.RFC3339))
+		default:
+			added = append(added, item)
+		}
+
+		results[item.Destination] = route
+		routes = append(routes, route)
+	}
+
+	for _, problem := range list.Duplicates {
+		routes = append(routes, &pb.ImportedRoute{Destination: problem.Text, Line: int32(problem.Line),
+			Action: pb.ImportAction_IMPORT_ACTION_DUPLICATE, Reason: problem.Reason})
+	}
+
+	for _, problem := range list.Invalid {
+		routes = append(routes, &pb.ImportedRoute{Destination: problem.Text, Line: int32(problem.Line),
+			Action: pb.ImportAction_IMPORT_ACTION_INVALID, Reason: problem.Reason})
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].GetLine() < routes[j].GetLine() })
+
+	var removed []*pb.ImportedRoute
+	if req.GetMode() == pb.ImportMode_IMPORT_MODE_REPLACE {
+		// only the destinations that are added over CLI or imported are replaced, the config file, the subscriptions
+		// and the presets own theirs and would add them back on the next reload or sync anyway
+		for _, entry := range s.st.Entries {
+			if results[entry.Domain] == nil && (entry.Source == "" || entry.Source == constants.SourceImport) {
+				removed = append(removed, &pb.ImportedRoute{Destination: entry.Domain, Action: pb.ImportAction_IMPORT_ACTION_REMOVE,
+					Ips: entry.ResolvedIPs})
+			}
+		}
+	}
+
+	if req.GetDryRun() {
+		return &pb.ImportRoutesResponse{
+			Payload: &pb.ImportRoutesPayload{Format: newListFormat(format), DryRun: true, Routes: append(routes, removed...)},
+		}, nil
+	}
+
+	var gw string
+	if len(added) > 0 {
+		if gw, err = utils.GetDefaultNonVPNGateway(); err != nil {
+			logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+
+			return nil, newStatusError(newGatewayError(err), nil)
+		}
+	}
+
+	// removals first, so that the routes of both lists are not installed at the same time
+	for _, route := range removed {
+		if _, err := s.st.UninstallEntry(route.GetDestination()); err != nil {
+			logger.Error().Err(err).Str("domain", route.GetDestination()).Msg(constants.FailedToRemoveRouteEntry)
+			route.Error = newError(wrapStateError(err, constants.FailedToRemoveRouteEntry))
+
+			continue
+		}
+
+		logger.Info().Str("domain", route.GetDestination()).Msg("successfully removed route from routing table")
+	}
+
+	for _, item := range added {
+		route := results[item.Destination]
+		if route.Ips, err = s.importItem(item, gw, now); err != nil {
+			logger.Error().Err(err).Str("domain", item.Destination).Msg(constants.FailedToImportRouteEntry)
+			route.Error = newError(err)
+
+			continue
+		}
+
+		logger.Info().Str("domain", item.Destination).Msg("successfully imported route")
+	}
+
+	return &pb.ImportRoutesResponse{
+		Payload: &pb.ImportRoutesPayload{Format: newListFormat(format), Routes: append(routes, removed...)},
+	}, nil
+}
+
+// importItem adds the given item as a new entry with the source of the imported entries, its group is created if it
+// does not exist. The routed IPs are returned
+func (s *Server) importItem(item *bypasslist.Item, gw string, now time.Time) ([]string, error) {
+	if item.Group != "" && s.st.GetGroup(item.Group) == nil {
+		if err := s.st.CreateGroup(item.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	var ttl time.Duration
+	if item.ExpiresAt != nil {
+		ttl = item.ExpiresAt.Sub(now)
+	}
+
+	ips, err := s.addDomain(item.Destination, gw, item.Group, ttl, item.Accumulate)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.st.UpdateEntry(item.Destination, func(entry *state.RouteEntry) {
+		entry.Source = constants.SourceImport
+		entry.Tags = item.Tags
+		entry.Comment = item.Comment
+	})
+	if err != nil {
+		return ips, wrapStateError(err, constants.FailedToUpdateRouteEntry)
+	}
+
+	return ips, nil
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestServer_ExportRoutes(t *testing.T) {
+	entry := state.NewRouteEntry("zoom.us", "192.168.1.1", []string{"1.1.1.1"})
+	entry.Tags = []string{"work"}
+	entry.Comment = "calls"
+
+	client := newTestClient(t, testutil.NewState(t, entry, state.NewRouteEntry("10.0.0.0/8", "192.168.1.1", []string{"10.0.0.0/8"})), nil)
+
+	r, err := client.ExportRoutes(context.Background(), &pb.ExportRoutesRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, pb.RouteListFormat_ROUTE_LIST_FORMAT_JSON, r.GetPayload().GetFormat())
+	assert.Equal(t, int32(2), r.GetPayload().GetRoutes())
+	assert.Contains(t, r.GetPayload().GetContent(), `"comment": "calls"`)
+
+	r, err = client.ExportRoutes(context.Background(), &pb.ExportRoutesRequest{Format: pb.RouteListFormat_ROUTE_LIST_FORMAT_TEXT})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "zoom.us # calls\n10.0.0.0/8\n", r.GetPayload().GetContent())
+	}
+
+	_, err = client.ExportRoutes(context.Background(), &pb.ExportRoutesRequest{Format: pb.RouteListFormat_ROUTE_LIST_FORMAT_HOSTS})
+	assertStatusError(t, err, codes.InvalidArgument, pb.StatusCode_INVALID_ROUTE_LIST)
+}
+
+func TestServer_ImportRoutes_DryRun(t *testing.T) {
+	configured := state.NewRouteEntry("github.com", "192.168.1.1", []string{"3.3.3.3"})
+	configured.Source = constants.SourceConfig
+	subscribed := state.NewRouteEntry("10.1.0.0/16", "192.168.1.1", []string{"10.1.0.0/16"})
+	subscribed.Source = constants.SourceSubscription
+	expanded := state.NewRouteEntry("170.114.0.0/16", "192.168.1.1", []string{"170.114.0.0/16"})
+	expanded.Source = constants.SourcePreset
+
+	st := testutil.NewState(t,
+		state.NewRouteEntry("zoom.us", "192.168.1.1", []string{"1.1.1.1"}),
+		state.NewRouteEntry("slack.com", "192.168.1.1", []string{"2.2.2.2"}),
+		configured,
+		subscribed,
+		expanded,
+	)
+	client := newTestClient(t, st, nil)
+
+	content := "zoom.us\nexample.com # docs\n-invalid-\nexample.com\n"
+	r, err := client.ImportRoutes(context.Background(), &pb.ImportRoutesRequest{Content: content, Mode: pb.ImportMode_IMPORT_MODE_REPLACE,
+		DryRun: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, r.GetPayload().GetDryRun())
+	assert.Equal(t, pb.RouteListFormat_ROUTE_LIST_FORMAT_TEXT, r.GetPayload().GetFormat())
+
+	var actions []pb.ImportAction
+	var destinations []string
+	for _, route := range r.GetPayload().GetRoutes() {
+		actions = append(actions, route.GetAction())
+		destinations = append(destinations, route.GetDestination())
+	}
+
+	// the entries of the config file, the subscriptions and the presets are kept even though the list does not have them
+	assert.Equal(t, []string{"zoom.us", "example.com", "-invalid-", "example.com", "slack.com"}, destinations)
+	assert.Equal(t, []pb.ImportAction{
+		pb.ImportAction_IMPORT_ACTION_EXISTS,
+		pb.ImportAction_IMPORT_ACTION_ADD,
+		pb.ImportAction_IMPORT_ACTION_INVALID,
+		pb.ImportAction_IMPORT_ACTION_DUPLICATE,
+		pb.ImportAction_IMPORT_ACTION_REMOVE,
+	}, actions)
+	assert.Equal(t, "duplicate of line 2", r.GetPayload().GetRoutes()[3].GetReason())
+	assert.Equal(t, []string{"2.2.2.2"}, r.GetPayload().GetRoutes()[4].GetIps())
+
+	// nothing is changed on a dry run
+	assert.Len(t, st.Entries, 5)
+	assert.Nil(t, st.GetEntry("example.com"))
+}
+
+func TestServer_ImportRoutes_InvalidList(t *testing.T) {
+	client := newTestClient(t, testutil.NewState(t), nil)
+
+	_, err := client.ImportRoutes(context.Background(), &pb.ImportRoutesRequest{Format: pb.RouteListFormat_ROUTE_LIST_FORMAT_JSON,
+		Content: `{"routes": [`})
+	assertStatusError(t, err, codes.InvalidArgument, pb.StatusCode_INVALID_ROUTE_LIST)
+
+	// the lists without any new destination do not need the gateway
+	r, err := client.ImportRoutes(context.Background(), &pb.ImportRoutesRequest{Content: "# nothing to import\n"})
+	if assert.NoError(t, err) {
+		assert.Empty(t, r.GetPayload().GetRoutes())
+	}
+}
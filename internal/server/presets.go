@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+)
+
+// presetMetadata returns the google.rpc.ErrorInfo metadata of the errors about the given preset
+func presetMetadata(name string) map[string]string {
+	return map[string]string{"preset": name}
+}
+
+// ListPresets returns the presets of the catalog, the ones that have a managed group in the state are enabled
+func (s *Server) ListPresets(ctx context.Context, req *pb.ListPresetsRequest) (*pb.ListPresetsResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	enabled := make(map[string]bool)
+	for _, name := range s.st.EnabledPresets() {
+		enabled[name] = true
+	}
+
+	catalog := s.presets.Load()
+	presets := make([]*pb.Preset, 0, len(catalog.Presets))
+	for _, p := range catalog.Presets {
+		presets = append(presets, &pb.Preset{
+			Name:         p.Name,
+			Description:  p.Description,
+			Destinations: p.Destinations,
+			Enabled:      enabled[p.Name],
+			Overridden:   p.Overridden,
+		})
+	}
+
+	return &pb.ListPresetsResponse{
+		Payload: &pb.ListPresetsPayload{CatalogVersion: int32(catalog.Version), Presets: presets},
+	}, nil
+}
+
+// EnablePreset expands the preset with the given name into its managed group. Enabling an enabled preset syncs its
+// group to the catalog again
+func (s *Server) EnablePreset(ctx context.Context, req *pb.EnablePresetRequest) (*pb.EnablePresetResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "preset-enable").Str("preset", req.GetName()).Logger()
+
+	p := s.presets.Load().Get(req.GetName())
+	if p == nil {
+		return nil, newStatusError(errors.New(constants.PresetNotFound), presetMetadata(req.GetName()))
+	}
+
+	gw, err := utils.GetDefaultNonVPNGateway()
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+
+		return nil, newStatusError(newGatewayError(err), presetMetadata(req.GetName()))
+	}
+
+	added, removed, err := s.st.SyncPreset(p.Name, p.Destinations, gw)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to enable preset")
+
+		return nil, newStatusError(err, presetMetadata(req.GetName()))
+	}
+
+	// destinations that cannot be resolved or routed yet are added pending without any IP
+	var unresolved []string
+	for _, destination := range p.Destinations {
+		if entry := s.st.GetEntry(destination); entry == nil || len(entry.ResolvedIPs) == 0 {
+			unresolved = append(unresolved, destination)
+		}
+	}
+
+	logger.Info().Strs("added", added).Strs("removed", removed).Strs("unresolved", unresolved).
+		Msg("successfully enabled preset")
+
+	message := fmt.Sprintf("enabled preset %s, added %d and removed %d destination(s)", p.Name, len(added), len(removed))
+	if len(unresolved) > 0 {
+		message += fmt.Sprintf(", %d destination(s) could not be resolved yet", len(unresolved))
+	}
+
+	return &pb.EnablePresetResponse{
+		Payload: &pb.EnablePresetPayload{
+			Success:    true,
+			Message:    message,
+			Added:      added,
+			Removed:    removed,
+			Unresolved: unresolved,
+		},
+	}, nil
+}
+
+// DisablePreset removes the destinations of the preset with the given name and its managed group. Presets that are
+// not in the catalog anymore can still be disabled
+func (s *Server) DisablePreset(ctx context.Context, req *pb.DisablePresetRequest) (*pb.DisablePresetResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "preset-disable").Str("preset", req.GetName()).Logger()
+
+	removed, err := s.st.RemovePreset(req.GetName())
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to disable preset")
+
+		return nil, newStatusError(err, presetMetadata(req.GetName()))
+	}
+
+	logger.Info().Strs("removed", removed).Msg("successfully disabled preset")
+
+	return &pb.DisablePresetResponse{
+		Payload: &pb.DisablePresetPayload{
+			Success: true,
+			Message: fmt.Sprintf("disabled preset %s, removed %d destination(s)", req.GetName(), len(removed)),
+			Removed: removed,
+		},
+	}, nil
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/preset"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// newPresetState returns a state.State in which the zoom preset is enabled, its group is disabled so that no routes
+// are touched while testing
+func newPresetState(t *testing.T) *state.State {
+	st := testutil.NewState(t)
+	st.Groups = append(st.Groups, &state.Group{Name: "zoom", Source: constants.SourcePreset})
+	st.Entries = append(st.Entries, &state.RouteEntry{Domain: "170.114.0.0/16", Group: "zoom", Source: constants.SourcePreset})
+
+	return st
+}
+
+func TestServer_ListPresets(t *testing.T) {
+	client := newTestClient(t, newPresetState(t), nil)
+
+	r, err := client.ListPresets(context.Background(), &pb.ListPresetsRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int32(preset.Builtin().Version), r.GetPayload().GetCatalogVersion())
+	assert.Len(t, r.GetPayload().GetPresets(), len(preset.Builtin().Presets))
+
+	enabled := make(map[string]bool)
+	for _, p := range r.GetPayload().GetPresets() {
+		enabled[p.GetName()] = p.GetEnabled()
+	}
+
+	assert.True(t, enabled["zoom"])
+	assert.False(t, enabled["slack"])
+}
+
+func TestServer_EnablePreset_NotFound(t *testing.T) {
+	client := newTestClient(t, testutil.NewState(t), nil)
+
+	_, err := client.EnablePreset(context.Background(), &pb.EnablePresetRequest{Name: "missing"})
+	assertStatusError(t, err, codes.NotFound, pb.StatusCode_PRESET_NOT_FOUND)
+}
+
+func TestServer_DisablePreset(t *testing.T) {
+	st := newPresetState(t)
+	client := newTestClient(t, st, nil)
+
+	r, err := client.DisablePreset(context.Background(), &pb.DisablePresetRequest{Name: "zoom"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"170.114.0.0/16"}, r.GetPayload().GetRemoved())
+		assert.Empty(t, st.Entries)
+		assert.Nil(t, st.GetGroup("zoom"))
+	}
+
+	_, err = client.DisablePreset(context.Background(), &pb.DisablePresetRequest{Name: "zoom"})
+	assertStatusError(t, err, codes.NotFound, pb.StatusCode_PRESET_NOT_FOUND)
+}
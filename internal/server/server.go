@@ -0,0 +1,491 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/preset"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server is the gRPC implementation of the RouteManager service, which operates on the given state.State. Failed
+// requests are answered with the gRPC status errors that are described on pb.StatusCode
+type Server struct {
+	pb.UnimplementedRouteManagerServer
+	st     *state.State
+	events *events.Bus
+	logger zerolog.Logger
+	// startedAt is the time that the Server is created at, which is reported as the start time of the daemon
+	startedAt time.Time
+	// presets is the catalog of the presets, it is replaced when the override files are reloaded
+	presets atomic.Pointer[preset.Catalog]
+}
+
+// NewServer creates a new Server with the given state.State, the events.Bus that WatchRoutes streams and logger
+func NewServer(st *state.State, bus *events.Bus, logger zerolog.Logger) *Server {
+	s := &Server{
+		st:        st,
+		events:    bus,
+		logger:    logger,
+		startedAt: time.Now(),
+	}
+
+	s.presets.Store(preset.Builtin())
+
+	return s
+}
+
+// SetPresets replaces the catalog of the presets, the built-in catalog is used until it is set
+func (s *Server) SetPresets(catalog *preset.Catalog) {
+	s.presets.Store(catalog)
+}
+
+// log returns the logger of the call that the given context belongs to, which carries its request ID and caller
+func (s *Server) log(ctx context.Context) zerolog.Logger {
+	return loggerFromContext(ctx, s.logger)
+}
+
+// destinationMetadata returns the google.rpc.ErrorInfo metadata of the errors about the given destination
+func destinationMetadata(destination string) map[string]string {
+	return map[string]string{"destination": destination}
+}
+
+// groupMetadata returns the google.rpc.ErrorInfo metadata of the errors about the given group
+func groupMetadata(name string) map[string]string {
+	return map[string]string{"group": name}
+}
+
+// AddRoute resolves the destination and adds its routes to the routing table
+func (s *Server) AddRoute(ctx context.Context, req *pb.AddRouteRequest) (*pb.AddRouteResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "add").Str("domain", req.GetDestination()).Logger()
+
+	if req.GetDestination() == "" {
+		return nil, newInvalidDestinationError()
+	}
+
+	if req.GetTtl().AsDuration() < 0 {
+		return nil, newInvalidArgumentError(pb.StatusCode_INVALID_TTL, "ttl", "TTL cannot be negative")
+	}
+
+	gw, err := utils.GetDefaultNonVPNGateway()
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+
+		return nil, newStatusError(newGatewayError(err), destinationMetadata(req.GetDestination()))
+	}
+
+	ips, err := s.addDomain(req.GetDestination(), gw, "", req.GetTtl().AsDuration(), req.GetAccumulate())
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToAddRoute)
+
+		return nil, newStatusError(err, destinationMetadata(req.GetDestination()))
+	}
+
+	logger.Info().Msg("successfully added route to routing table")
+
+	return &pb.AddRouteResponse{
+		Payload: &pb.AddRoutePayload{
+			Success: true,
+			Message: "Route added successfully",
+			Ips:     ips,
+		},
+	}, nil
+}
+
+// ListRoutes returns the entries in the state with the statuses of the routes of their IPs
+func (s *Server) ListRoutes(ctx context.Context, req *pb.ListRoutesRequest) (*pb.ListRoutesResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	routes := make([]*pb.Route, 0, len(s.st.Entries))
+	for _, entry := range s.st.Entries {
+		routes = append(routes, newRoute(entry, s.st.IsEntryActive(entry)))
+	}
+
+	return &pb.ListRoutesResponse{
+		Payload: &pb.ListRoutesPayload{Routes: routes},
+	}, nil
+}
+
+// RemoveRoute removes the routes of the destination from the routing table and the destination from the state
+func (s *Server) RemoveRoute(ctx context.Context, req *pb.RemoveRouteRequest) (*pb.RemoveRouteResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "remove").Str("domain", req.GetDestination()).Logger()
+
+	if req.GetDestination() == "" {
+		return nil, newInvalidDestinationError()
+	}
+
+	entry, err := s.st.UninstallEntry(req.GetDestination())
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToRemoveRouteEntry)
+
+		return nil, newStatusError(wrapStateError(err, constants.FailedToRemoveRouteEntry), destinationMetadata(req.GetDestination()))
+	}
+
+	logger.Info().Msg("successfully removed route from routing table")
+
+	return &pb.RemoveRouteResponse{
+		Payload: &pb.RemoveRoutePayload{
+			Success: true,
+			Message: "Route removed successfully",
+			Ips:     entry.ResolvedIPs,
+		},
+	}, nil
+}
+
+// GetRoute returns the destination with the statuses of the routes of its IPs
+func (s *Server) GetRoute(ctx context.Context, req *pb.GetRouteRequest) (*pb.GetRouteResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	entry := s.st.GetEntry(req.GetDestination())
+	if entry == nil {
+		return nil, newStatusError(errors.New(constants.EntryNotFound), destinationMetadata(req.GetDestination()))
+	}
+
+	return &pb.GetRouteResponse{
+		Payload: &pb.GetRoutePayload{Route: newRoute(entry, s.st.IsEntryActive(entry))},
+	}, nil
+}
+
+// UpdateRoute changes the expiry and the accumulate mode of the destination, the fields that are not set in the
+// request are left as they are
+func (s *Server) UpdateRoute(ctx context.Context, req *pb.UpdateRouteRequest) (*pb.UpdateRouteResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "update").Str("domain", req.GetDestination()).Logger()
+
+	if req.GetDestination() == "" {
+		return nil, newInvalidDestinationError()
+	}
+
+	if req.GetTtl().AsDuration() < 0 {
+		return nil, newInvalidArgumentError(pb.StatusCode_INVALID_TTL, "ttl", "TTL cannot be negative")
+	}
+
+	entry, err := s.st.UpdateEntry(req.GetDestination(), func(entry *state.RouteEntry) {
+		if req.Ttl != nil {
+			entry.ExpiresAt = nil
+			if ttl := req.GetTtl().AsDuration(); ttl > 0 {
+				expiresAt := time.Now().Add(ttl)
+				entry.ExpiresAt = &expiresAt
+			}
+		}
+
+		if req.Accumulate != nil {
+			entry.Accumulate = req.GetAccumulate().GetValue()
+		}
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToUpdateRouteEntry)
+
+		return nil, newStatusError(wrapStateError(err, constants.FailedToUpdateRouteEntry), destinationMetadata(req.GetDestination()))
+	}
+
+	logger.Info().Msg("successfully updated route")
+
+	return &pb.UpdateRouteResponse{
+		Payload: &pb.UpdateRoutePayload{Route: newRoute(entry, s.st.IsEntryActive(entry))},
+	}, nil
+}
+
+// Purge removes every destination and its routes, failures of the single destinations are reported in their results
+func (s *Server) Purge(ctx context.Context, req *pb.PurgeRequest) (*pb.PurgeResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "purge").Logger()
+
+	// entries are removed from the state one by one, so the list is copied before
+	entries := append([]*state.RouteEntry{}, s.st.Entries...)
+
+	routes := make([]*pb.PurgedRoute, 0, len(entries))
+	for _, entry := range entries {
+		route := &pb.PurgedRoute{Destination: entry.Domain, Ips: entry.ResolvedIPs}
+		routes = append(routes, route)
+
+		if _, err := s.st.UninstallEntry(entry.Domain); err != nil {
+			logger.Error().Err(err).Str("domain", entry.Domain).Msg(constants.FailedToRemoveRouteEntry)
+			route.Error = newError(wrapStateError(err, constants.FailedToRemoveRouteEntry))
+
+			// the IPs that are still routed are reported
+			route.Ips = nil
+			if remaining := s.st.GetEntry(entry.Domain); remaining != nil {
+				route.Ips = remaining.ResolvedIPs
+			}
+
+			continue
+		}
+
+		logger.Info().Str("domain", entry.Domain).Msg("successfully removed route from routing table")
+	}
+
+	return &pb.PurgeResponse{
+		Payload: &pb.PurgePayload{Routes: routes},
+	}, nil
+}
+
+// Status returns the version of the daemon, its uptime, the detected gateway, the counts of the state and the outcomes
+// of the subscription syncs
+func (s *Server) Status(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	var failed, active, temporary int
+	for _, entry := range s.st.Entries {
+		failed += len(entry.FailedIPs())
+		if s.st.IsEntryActive(entry) {
+			active++
+		}
+
+		if entry.ExpiresAt != nil {
+			temporary++
+		}
+	}
+
+	ver := version.Get()
+	payload := &pb.StatusPayload{
+		Version:         ver.GitVersion,
+		GitCommit:       ver.GitCommit,
+		StartedAt:       timestamppb.New(s.startedAt),
+		Uptime:          durationpb.New(time.Since(s.startedAt)),
+		Backend:         utils.RouteBackend,
+		RouteMode:       string(s.st.RouteMode()),
+		Routes:          int32(len(s.st.Entries)),
+		Groups:          int32(len(s.st.Groups)),
+		FailedIps:       int32(failed),
+		ActiveRoutes:    int32(active),
+		TemporaryRoutes: int32(temporary),
+		DnsServers:      utils.DNSServers(),
+	}
+
+	if lastRefresh := s.st.LastRefresh(); !lastRefresh.IsZero() {
+		payload.LastRefresh = timestamppb.New(lastRefresh)
+	}
+
+	for _, sub := range s.st.Subscriptions {
+		payload.Subscriptions = append(payload.Subscriptions, newSubscriptionStatus(sub))
+	}
+
+	// the gateway is reported as empty if it cannot be detected, the rest of the status is still useful
+	if gw, err := utils.GetDefaultNonVPNRoute(); err == nil {
+		payload.Gateway = gw.Gateway
+		payload.GatewayInterface = gw.Interface
+	} else {
+		logger := s.log(ctx)
+		logger.Warn().Err(err).Str("operation", "status").Msg(constants.FailedToGetDefaultGateway)
+	}
+
+	return &pb.StatusResponse{Payload: payload}, nil
+}
+
+// CreateGroup creates a new enabled group with the given name
+func (s *Server) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "group-create").Str("group", req.GetName()).Logger()
+
+	if req.GetName() == "" {
+		return nil, newInvalidGroupError()
+	}
+
+	if err := s.st.CreateGroup(req.GetName()); err != nil {
+		logger.Error().Err(err).Msg("failed to create group")
+
+		return nil, newStatusError(err, groupMetadata(req.GetName()))
+	}
+
+	logger.Info().Msg("successfully created group")
+
+	return &pb.CreateGroupResponse{
+		Payload: &pb.CreateGroupPayload{
+			Success: true,
+			Message: fmt.Sprintf("created group %s", req.GetName()),
+		},
+	}, nil
+}
+
+// AddToGroup adds the given destinations to the group. Destinations that are already in the state are moved into the
+// group, the others are resolved and added as new entries
+func (s *Server) AddToGroup(ctx context.Context, req *pb.AddToGroupRequest) (*pb.AddToGroupResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "group-add").Str("group", req.GetName()).Logger()
+
+	if req.GetName() == "" {
+		return nil, newInvalidGroupError()
+	}
+
+	if s.st.GetGroup(req.GetName()) == nil {
+		return nil, newStatusError(errors.New(constants.GroupNotFound), groupMetadata(req.GetName()))
+	}
+
+	gw, err := utils.GetDefaultNonVPNGateway()
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+
+		return nil, newStatusError(newGatewayError(err), groupMetadata(req.GetName()))
+	}
+
+	var firstErr error
+	var failures, failed []string
+	for _, domain := range req.GetDestinations() {
+		if s.st.GetEntry(domain) != nil {
+			err = s.st.SetEntryGroup(domain, req.GetName())
+		} else {
+			_, err = s.addDomain(domain, gw, req.GetName(), 0, false)
+		}
+
+		if err != nil {
+			logger.Error().Err(err).Str("domain", domain).Msg("failed to add domain to group")
+
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			failures = append(failures, fmt.Sprintf("%s: %s", domain, err.Error()))
+			failed = append(failed, domain)
+
+			continue
+		}
+
+		logger.Info().Str("domain", domain).Msg("successfully added domain to group")
+	}
+
+	// the code of the first failure is reported with the failures of all the destinations
+	if firstErr != nil {
+		metadata := groupMetadata(req.GetName())
+		metadata["destinations"] = strings.Join(failed, ",")
+
+		return nil, newStatusError(&codedError{code: errorCode(firstErr), err: errors.New(strings.Join(failures, ", "))}, metadata)
+	}
+
+	return &pb.AddToGroupResponse{
+		Payload: &pb.AddToGroupPayload{
+			Success: true,
+			Message: fmt.Sprintf("added %d destination(s) to group %s", len(req.GetDestinations()), req.GetName()),
+		},
+	}, nil
+}
+
+// EnableGroup installs the routes of all the entries in the group
+func (s *Server) EnableGroup(ctx context.Context, req *pb.EnableGroupRequest) (*pb.EnableGroupResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "group-enable").Str("group", req.GetName()).Logger()
+
+	gw, err := utils.GetDefaultNonVPNGateway()
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToGetDefaultGateway)
+
+		return nil, newStatusError(newGatewayError(err), groupMetadata(req.GetName()))
+	}
+
+	if err := s.st.EnableGroup(req.GetName(), gw); err != nil {
+		logger.Error().Err(err).Msg("failed to enable group")
+
+		return nil, newStatusError(err, groupMetadata(req.GetName()))
+	}
+
+	logger.Info().Msg("successfully enabled group")
+
+	return &pb.EnableGroupResponse{
+		Payload: &pb.EnableGroupPayload{
+			Success: true,
+			Message: fmt.Sprintf("enabled group %s", req.GetName()),
+		},
+	}, nil
+}
+
+// DisableGroup removes the routes of all the entries in the group, entries are kept in the state
+func (s *Server) DisableGroup(ctx context.Context, req *pb.DisableGroupRequest) (*pb.DisableGroupResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "group-disable").Str("group", req.GetName()).Logger()
+
+	if err := s.st.DisableGroup(req.GetName()); err != nil {
+		logger.Error().Err(err).Msg("failed to disable group")
+
+		return nil, newStatusError(err, groupMetadata(req.GetName()))
+	}
+
+	logger.Info().Msg("successfully disabled group")
+
+	return &pb.DisableGroupResponse{
+		Payload: &pb.DisableGroupPayload{
+			Success: true,
+			Message: fmt.Sprintf("disabled group %s", req.GetName()),
+		},
+	}, nil
+}
+
+// ListGroups returns all the groups with their destinations
+func (s *Server) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	groups := make([]*pb.Group, 0, len(s.st.Groups))
+	for _, group := range s.st.Groups {
+		destinations := make([]string, 0)
+		for _, entry := range s.st.GroupEntries(group.Name) {
+			destinations = append(destinations, entry.Domain)
+		}
+
+		groups = append(groups, &pb.Group{
+			Name:         group.Name,
+			Enabled:      group.Enabled,
+			Destinations: destinations,
+		})
+	}
+
+	return &pb.ListGroupsResponse{
+		Payload: &pb.ListGroupsPayload{Groups: groups},
+	}, nil
+}
+
+// addDomain resolves the given domain, adds it to the state as a member of the given group and installs its routes if
+// the entry is active. A positive ttl makes the entry expire after the given duration and accumulate keeps the IPs that
+// are resolved over time routed, the routed IPs are returned
+func (s *Server) addDomain(domain, gw, group string, ttl time.Duration, accumulate bool) ([]string, error) {
+	res, err := utils.Resolve(domain)
+	if err != nil {
+		return nil, &codedError{code: pb.StatusCode_RESOLVE_FAILED, err: errors.Wrap(err, constants.FailedToResolveDomain)}
+	}
+
+	entry := state.NewResolvedEntry(domain, gw, res)
+	entry.Group = group
+	entry.Accumulate = accumulate
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	if err := s.st.InstallEntry(entry); err != nil {
+		return nil, wrapStateError(err, constants.FailedToAddRouteEntry)
+	}
+
+	return s.st.GetEntry(domain).ResolvedIPs, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestServer_GetRoute(t *testing.T) {
+	client := newTestClient(t, testutil.NewState(t, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"})), nil)
+
+	r, err := client.GetRoute(context.Background(), &pb.GetRouteRequest{Destination: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	route := r.GetPayload().GetRoute()
+	assert.Equal(t, "example.com", route.GetDomain())
+	assert.Equal(t, "192.168.1.1", route.GetGateway())
+	assert.Equal(t, []string{"1.1.1.1"}, route.GetRoutedIps())
+	assert.True(t, route.GetActive())
+	assert.Nil(t, route.GetExpiresAt())
+
+	_, err = client.GetRoute(context.Background(), &pb.GetRouteRequest{Destination: "example.org"})
+	assertStatusError(t, err, codes.NotFound, pb.StatusCode_ROUTE_NOT_FOUND)
+}
+
+func TestServer_UpdateRoute(t *testing.T) {
+	client := newTestClient(t, testutil.NewState(t, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"})), nil)
+
+	// only the expiry is changed, the accumulate mode is left as it is
+	r, err := client.UpdateRoute(context.Background(), &pb.UpdateRouteRequest{Destination: "example.com", Ttl: durationpb.New(time.Hour)})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	route := r.GetPayload().GetRoute()
+	assert.WithinDuration(t, time.Now().Add(time.Hour), route.GetExpiresAt().AsTime(), time.Minute)
+	assert.False(t, route.GetAccumulate())
+
+	// zero TTL makes the route permanent again
+	r, err = client.UpdateRoute(context.Background(), &pb.UpdateRouteRequest{
+		Destination: "example.com",
+		Ttl:         durationpb.New(0),
+		Accumulate:  wrapperspb.Bool(true),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	route = r.GetPayload().GetRoute()
+	assert.Nil(t, route.GetExpiresAt())
+	assert.True(t, route.GetAccumulate())
+
+	cases := []struct {
+		name     string
+		req      *pb.UpdateRouteRequest
+		grpcCode codes.Code
+		code     pb.StatusCode
+	}{
+		{"missing destination", &pb.UpdateRouteRequest{}, codes.InvalidArgument, pb.StatusCode_INVALID_DESTINATION},
+		{"negative ttl", &pb.UpdateRouteRequest{Destination: "example.com", Ttl: durationpb.New(-time.Hour)}, codes.InvalidArgument, pb.StatusCode_INVALID_TTL},
+		{"unknown destination", &pb.UpdateRouteRequest{Destination: "example.org", Ttl: durationpb.New(0)}, codes.NotFound, pb.StatusCode_ROUTE_NOT_FOUND},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.UpdateRoute(context.Background(), tc.req)
+			assertStatusError(t, err, tc.grpcCode, tc.code)
+		})
+	}
+}
+
+func TestServer_Status(t *testing.T) {
+	failed := state.NewRouteEntry("example.org", "192.168.1.1", []string{"2.2.2.2"})
+	failed.Routes = []*state.IPRoute{{IP: "2.2.2.2", Status: state.IPStatusFailed}}
+	st := testutil.NewState(t, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}), failed)
+	st.Subscriptions = []*state.Subscription{{Name: "microsoft", URL: "https://example.com/m365.txt", Group: "m365",
+		Status: state.SyncStatusFailed, LastError: "failed to fetch list: unexpected status 503 Service Unavailable"}}
+	client := newTestClient(t, st, nil)
+
+	r, err := client.Status(context.Background(), &pb.StatusRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	status := r.GetPayload()
+	assert.Equal(t, int32(2), status.GetRoutes())
+	assert.Equal(t, int32(1), status.GetFailedIps())
+	assert.Equal(t, int32(2), status.GetActiveRoutes())
+	assert.Zero(t, status.GetTemporaryRoutes())
+	assert.Nil(t, status.GetLastRefresh())
+	assert.Equal(t, []string{utils.SystemResolver}, status.GetDnsServers())
+	assert.Equal(t, string(state.RouteModeTransactional), status.GetRouteMode())
+	assert.NotNil(t, status.GetStartedAt())
+	if assert.Len(t, status.GetSubscriptions(), 1) {
+		assert.Equal(t, pb.SubscriptionSyncStatus_SUBSCRIPTION_SYNC_STATUS_FAILED, status.GetSubscriptions()[0].GetStatus())
+		assert.Nil(t, status.GetSubscriptions()[0].GetLastSync())
+	}
+}
+
+func TestServer_Purge_Empty(t *testing.T) {
+	client := newTestClient(t, testutil.NewState(t), nil)
+
+	r, err := client.Purge(context.Background(), &pb.PurgeRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, r.GetPayload().GetRoutes())
+}
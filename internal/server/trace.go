@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// the lookups of TraceRoute, they are replaced in the tests
+var (
+	resolve       = utils.Resolve
+	routeDecision = utils.GetRouteDecision
+	routingRules  = utils.GetRoutingRules
+	routingTable  = utils.GetRoutingTable
+	defaultRoute  = utils.GetDefaultNonVPNRoute
+)
+
+// trace is what TraceRoute knows about the routing of a host, which the paths of its IPs are explained with
+type trace struct {
+	host string
+	// route is the destination that covers the host, nil if none does
+	route *pb.Route
+	// gateway is the default non-VPN route, nil if it cannot be detected
+	gateway *utils.KernelRoute
+	table   []*utils.KernelRoute
+	rules   []*utils.RoutingRule
+}
+
+// TraceRoute resolves the host the same way as the destinations and explains whether the kernel routes the traffic
+// to its IPs over the non-VPN gateway or the VPN, and why
+func (s *Server) TraceRoute(ctx context.Context, req *pb.TraceRouteRequest) (*pb.TraceRouteResponse, error) {
+	s.st.Lock()
+	defer s.st.Unlock()
+
+	logger := s.log(ctx).With().Str("operation", "trace").Str("domain", req.GetHost()).Logger()
+
+	if req.GetHost() == "" {
+		return nil, newInvalidDestinationError()
+	}
+
+	res, err := resolve(req.GetHost())
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToResolveDomain)
+
+		return nil, newStatusError(&codedError{code: pb.StatusCode_RESOLVE_FAILED, err: errors.Wrap(err, constants.FailedToResolveDomain)},
+			destinationMetadata(req.GetHost()))
+	}
+
+	t := &trace{host: req.GetHost()}
+	if entry := s.findEntry(req.GetHost(), res.IPs); entry != nil {
+		t.route = newRoute(entry, s.st.IsEntryActive(entry))
+	}
+
+	// the paths are still explained without the ones that cannot be read, with less details
+	if t.gateway, err = defaultRoute(); err != nil {
+		logger.Warn().Err(err).Msg(constants.FailedToGetDefaultGateway)
+	}
+
+	if t.table, err = routingTable(); err != nil {
+		logger.Warn().Err(err).Msg("failed to read routing table")
+	}
+
+	if t.rules, err = routingRules(); err != nil {
+		logger.Warn().Err(err).Msg("failed to read routing rules")
+	}
+
+	payload := &pb.TraceRoutePayload{Host: req.GetHost(), Route: t.route}
+	if t.gateway != nil {
+		payload.Gateway = t.gateway.Gateway
+	}
+
+	for _, ip := range res.IPs {
+		payload.Ips = append(payload.Ips, t.traceIP(ip, res.Sources[ip]))
+	}
+
+	return &pb.TraceRouteResponse{Payload: payload}, nil
+}
+
+// findEntry returns the entry of the given host, or the first entry whose routed IPs cover one of the given IPs of the
+// host, such as the entry of a CIDR block. It returns nil if none does
+func (s *Server) findEntry(host string, ips []string) *state.RouteEntry {
+	if entry := s.st.GetEntry(host); entry != nil {
+		return entry
+	}
+
+	for _, entry := range s.st.Entries {
+		for _, ip := range ips {
+			if covers(entry.ResolvedIPs, ip) {
+				return entry
+			}
+		}
+	}
+
+	return nil
+}
+
+// ipNet returns the network of the given IP or CIDR block, a single IP is a /32 network
+func ipNet(ip string) *net.IPNet {
+	if _, n, err := net.ParseCIDR(ip); err == nil {
+		return n
+	}
+
+	if parsed := net.ParseIP(ip).To4(); parsed != nil {
+		return &net.IPNet{IP: parsed, Mask: net.CIDRMask(32, 32)}
+	}
+
+	return nil
+}
+
+// covers returns true if one of the given routed IPs or CIDR blocks contains the given IP
+func covers(routed []string, ip string) bool {
+	target := ipNet(ip)
+	if target == nil {
+		return false
+	}
+
+	for _, r := range routed {
+		if n := ipNet(r); n != nil && n.Contains(target.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// traceIP asks the kernel for the route of the given IP, a CIDR block is traced with its first address
+func (t *trace) traceIP(ip string, resolvers []string) *pb.TracedIP {
+	traced := &pb.TracedIP{
+		Ip:        ip,
+		Resolvers: resolvers,
+		Routed:    t.route != nil && t.route.GetActive() && covers(t.route.GetRoutedIps(), ip),
+	}
+
+	target := ipNet(ip)
+	if target == nil {
+		traced.Path = pb.TracePath_TRACE_PATH_UNREACHABLE
+		traced.Reason = fmt.Sprintf("%s is not an IPv4 address", ip)
+
+		return traced
+	}
+
+	if kr := utils.LookupRoute(t.table, target.IP); kr != nil {
+		traced.KernelRoute = kr.String()
+	}
+
+	decision, err := routeDecision(target.IP)
+	if err != nil {
+		traced.Path = pb.TracePath_TRACE_PATH_UNREACHABLE
+		traced.Reason = err.Error()
+
+		return traced
+	}
+
+	traced.Interface = decision.Interface
+	traced.Gateway = decision.Gateway
+	traced.Source = decision.Source
+	traced.Table = utils.TableName(decision.Table)
+	if rule := utils.LookupRule(t.rules, decision.Table); rule != nil {
+		traced.Rule = rule.String()
+	}
+
+	traced.Path = pb.TracePath_TRACE_PATH_VPN
+	if t.bypasses(decision) {
+		traced.Path = pb.TracePath_TRACE_PATH_BYPASS
+	}
+
+	traced.Reason = t.explain(traced, target.IP, decision)
+
+	return traced
+}
+
+// bypasses returns true if the given decision routes the traffic over the interface of the non-VPN gateway, or over
+// the gateway of the destination if the non-VPN gateway cannot be detected
+func (t *trace) bypasses(decision *utils.RouteDecision) bool {
+	if t.gateway != nil {
+		return decision.Interface == t.gateway.Interface
+	}
+
+	return t.route != nil && decision.Gateway != "" && decision.Gateway == t.route.GetGateway()
+}
+
+// explain returns why the kernel picks the path of the given IP, and how to fix it if the IP is expected to bypass VPN
+func (t *trace) explain(traced *pb.TracedIP, ip net.IP, decision *utils.RouteDecision) string {
+	if traced.GetPath() == pb.TracePath_TRACE_PATH_BYPASS {
+		if traced.GetRouted() {
+			return fmt.Sprintf("route of %s via %s bypasses VPN", t.route.GetDomain(), t.route.GetGateway())
+		}
+
+		return fmt.Sprintf("%s is not routed by the daemon, the kernel routes it outside the VPN anyway", ip)
+	}
+
+	switch {
+	case t.route == nil:
+		return fmt.Sprintf("no destination covers %s, add it with stt-cli add %s", t.host, t.host)
+	case !t.route.GetActive():
+		return fmt.Sprintf("%s belongs to the disabled group %s, enable it with stt-cli group enable %s",
+			t.route.GetDomain(), t.route.GetGroup(), t.route.GetGroup())
+	case !traced.GetRouted():
+		for _, routeIP := range t.route.GetIps() {
+			if routeIP.GetIp() == ip.String() && routeIP.GetStatus() == pb.RouteIPStatus_ROUTE_IP_STATUS_FAILED {
+				return fmt.Sprintf("route of %s could not be installed: %s", ip, routeIP.GetLastError())
+			}
+		}
+
+		return fmt.Sprintf("%s is not a routed IP of %s, it is resolved since the last IP check, which adds its route",
+			ip, t.route.GetDomain())
+	case decision.Table != utils.MainTable:
+		return fmt.Sprintf("rule %q looks the route up in table %s before the main table that has the route of %s, "+
+			"exclude the destination in the VPN client", traced.GetRule(), traced.GetTable(), t.route.GetDomain())
+	}
+
+	// the route of the destination is in the main table unless it is flushed, a more specific one shadows it
+	var installed *utils.KernelRoute
+	for _, kr := range t.table {
+		if kr.Destination.Contains(ip) && !kr.Default() && kr.Gateway == t.route.GetGateway() {
+			installed = kr
+		}
+	}
+
+	if installed == nil {
+		return fmt.Sprintf("route of %s via %s is missing from the kernel, restart the daemon to restore the routes "+
+			"of %s", ip, t.route.GetGateway(), t.route.GetDomain())
+	}
+
+	if traced.GetKernelRoute() == installed.String() {
+		return fmt.Sprintf("kernel picks %s instead of the route %s of %s", decision, installed, t.route.GetDomain())
+	}
+
+	return fmt.Sprintf("route %s of the VPN shadows the route %s of %s, exclude the destination in the VPN client",
+		traced.GetKernelRoute(), installed, t.route.GetDomain())
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// kernelRoute returns a utils.KernelRoute of the given CIDR block
+func kernelRoute(iface, cidr, gateway string, metric int) *utils.KernelRoute {
+	_, destination, _ := net.ParseCIDR(cidr)
+
+	return &utils.KernelRoute{Interface: iface, Destination: destination, Gateway: gateway, Metric: metric}
+}
+
+// fakeRouting replaces the lookups of TraceRoute with a VPN that captures the default route over tun0 and routes
+// 51820 with the rules of wg-quick. The kernel picks the given decisions, the other IPs are unreachable
+func fakeRouting(t *testing.T, decisions map[string]*utils.RouteDecision, table ...*utils.KernelRoute) {
+	gateway := kernelRoute("eth0", "0.0.0.0/0", "192.168.1.1", 100)
+
+	resolve = func(domain string) (*utils.Resolution, error) {
+		if domain == "example.com" {
+			return &utils.Resolution{
+				IPs:     []string{"1.1.1.1", "2.2.2.2"},
+				Sources: map[string][]string{"1.1.1.1": {"8.8.8.8:53"}, "2.2.2.2": {"8.8.8.8:53"}},
+			}, nil
+		}
+
+		return utils.Resolve(domain)
+	}
+
+	routeDecision = func(ip net.IP) (*utils.RouteDecision, error) {
+		if decision, ok := decisions[ip.String()]; ok {
+			decision.IP = ip.String()
+			return decision, nil
+		}
+
+		return nil, errors.Wrap(syscall.ENETUNREACH, "kernel has no route")
+	}
+
+	routingRules = func() ([]*utils.RoutingRule, error) {
+		return []*utils.RoutingRule{
+			{Priority: 32764, Table: 51820, Invert: true, Mark: 0xca6c, Mask: 0xffffffff, SuppressPrefixLength: -1},
+			{Priority: 32765, Table: utils.MainTable, SuppressPrefixLength: 0},
+			{Priority: 32766, Table: utils.MainTable, SuppressPrefixLength: -1},
+		}, nil
+	}
+
+	routingTable = func() ([]*utils.KernelRoute, error) {
+		return append([]*utils.KernelRoute{kernelRoute("tun0", "0.0.0.0/0", "", 50), gateway}, table...), nil
+	}
+
+	defaultRoute = func() (*utils.KernelRoute, error) {
+		return gateway, nil
+	}
+
+	t.Cleanup(func() {
+		resolve, routeDecision, routingRules = utils.Resolve, utils.GetRouteDecision, utils.GetRoutingRules
+		routingTable, defaultRoute = utils.GetRoutingTable, utils.GetDefaultNonVPNRoute
+	})
+}
+
+func TestServer_TraceRoute(t *testing.T) {
+	failed := state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"})
+	failed.Routes = []*state.IPRoute{
+		{IP: "1.1.1.1", Status: state.IPStatusInstalled},
+		{IP: "2.2.2.2", Status: state.IPStatusFailed, LastError: "file exists"},
+	}
+
+	client := newTestClient(t, testutil.NewState(t,
+		failed,
+		state.NewRouteEntry("8.8.8.0/24", "192.168.1.1", []string{"8.8.8.0/24"}),
+		state.NewRouteEntry("9.9.9.9", "192.168.1.1", []string{"9.9.9.9"}),
+		state.NewRouteEntry("4.4.4.4", "192.168.1.1", []string{"4.4.4.4"}),
+	), nil)
+
+	vpn := &utils.RouteDecision{Interface: "tun0", Source: "10.8.0.2", Table: utils.MainTable}
+	fakeRouting(t, map[string]*utils.RouteDecision{
+		"1.1.1.1": {Interface: "eth0", Gateway: "192.168.1.1", Table: utils.MainTable},
+		"2.2.2.2": vpn,
+		"3.3.3.3": vpn,
+		"4.4.4.4": vpn,
+		"8.8.8.8": vpn,
+		"9.9.9.9": {Interface: "wg0", Table: 51820},
+	},
+		kernelRoute("eth0", "1.1.1.1/32", "192.168.1.1", 0),
+		kernelRoute("eth0", "8.8.8.0/24", "192.168.1.1", 0),
+		kernelRoute("tun0", "8.8.8.8/32", "", 0),
+		kernelRoute("eth0", "9.9.9.9/32", "192.168.1.1", 0),
+	)
+
+	r, err := client.TraceRoute(context.Background(), &pb.TraceRouteRequest{Host: "example.com"})
+	if !assert.NoError(t, err) || !assert.Len(t, r.GetPayload().GetIps(), 2) {
+		return
+	}
+
+	assert.Equal(t, "example.com", r.GetPayload().GetRoute().GetDomain())
+	assert.Equal(t, "192.168.1.1", r.GetPayload().GetGateway())
+
+	bypassed := r.GetPayload().GetIps()[0]
+	assert.True(t, bypassed.GetRouted())
+	assert.Equal(t, pb.TracePath_TRACE_PATH_BYPASS, bypassed.GetPath())
+	assert.Equal(t, []string{"8.8.8.8:53"}, bypassed.GetResolvers())
+	assert.Equal(t, "main", bypassed.GetTable())
+	assert.Equal(t, "32765: from all lookup main suppress_prefixlength 0", bypassed.GetRule())
+	assert.Equal(t, "1.1.1.1/32 via 192.168.1.1 dev eth0", bypassed.GetKernelRoute())
+
+	notRouted := r.GetPayload().GetIps()[1]
+	assert.False(t, notRouted.GetRouted())
+	assert.Equal(t, pb.TracePath_TRACE_PATH_VPN, notRouted.GetPath())
+	assert.Equal(t, "tun0", notRouted.GetInterface())
+	assert.Equal(t, "route of 2.2.2.2 could not be installed: file exists", notRouted.GetReason())
+
+	cases := []struct {
+		host   string
+		path   pb.TracePath
+		route  string
+		reason string
+	}{
+		{"3.3.3.3", pb.TracePath_TRACE_PATH_VPN, "", "no destination covers 3.3.3.3, add it with stt-cli add 3.3.3.3"},
+		{"5.5.5.5", pb.TracePath_TRACE_PATH_UNREACHABLE, "", "kernel has no route: network is unreachable"},
+		{"4.4.4.4", pb.TracePath_TRACE_PATH_VPN, "4.4.4.4",
+			"route of 4.4.4.4 via 192.168.1.1 is missing from the kernel, restart the daemon to restore the routes of 4.4.4.4"},
+		// the CIDR block covers the IP but a more specific route of the VPN wins
+		{"8.8.8.8", pb.TracePath_TRACE_PATH_VPN, "8.8.8.0/24",
+			"route 8.8.8.8/32 dev tun0 of the VPN shadows the route 8.8.8.0/24 via 192.168.1.1 dev eth0 of 8.8.8.0/24, exclude the destination in the VPN client"},
+		{"9.9.9.9", pb.TracePath_TRACE_PATH_VPN, "9.9.9.9",
+			`rule "32764: not from all fwmark 0xca6c lookup 51820" looks the route up in table 51820 before the main table that has the route of 9.9.9.9, exclude the destination in the VPN client`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.host, func(t *testing.T) {
+			r, err := client.TraceRoute(context.Background(), &pb.TraceRouteRequest{Host: c.host})
+			if !assert.NoError(t, err) || !assert.Len(t, r.GetPayload().GetIps(), 1) {
+				return
+			}
+
+			traced := r.GetPayload().GetIps()[0]
+			assert.Equal(t, c.path, traced.GetPath())
+			assert.Equal(t, c.route, r.GetPayload().GetRoute().GetDomain())
+			assert.Equal(t, c.reason, traced.GetReason())
+		})
+	}
+
+	_, err = client.TraceRoute(context.Background(), &pb.TraceRouteRequest{})
+	assertStatusError(t, err, codes.InvalidArgument, pb.StatusCode_INVALID_DESTINATION)
+}
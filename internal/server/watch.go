@@ -0,0 +1,68 @@
+package server
+
+import (
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchRoutes streams the events that match the request, the last matching events are replayed first. The stream ends
+// when the client cancels it, or with codes.ResourceExhausted when the client cannot keep up with the events
+func (s *Server) WatchRoutes(req *pb.WatchRoutesRequest, stream pb.RouteManager_WatchRoutesServer) error {
+	logger := s.log(stream.Context()).With().Str("operation", "watch").Logger()
+
+	sub := s.events.Subscribe(newEventFilter(req), int(req.GetReplay()))
+	defer sub.Close()
+
+	logger.Debug().Msg(constants.StartedWatch)
+
+	// header is sent right away, so that the clients such as the HTTP gateway know that the subscription is established
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			logger.Debug().Msg(constants.StoppedWatch)
+			return nil
+		case e, ok := <-sub.C:
+			if !ok {
+				logger.Warn().Msg(constants.DroppedSlowWatcher)
+				return status.Error(codes.ResourceExhausted, constants.DroppedSlowWatcher)
+			}
+
+			if err := stream.Send(newRouteEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// newEventFilter returns the events.Filter of the given request, events that are not about a single domain pass the
+// filter of the destinations
+func newEventFilter(req *pb.WatchRoutesRequest) events.Filter {
+	if len(req.GetTypes()) == 0 && len(req.GetDestinations()) == 0 {
+		return nil
+	}
+
+	types := make(map[pb.RouteEventType]bool, len(req.GetTypes()))
+	for _, typ := range req.GetTypes() {
+		types[typ] = true
+	}
+
+	destinations := make(map[string]bool, len(req.GetDestinations()))
+	for _, destination := range req.GetDestinations() {
+		destinations[destination] = true
+	}
+
+	return func(e *events.Event) bool {
+		if len(types) > 0 && !types[eventTypes[e.Type]] {
+			return false
+		}
+
+		return len(destinations) == 0 || e.Domain == "" || destinations[e.Domain]
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves a Server with the given state.State and events.Bus over an in-memory connection and returns a
+// client of it
+func newTestClient(t *testing.T, st *state.State, bus *events.Bus) pb.RouteManagerClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterRouteManagerServer(srv, NewServer(st, bus, logging.GetLogger()))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewRouteManagerClient(conn)
+}
+
+func TestServer_WatchRoutes(t *testing.T) {
+	bus := events.NewBus(10)
+	client := newTestClient(t, nil, bus)
+
+	bus.Publish(&events.Event{Type: events.EntryAdded, Domain: "example.com", IPs: []string{"1.1.1.1"}})
+	bus.Publish(&events.Event{Type: events.EntryAdded, Domain: "example.org"})
+	bus.Publish(&events.Event{Type: events.IPsChanged, Domain: "example.com"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchRoutes(ctx, &pb.WatchRoutesRequest{
+		Types:        []pb.RouteEventType{pb.RouteEventType_ROUTE_EVENT_TYPE_ENTRY_ADDED, pb.RouteEventType_ROUTE_EVENT_TYPE_GATEWAY_CHANGED},
+		Destinations: []string{"example.com"},
+		Replay:       5,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the replayed event is the only matching one in the history
+	e, err := stream.Recv()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint64(1), e.GetSequence())
+	assert.Equal(t, pb.RouteEventType_ROUTE_EVENT_TYPE_ENTRY_ADDED, e.GetType())
+	assert.Equal(t, []string{"1.1.1.1"}, e.GetIps())
+
+	// events that are not about a destination pass the filter of the destinations
+	bus.Publish(&events.Event{Type: events.EntryAdded, Domain: "example.org"})
+	bus.Publish(&events.Event{Type: events.GatewayChanged, Gateway: "10.0.0.2", PreviousGateway: "10.0.0.1"})
+
+	e, err = stream.Recv()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint64(5), e.GetSequence())
+	assert.Equal(t, pb.RouteEventType_ROUTE_EVENT_TYPE_GATEWAY_CHANGED, e.GetType())
+	assert.Equal(t, "10.0.0.1", e.GetPreviousGateway())
+}
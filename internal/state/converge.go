@@ -0,0 +1,139 @@
+package state
+
+import (
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// Declaration is the desired set of entries and groups that are declared in the config file
+type Declaration struct {
+	// Entries are the declared entries, only RouteEntry.Domain, RouteEntry.Group and RouteEntry.Accumulate are taken
+	// into account
+	Entries []*RouteEntry
+	Groups  []*DeclaredGroup
+}
+
+// DeclaredGroup is a Group that is declared in the config file
+type DeclaredGroup struct {
+	Name string
+	// Enabled is the desired status of the group, nil leaves the status to the CLI
+	Enabled *bool
+}
+
+// Converge brings the State to the given Declaration. Missing entries and groups are added, the entries and groups
+// that came from the config file and are not declared anymore are removed. Entries and groups that are added over CLI
+// are left alone, even if they are declared too
+func (s *State) Converge(decl *Declaration, gateway string) error {
+	var changed bool
+
+	// groups first, so that the entries can be placed in them
+	declaredGroups := make(map[string]bool)
+	for _, dg := range decl.Groups {
+		declaredGroups[dg.Name] = true
+
+		group := s.GetGroup(dg.Name)
+		if group == nil {
+			group = NewGroup(dg.Name)
+			group.Source = constants.SourceConfig
+			if dg.Enabled != nil {
+				group.Enabled = *dg.Enabled
+			}
+
+			s.Groups = append(s.Groups, group)
+			changed = true
+
+			continue
+		}
+
+		if group.Source != constants.SourceConfig || dg.Enabled == nil || group.Enabled == *dg.Enabled {
+			continue
+		}
+
+		var err error
+		if *dg.Enabled {
+			err = s.EnableGroup(group.Name, gateway)
+		} else {
+			err = s.DisableGroup(group.Name)
+		}
+
+		if err != nil {
+			s.logger.Error().Err(err).Str("group", group.Name).Msg(constants.FailedToConvergeGroup)
+		}
+	}
+
+	declaredEntries := make(map[string]bool)
+	for _, de := range decl.Entries {
+		declaredEntries[de.Domain] = true
+
+		entry := s.GetEntry(de.Domain)
+		if entry == nil {
+			s.installDeclaredEntry(de, gateway)
+			continue
+		}
+
+		if entry.Source == constants.SourceConfig && entry.Group != de.Group {
+			if routeErr := s.moveEntry(entry, de.Group); routeErr != nil {
+				s.logger.Error().Err(routeErr).Str("domain", entry.Domain).Msg(constants.FailedToConvergeEntry)
+			}
+
+			changed = true
+		}
+
+		// accumulated IPs are aged out on the next refresh if the entry does not accumulate anymore
+		if entry.Source == constants.SourceConfig && entry.Accumulate != de.Accumulate {
+			entry.Accumulate = de.Accumulate
+			changed = true
+		}
+	}
+
+	var undeclared []string
+	for _, entry := range s.Entries {
+		if entry.Source == constants.SourceConfig && !declaredEntries[entry.Domain] {
+			undeclared = append(undeclared, entry.Domain)
+		}
+	}
+
+	for _, domain := range undeclared {
+		if _, err := s.UninstallEntry(domain); err != nil {
+			s.logger.Error().Err(err).Str("domain", domain).Msg(constants.FailedToConvergeEntry)
+			continue
+		}
+
+		s.logger.Info().Str("domain", domain).Msg(constants.RemovedUndeclaredEntry)
+	}
+
+	// undeclared groups are kept while they still hold entries that are added over CLI
+	groups := make([]*Group, 0, len(s.Groups))
+	for _, group := range s.Groups {
+		if group.Source == constants.SourceConfig && !declaredGroups[group.Name] && len(s.GroupEntries(group.Name)) == 0 {
+			changed = true
+			continue
+		}
+
+		groups = append(groups, group)
+	}
+
+	s.Groups = groups
+
+	if !changed {
+		return nil
+	}
+
+	return s.Write()
+}
+
+// installDeclaredEntry adds the given declared entry with installPending
+func (s *State) installDeclaredEntry(de *RouteEntry, gateway string) {
+	entry := NewRouteEntry(de.Domain, gateway, []string{})
+	entry.Group = de.Group
+	entry.Accumulate = de.Accumulate
+	entry.Source = constants.SourceConfig
+
+	added, err := s.installPending(entry)
+	if err != nil {
+		s.logger.Error().Err(err).Str("domain", de.Domain).Msg(constants.FailedToConvergeEntry)
+	}
+
+	if added {
+		s.logger.Info().Str("domain", de.Domain).Str("group", de.Group).Msg(constants.AddedDeclaredEntry)
+	}
+}
@@ -0,0 +1,83 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_Converge(t *testing.T) {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	disabled := false
+
+	// every entry is kept in a disabled group, so that no routes are touched while testing
+	assert.NoError(t, st.CreateGroup("cli"))
+	st.GetGroup("cli").Enabled = false
+	cliEntry := &RouteEntry{Domain: "10.1.0.0/16", Group: "cli"}
+	st.Entries = append(st.Entries, cliEntry)
+
+	decl := &Declaration{
+		Groups: []*DeclaredGroup{{Name: "office", Enabled: &disabled}},
+		Entries: []*RouteEntry{
+			{Domain: "10.2.0.0/16", Group: "office"},
+			{Domain: "10.3.0.1", Group: "office"},
+			{Domain: "10.1.0.0/16", Group: "office"},
+		},
+	}
+
+	assert.NoError(t, st.Converge(decl, "192.168.1.1"))
+	assert.False(t, st.GetGroup("office").Enabled)
+	assert.Equal(t, constants.SourceConfig, st.GetGroup("office").Source)
+	assert.Equal(t, []string{"10.2.0.0/16"}, st.GetEntry("10.2.0.0/16").ResolvedIPs)
+	assert.Equal(t, constants.SourceConfig, st.GetEntry("10.3.0.1").Source)
+	// entries that are added over CLI are left alone
+	assert.Equal(t, "cli", st.GetEntry("10.1.0.0/16").Group)
+	assert.Empty(t, st.GetEntry("10.1.0.0/16").Source)
+
+	// undeclared entries and groups that came from the config are removed
+	decl = &Declaration{Entries: []*RouteEntry{{Domain: "10.2.0.0/16", Group: "cli"}}}
+	assert.NoError(t, st.Converge(decl, "192.168.1.1"))
+	assert.Nil(t, st.GetEntry("10.3.0.1"))
+	assert.Nil(t, st.GetGroup("office"))
+	assert.Equal(t, "cli", st.GetEntry("10.2.0.0/16").Group)
+	assert.NotNil(t, st.GetEntry("10.1.0.0/16"))
+
+	assert.NoError(t, st.Converge(&Declaration{}, "192.168.1.1"))
+	assert.Equal(t, []*RouteEntry{cliEntry}, st.Entries)
+	assert.NotNil(t, st.GetGroup("cli"))
+}
+
+func TestState_Converge_Pending(t *testing.T) {
+	fakeRoutes(t, "10.4.0.1")
+	st := newTestState(t, RouteModeTransactional)
+
+	utils.SetDNSServers([]string{"127.0.0.1:1"})
+	t.Cleanup(func() { utils.SetDNSServers(nil) })
+
+	decl := &Declaration{Entries: []*RouteEntry{{Domain: "pending.invalid"}, {Domain: "10.4.0.1"}}}
+	assert.NoError(t, st.Converge(decl, "192.168.1.1"))
+
+	// entries that cannot be resolved or routed are kept pending without any IP
+	for _, domain := range []string{"pending.invalid", "10.4.0.1"} {
+		entry := st.GetEntry(domain)
+		if assert.NotNil(t, entry, domain) {
+			assert.Empty(t, entry.ResolvedIPs)
+			assert.Equal(t, constants.SourceConfig, entry.Source)
+		}
+	}
+
+	// and their routes are tried again on every refresh
+	assert.NoError(t, st.CheckIPChanges())
+	assert.Empty(t, st.GetEntry("10.4.0.1").ResolvedIPs)
+	assert.Equal(t, IPStatusFailed, st.GetEntry("10.4.0.1").Route("10.4.0.1").Status)
+
+	routes := fakeRoutes(t)
+	assert.NoError(t, st.CheckIPChanges())
+	assert.Equal(t, []string{"10.4.0.1"}, st.GetEntry("10.4.0.1").ResolvedIPs)
+	assert.Equal(t, map[string]bool{"10.4.0.1": true}, routes)
+	assert.Empty(t, st.GetEntry("pending.invalid").ResolvedIPs)
+}
@@ -0,0 +1,48 @@
+package state
+
+import (
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+)
+
+// SetEventBus makes the State publish the changes on its entries to the given events.Bus
+func (s *State) SetEventBus(bus *events.Bus) {
+	s.events = bus
+}
+
+// publishEntry publishes an event of the given type about the given RouteEntry
+func (s *State) publishEntry(typ events.Type, entry *RouteEntry) {
+	s.events.Publish(&events.Event{
+		Type:    typ,
+		Domain:  entry.Domain,
+		IPs:     append([]string{}, entry.ResolvedIPs...),
+		Gateway: entry.Gateway,
+	})
+}
+
+// publishIPChanges publishes events.IPsChanged if the routed IPs of the given RouteEntry differ from the old ones
+func (s *State) publishIPChanges(entry *RouteEntry, oldIPs []string) {
+	added, removed := difference(entry.ResolvedIPs, oldIPs), difference(oldIPs, entry.ResolvedIPs)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	s.events.Publish(&events.Event{
+		Type:       events.IPsChanged,
+		Domain:     entry.Domain,
+		IPs:        append([]string{}, entry.ResolvedIPs...),
+		AddedIPs:   added,
+		RemovedIPs: removed,
+		Gateway:    entry.Gateway,
+	})
+}
+
+// publishRouteFailure publishes events.RouteFailed for the failed IPs of the given RouteError
+func (s *State) publishRouteFailure(gateway string, routeErr *RouteError) {
+	s.events.Publish(&events.Event{
+		Type:    events.RouteFailed,
+		Domain:  routeErr.Domain,
+		IPs:     routeErr.ips(),
+		Gateway: gateway,
+		Error:   routeErr.Error(),
+	})
+}
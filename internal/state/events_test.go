@@ -0,0 +1,89 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_PublishesEvents(t *testing.T) {
+	fakeRoutes(t, "3.3.3.3")
+	st := newTestState(t, RouteModeTransactional)
+	bus := events.NewBus(10)
+	st.SetEventBus(bus)
+	sub := bus.Subscribe(nil, 0)
+	defer sub.Close()
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"})))
+	entry := st.GetEntry("example.com")
+	assert.NoError(t, st.refreshIPs(entry, []string{"2.2.2.2"}, time.Now()))
+	assert.Error(t, st.refreshIPs(entry, []string{"3.3.3.3"}, time.Now()))
+	_, err := st.UninstallEntry("example.com")
+	assert.NoError(t, err)
+
+	var got []*events.Event
+	for len(sub.C) > 0 {
+		got = append(got, <-sub.C)
+	}
+
+	if !assert.Len(t, got, 4) {
+		return
+	}
+
+	assert.Equal(t, events.EntryAdded, got[0].Type)
+	assert.Equal(t, []string{"1.1.1.1"}, got[0].IPs)
+
+	assert.Equal(t, events.IPsChanged, got[1].Type)
+	assert.Equal(t, []string{"2.2.2.2"}, got[1].AddedIPs)
+	assert.Equal(t, []string{"1.1.1.1"}, got[1].RemovedIPs)
+
+	assert.Equal(t, events.RouteFailed, got[2].Type)
+	assert.Equal(t, []string{"3.3.3.3"}, got[2].IPs)
+	assert.NotEmpty(t, got[2].Error)
+
+	assert.Equal(t, events.EntryRemoved, got[3].Type)
+	assert.Equal(t, "example.com", got[3].Domain)
+}
+
+func TestState_PublishesGroupEvents(t *testing.T) {
+	fakeRoutes(t, "2.2.2.2")
+	st := newTestState(t, RouteModeTransactional)
+	bus := events.NewBus(10)
+	st.SetEventBus(bus)
+	sub := bus.Subscribe(func(e *events.Event) bool { return e.Type != events.RouteFailed }, 0)
+	defer sub.Close()
+
+	assert.NoError(t, st.CreateGroup("office"))
+	st.GetGroup("office").Enabled = false
+	entry := NewRouteEntry("1.1.1.1", "10.0.0.1", []string{"1.1.1.1"})
+	entry.Group = "office"
+	st.Entries = append(st.Entries, entry)
+
+	assert.NoError(t, st.EnableGroup("office", "10.0.0.1"))
+	assert.NoError(t, st.DisableGroup("office"))
+
+	// a group that is left disabled by a rollback publishes no entry events
+	failing := NewRouteEntry("2.2.2.2", "10.0.0.1", []string{"2.2.2.2"})
+	failing.Group = "office"
+	st.Entries = append(st.Entries, failing)
+	assert.Error(t, st.EnableGroup("office", "10.0.0.1"))
+
+	var got []*events.Event
+	for len(sub.C) > 0 {
+		got = append(got, <-sub.C)
+	}
+
+	if !assert.Len(t, got, 2) {
+		return
+	}
+
+	assert.Equal(t, events.EntryAdded, got[0].Type)
+	assert.Equal(t, "1.1.1.1", got[0].Domain)
+	assert.Equal(t, []string{"1.1.1.1"}, got[0].IPs)
+	assert.Equal(t, "10.0.0.1", got[0].Gateway)
+
+	assert.Equal(t, events.EntryRemoved, got[1].Type)
+	assert.Equal(t, "1.1.1.1", got[1].Domain)
+}
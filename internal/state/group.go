@@ -0,0 +1,230 @@
+package state
+
+import (
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/pkg/errors"
+)
+
+// Group is the struct that holds a named set of RouteEntry which can be enabled and disabled together
+type Group struct {
+	Name string `json:"name"`
+	// Enabled is the flag that shows if the routes of the entries in the group are installed to the routing table
+	Enabled bool `json:"enabled"`
+	// Source is the origin of the group, empty for the groups that are created over CLI
+	Source string `json:"source,omitempty"`
+}
+
+// NewGroup creates a new enabled Group with the given name
+func NewGroup(name string) *Group {
+	return &Group{
+		Name:    name,
+		Enabled: true,
+	}
+}
+
+// CreateGroup adds a new Group with the given name to the State
+func (s *State) CreateGroup(name string) error {
+	if s.GetGroup(name) != nil {
+		return errors.New(constants.GroupAlreadyExists)
+	}
+
+	s.Groups = append(s.Groups, NewGroup(name))
+
+	return s.Write()
+}
+
+// GetGroup returns the Group with the given name from the State
+func (s *State) GetGroup(name string) *Group {
+	for i := range s.Groups {
+		if s.Groups[i].Name == name {
+			return s.Groups[i]
+		}
+	}
+
+	return nil
+}
+
+// GroupEntries returns the RouteEntry list that belongs to the Group with the given name
+func (s *State) GroupEntries(name string) []*RouteEntry {
+	var entries []*RouteEntry
+	for _, entry := range s.Entries {
+		if entry.Group == name {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// IsEntryActive returns true if the routes of the given RouteEntry should be installed to the routing table, which
+// means the entry is not grouped or its Group is enabled
+func (s *State) IsEntryActive(entry *RouteEntry) bool {
+	if entry.Group == "" {
+		return true
+	}
+
+	group := s.GetGroup(entry.Group)
+
+	return group == nil || group.Enabled
+}
+
+// SetEntryGroup moves the RouteEntry of the given domain into the Group with the given name. Routes of the entry are
+// installed or removed if the entry is activated or deactivated by the move
+func (s *State) SetEntryGroup(domain, name string) error {
+	entry := s.GetEntry(domain)
+	if entry == nil {
+		return errors.New(constants.EntryNotFound)
+	}
+
+	if s.GetGroup(name) == nil {
+		return errors.New(constants.GroupNotFound)
+	}
+
+	if entry.Group == name {
+		return errors.New(constants.EntryAlreadyInGroup)
+	}
+
+	return s.writeRoutes(s.moveEntry(entry, name))
+}
+
+// moveEntry sets the Group of the given RouteEntry, routes of the entry are installed or removed if the entry is
+// activated or deactivated by the move. In RouteModeTransactional, the entry is left in its Group if any of its routes
+// fails. The State is written by the caller
+func (s *State) moveEntry(entry *RouteEntry, name string) *RouteError {
+	wasActive := s.IsEntryActive(entry)
+	previous := entry.Group
+	entry.Group = name
+
+	var routeErr *RouteError
+	switch isActive := s.IsEntryActive(entry); {
+	case wasActive && !isActive:
+		routeErr = s.deactivateEntry(entry, time.Now())
+	case !wasActive && isActive:
+		routeErr = s.activateEntry(entry, entry.ResolvedIPs, time.Now())
+	}
+
+	if routeErr != nil && routeErr.RolledBack {
+		entry.Group = previous
+	}
+
+	return routeErr
+}
+
+// EnableGroup resolves the entries of the Group with the given name again and installs their routes via the given
+// gateway. In RouteModeTransactional, the group is left disabled and the installed routes are removed again if the
+// routes of any entry fail. In RouteModeBestEffort, the group is enabled with the routes that succeed. A *RouteError of
+// the first entry whose routes fail is returned
+func (s *State) EnableGroup(name, gateway string) error {
+	group := s.GetGroup(name)
+	if group == nil {
+		return errors.New(constants.GroupNotFound)
+	}
+
+	if group.Enabled {
+		return errors.New(constants.GroupAlreadyEnabled)
+	}
+
+	// the group is enabled after its entries, so that the IPs that they share are not seen as routed by each other
+	now := time.Now()
+	entries := s.GroupEntries(name)
+	var failed *RouteError
+	for i, entry := range entries {
+		ips := entry.ResolvedIPs
+
+		// IPs may have been changed while the group was disabled
+		res, err := utils.Resolve(entry.Domain)
+		if err != nil {
+			s.logger.Error().Err(err).Str("domain", entry.Domain).Msg(constants.FailedToResolveDomain)
+		} else {
+			ips = res.IPs
+			entry.recordResolvers(res.IPs, res.Sources, now)
+		}
+
+		entry.Gateway = gateway
+		routeErr := s.activateEntry(entry, ips, now)
+		if routeErr == nil {
+			continue
+		}
+
+		if routeErr.RolledBack {
+			for _, done := range entries[:i] {
+				_ = s.deactivateEntry(done, now)
+			}
+
+			return s.writeRoutes(routeErr)
+		}
+
+		if failed == nil {
+			failed = routeErr
+		}
+	}
+
+	group.Enabled = true
+
+	return s.writeGroup(failed, events.EntryAdded, entries)
+}
+
+// DisableGroup removes the routes of the entries in the Group with the given name from the routing table, entries are
+// kept in the State so that the group can be enabled again. In RouteModeTransactional, the group is left enabled and
+// the removed routes are installed again if the routes of any entry cannot be removed. In RouteModeBestEffort, the
+// group is disabled and the routes that cannot be removed are recorded. A *RouteError of the first entry whose routes
+// fail is returned
+func (s *State) DisableGroup(name string) error {
+	group := s.GetGroup(name)
+	if group == nil {
+		return errors.New(constants.GroupNotFound)
+	}
+
+	if !group.Enabled {
+		return errors.New(constants.GroupAlreadyDisabled)
+	}
+
+	// the group is disabled first, so that the IPs that its entries share are not seen as routed by each other
+	group.Enabled = false
+	now := time.Now()
+	entries := s.GroupEntries(name)
+	var failed *RouteError
+	for i, entry := range entries {
+		routeErr := s.deactivateEntry(entry, now)
+		if routeErr == nil {
+			continue
+		}
+
+		if routeErr.RolledBack {
+			for _, done := range entries[:i] {
+				_ = s.activateEntry(done, done.ResolvedIPs, now)
+			}
+
+			group.Enabled = true
+
+			return s.writeRoutes(routeErr)
+		}
+
+		if failed == nil {
+			failed = routeErr
+		}
+	}
+
+	return s.writeGroup(failed, events.EntryRemoved, entries)
+}
+
+// writeGroup writes the State after a Group is enabled or disabled and publishes an event of the given type about each
+// of its entries, the events are not published if the State cannot be written
+func (s *State) writeGroup(routeErr *RouteError, typ events.Type, entries []*RouteEntry) error {
+	err := s.writeRoutes(routeErr)
+
+	var writeErr *WriteError
+	if errors.As(err, &writeErr) {
+		return err
+	}
+
+	for _, entry := range entries {
+		s.publishEntry(typ, entry)
+	}
+
+	return err
+}
@@ -0,0 +1,109 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_CreateGroup(t *testing.T) {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+
+	assert.NoError(t, st.CreateGroup("meetings"))
+	assert.NotNil(t, st.GetGroup("meetings"))
+	assert.True(t, st.GetGroup("meetings").Enabled)
+	assert.EqualError(t, st.CreateGroup("meetings"), constants.GroupAlreadyExists)
+	assert.Nil(t, st.GetGroup("streaming"))
+}
+
+func TestState_IsEntryActive(t *testing.T) {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	assert.NoError(t, st.CreateGroup("meetings"))
+	st.GetGroup("meetings").Enabled = false
+
+	ungrouped := NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"})
+	grouped := NewRouteEntry("zoom.us", "10.0.0.1", []string{"2.2.2.2"})
+	grouped.Group = "meetings"
+	st.Entries = append(st.Entries, ungrouped, grouped)
+
+	assert.True(t, st.IsEntryActive(ungrouped))
+	assert.False(t, st.IsEntryActive(grouped))
+	assert.Equal(t, []*RouteEntry{grouped}, st.GroupEntries("meetings"))
+	assert.EqualError(t, st.DisableGroup("meetings"), constants.GroupAlreadyDisabled)
+	assert.EqualError(t, st.EnableGroup("streaming", "10.0.0.1"), constants.GroupNotFound)
+}
+
+func TestState_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), constants.StateFileName)
+	st := NewState(logging.GetLogger(), path)
+	assert.NoError(t, st.CreateGroup("meetings"))
+
+	entry := NewRouteEntry("zoom.us", "10.0.0.1", []string{"2.2.2.2"})
+	entry.Group = "meetings"
+	st.Entries = append(st.Entries, entry)
+	assert.NoError(t, st.Write())
+
+	loaded := NewState(logging.GetLogger(), path)
+	assert.NoError(t, loaded.Reload())
+	assert.Len(t, loaded.Groups, 1)
+	assert.Equal(t, "meetings", loaded.GetEntry("zoom.us").Group)
+
+	// the stale group of the in-memory entry should not survive a reload of an ungrouped entry
+	entry.Group = ""
+	assert.NoError(t, st.Write())
+	loaded.GetEntry("zoom.us").Group = "meetings"
+	assert.NoError(t, loaded.Reload())
+	assert.Empty(t, loaded.GetEntry("zoom.us").Group)
+}
+
+func TestState_EnableGroup_Transactional(t *testing.T) {
+	routes := fakeRoutes(t, "10.0.2.1")
+	st := newTestState(t, RouteModeTransactional)
+	assert.NoError(t, st.CreateGroup("office"))
+	st.GetGroup("office").Enabled = false
+
+	for _, domain := range []string{"10.0.1.1", "10.0.2.1"} {
+		entry := NewRouteEntry(domain, "10.0.0.1", []string{domain})
+		entry.Group = "office"
+		st.Entries = append(st.Entries, entry)
+	}
+
+	// the routes of the other entries are removed again and the group is left disabled
+	var routeErr *RouteError
+	assert.ErrorAs(t, st.EnableGroup("office", "10.0.0.1"), &routeErr)
+	assert.False(t, st.GetGroup("office").Enabled)
+	assert.Empty(t, routes)
+
+	st.SetRouteMode(RouteModeBestEffort)
+	assert.ErrorAs(t, st.EnableGroup("office", "10.0.0.1"), &routeErr)
+	assert.True(t, st.GetGroup("office").Enabled)
+	assert.Equal(t, map[string]bool{"10.0.1.1": true}, routes)
+	assert.Equal(t, IPStatusFailed, st.GetEntry("10.0.2.1").Route("10.0.2.1").Status)
+}
+
+func TestState_DisableGroup_Transactional(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+	assert.NoError(t, st.CreateGroup("office"))
+
+	for _, domain := range []string{"10.0.1.1", "10.0.2.1"} {
+		entry := NewRouteEntry(domain, "10.0.0.1", []string{domain})
+		entry.Group = "office"
+		assert.NoError(t, st.InstallEntry(entry))
+	}
+
+	failing := fakeRoutes(t, "10.0.2.1")
+	for ip := range routes {
+		failing[ip] = true
+	}
+
+	// the removed routes are installed again and the group is left enabled
+	var routeErr *RouteError
+	assert.ErrorAs(t, st.DisableGroup("office"), &routeErr)
+	assert.True(t, st.GetGroup("office").Enabled)
+	assert.Equal(t, map[string]bool{"10.0.1.1": true, "10.0.2.1": true}, failing)
+	assert.Equal(t, []string{"10.0.1.1"}, st.GetEntry("10.0.1.1").ResolvedIPs)
+}
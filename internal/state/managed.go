@@ -0,0 +1,140 @@
+package state
+
+import (
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// syncManagedGroup brings the managed Group with the given name to the given destinations, the group is created
+// enabled with the given source if it does not exist, otherwise its status is left to the CLI. Missing destinations
+// are added with InstallEntry, the ones that came from the same source and are not listed anymore are removed with
+// UninstallEntry. Destinations that exist with another source, such as the ones added over CLI, are left alone.
+// Entries of the previous group are moved to the group, and the previous group is removed once it is empty.
+// Destinations that cannot be resolved or whose routes are rolled back are added pending, see installPending. The
+// entries whose routes cannot be removed are kept, they are tried again on the next sync. The added and the removed
+// destinations are returned
+func (s *State) syncManagedGroup(source, name, previous string, destinations []string, gateway string) ([]string, []string) {
+	managed := func(entry *RouteEntry) bool {
+		return entry.Source == source && (entry.Group == name || entry.Group == previous)
+	}
+
+	if s.GetGroup(name) == nil {
+		group := NewGroup(name)
+		group.Source = source
+		s.Groups = append(s.Groups, group)
+	}
+
+	var added []string
+	listed := make(map[string]bool, len(destinations))
+	for _, destination := range destinations {
+		listed[destination] = true
+
+		entry := s.GetEntry(destination)
+		if entry == nil {
+			entry = NewRouteEntry(destination, gateway, []string{})
+			entry.Group = name
+			entry.Source = source
+
+			isAdded, err := s.installPending(entry)
+			if err != nil {
+				s.logger.Error().Err(err).Str("domain", destination).Str("group", name).Msg(constants.FailedToSyncEntry)
+			}
+
+			if isAdded {
+				added = append(added, destination)
+			}
+
+			continue
+		}
+
+		if managed(entry) && entry.Group != name {
+			if routeErr := s.moveEntry(entry, name); routeErr != nil {
+				s.logger.Error().Err(routeErr).Str("domain", destination).Str("group", name).Msg(constants.FailedToSyncEntry)
+			}
+		}
+	}
+
+	var unlisted []string
+	for _, entry := range s.Entries {
+		if managed(entry) && !listed[entry.Domain] {
+			unlisted = append(unlisted, entry.Domain)
+		}
+	}
+
+	removed, _ := s.uninstallEntries(unlisted, name)
+	if previous != name {
+		s.removeManagedGroup(source, previous)
+	}
+
+	return added, removed
+}
+
+// isGroupSynced reports whether every given destination has an entry and the managed Group with the given name holds
+// no other entry of the given source
+func (s *State) isGroupSynced(source, name string, destinations []string) bool {
+	listed := make(map[string]bool, len(destinations))
+	for _, destination := range destinations {
+		if s.GetEntry(destination) == nil {
+			return false
+		}
+
+		listed[destination] = true
+	}
+
+	for _, entry := range s.GroupEntries(name) {
+		if entry.Source == source && !listed[entry.Domain] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeManagedEntries removes the entries of the given group that came from the given source and returns them. The
+// entries whose routes cannot be removed are kept in the group, the last of their errors is returned
+func (s *State) removeManagedEntries(source, group string) ([]string, error) {
+	var domains []string
+	for _, entry := range s.Entries {
+		if entry.Source == source && entry.Group == group {
+			domains = append(domains, entry.Domain)
+		}
+	}
+
+	return s.uninstallEntries(domains, group)
+}
+
+// uninstallEntries removes the entries with the given domains with UninstallEntry, the failures are logged and the
+// last of them is returned along with the removed domains
+func (s *State) uninstallEntries(domains []string, group string) ([]string, error) {
+	var removed []string
+	var lastErr error
+	for _, domain := range domains {
+		if _, err := s.UninstallEntry(domain); err != nil {
+			s.logger.Error().Err(err).Str("domain", domain).Str("group", group).Msg(constants.FailedToSyncEntry)
+			lastErr = err
+
+			continue
+		}
+
+		removed = append(removed, domain)
+	}
+
+	return removed, lastErr
+}
+
+// removeManagedGroup removes the Group with the given name if it is managed by the given source and holds no entries
+// anymore, such as the entries that are added to it over CLI
+func (s *State) removeManagedGroup(source, name string) {
+	group := s.GetGroup(name)
+	if group == nil || group.Source != source || len(s.GroupEntries(name)) > 0 {
+		return
+	}
+
+	groups := make([]*Group, 0, len(s.Groups))
+	for _, g := range s.Groups {
+		if g.Name != name {
+			groups = append(groups, g)
+		}
+	}
+
+	s.Groups = groups
+}
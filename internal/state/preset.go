@@ -0,0 +1,55 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// EnabledPresets returns the names of the enabled presets, which are the names of the groups that are managed by them
+func (s *State) EnabledPresets() []string {
+	var names []string
+	for _, group := range s.Groups {
+		if group.Source == constants.SourcePreset {
+			names = append(names, group.Name)
+		}
+	}
+
+	return names
+}
+
+// SyncPreset brings the managed Group of the preset with the given name to the given destinations, see
+// syncManagedGroup. The first sync creates the group, which enables the preset. A group with the same name that is not
+// managed by a preset is not touched. The added and the removed destinations are returned
+func (s *State) SyncPreset(name string, destinations []string, gateway string) ([]string, []string, error) {
+	if group := s.GetGroup(name); group != nil && group.Source != constants.SourcePreset {
+		return nil, nil, errors.New(constants.GroupAlreadyExists)
+	}
+
+	added, removed := s.syncManagedGroup(constants.SourcePreset, name, name, destinations, gateway)
+
+	return added, removed, s.Write()
+}
+
+// RemovePreset removes the destinations that came from the preset with the given name and its managed Group, which
+// disables the preset. It returns the removed destinations
+func (s *State) RemovePreset(name string) ([]string, error) {
+	if group := s.GetGroup(name); group == nil || group.Source != constants.SourcePreset {
+		return nil, errors.New(constants.PresetNotEnabled)
+	}
+
+	// the preset is kept enabled to be removed again if any of its entries is kept
+	removed, err := s.removeManagedEntries(constants.SourcePreset, name)
+	if err != nil {
+		return removed, err
+	}
+
+	s.removeManagedGroup(constants.SourcePreset, name)
+
+	// the group is left to the CLI if it still holds the entries that are added over CLI
+	if group := s.GetGroup(name); group != nil {
+		group.Source = ""
+	}
+
+	return removed, s.Write()
+}
@@ -0,0 +1,89 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_SyncPreset(t *testing.T) {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+
+	// the entries are kept in a disabled group, so that no routes are touched while testing
+	st.Groups = append(st.Groups, &Group{Name: "zoom", Source: constants.SourcePreset}, &Group{Name: "chat"})
+	cliEntry := &RouteEntry{Domain: "10.1.0.0/16", Group: "zoom"}
+	st.Entries = append(st.Entries, cliEntry)
+
+	added, removed, err := st.SyncPreset("zoom", []string{"10.1.0.0/16", "10.2.0.0/16", "10.3.0.1"}, "192.168.1.1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"10.2.0.0/16", "10.3.0.1"}, added)
+	assert.Empty(t, removed)
+	assert.Equal(t, constants.SourcePreset, st.GetEntry("10.2.0.0/16").Source)
+	assert.Equal(t, []string{"zoom"}, st.EnabledPresets())
+
+	// a changed catalog removes the destinations that are not in the preset anymore
+	added, removed, err = st.SyncPreset("zoom", []string{"10.2.0.0/16"}, "192.168.1.1")
+	assert.NoError(t, err)
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"10.3.0.1"}, removed)
+
+	// groups that are not managed by a preset are not taken over
+	_, _, err = st.SyncPreset("chat", []string{"10.4.0.0/16"}, "192.168.1.1")
+	assert.EqualError(t, err, constants.GroupAlreadyExists)
+	assert.Nil(t, st.GetEntry("10.4.0.0/16"))
+
+	removed, err = st.RemovePreset("zoom")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.2.0.0/16"}, removed)
+	assert.Equal(t, []*RouteEntry{cliEntry}, st.Entries)
+	// the group still holds the entry that is added over CLI, it is not managed by the preset anymore
+	if assert.NotNil(t, st.GetGroup("zoom")) {
+		assert.Empty(t, st.GetGroup("zoom").Source)
+	}
+
+	assert.Empty(t, st.EnabledPresets())
+
+	_, err = st.RemovePreset("chat")
+	assert.EqualError(t, err, constants.PresetNotEnabled)
+}
+
+func TestState_SyncPreset_Routes(t *testing.T) {
+	routes := fakeRoutes(t, "10.3.0.1")
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+
+	// the destination whose route is rolled back is added pending, its routes are tried again on every refresh
+	added, _, err := st.SyncPreset("zoom", []string{"10.2.0.1", "10.3.0.1"}, "192.168.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.2.0.1", "10.3.0.1"}, added)
+	if assert.NotNil(t, st.GetEntry("10.3.0.1")) {
+		assert.Empty(t, st.GetEntry("10.3.0.1").ResolvedIPs)
+	}
+
+	assert.Equal(t, map[string]bool{"10.2.0.1": true}, routes)
+
+	assert.NoError(t, st.CheckIPChanges())
+	assert.Empty(t, st.GetEntry("10.3.0.1").ResolvedIPs)
+
+	// entries whose routes cannot be removed are kept, so that the preset can be removed again
+	removeRoute = func(ip string) error {
+		return errors.New("failed to remove route")
+	}
+
+	removed, err := st.RemovePreset("zoom")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"10.3.0.1"}, removed)
+	assert.NotNil(t, st.GetEntry("10.2.0.1"))
+	assert.Equal(t, []string{"zoom"}, st.EnabledPresets())
+
+	_, removed, err = st.SyncPreset("zoom", nil, "192.168.1.1")
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+	assert.NotNil(t, st.GetEntry("10.2.0.1"))
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+)
+
+// SetGracePeriod sets the duration that the IPs of an entry are kept routed after they are last resolved. DNS
+// round-robin returns rotating subsets of the IPs of a domain, so the connections that are opened against the previous
+// answers keep bypassing VPN during the grace period
+func (s *State) SetGracePeriod(gracePeriod time.Duration) {
+	s.gracePeriod = gracePeriod
+}
+
+// SetAccumulation sets the window that the IPs of the accumulating entries are kept routed after they are last resolved
+// and the maximum number of the IPs that they keep routed, maxIPs 0 means no limit
+func (s *State) SetAccumulation(window time.Duration, maxIPs int) {
+	s.accumulateWindow = window
+	s.accumulateMaxIPs = maxIPs
+}
+
+// LastRefresh returns the last time that CheckIPChanges checked the IPs of the entries, zero if it has not run yet
+func (s *State) LastRefresh() time.Time {
+	return s.lastRefresh
+}
+
+// refreshIPs moves the routes of the given active RouteEntry to the IPs that are resolved at the given time. Only the
+// difference is applied, routes of the new IPs are installed first and the stale IPs are removed after that, unless
+// they are seen within the grace period
+func (s *State) refreshIPs(entry *RouteEntry, resolved []string, now time.Time) error {
+	target := s.accumulatedIPs(entry, resolved, now)
+	if utils.SlicesEqual(append([]string{}, target...), append([]string{}, entry.ResolvedIPs...)) {
+		entry.markSeen(resolved, now)
+		entry.pruneRoutes(now)
+
+		return nil
+	}
+
+	s.logger.Info().Str("domain", entry.Domain).
+		Strs("added", difference(target, entry.ResolvedIPs)).
+		Strs("removed", difference(entry.ResolvedIPs, target)).
+		Msg("ip changes detected, applying changes to the routing table")
+
+	return s.refreshEntry(entry, resolved, target, now)
+}
+
+// recentIPs returns the routed IPs of the given RouteEntry that are resolved within the grace period, or within the
+// accumulation window if the entry accumulates its IPs. The most recently seen ones come first
+func (s *State) recentIPs(entry *RouteEntry, now time.Time) []string {
+	window := s.gracePeriod
+	if entry.Accumulate && s.accumulateWindow > window {
+		window = s.accumulateWindow
+	}
+
+	if window <= 0 {
+		return nil
+	}
+
+	var routes []*IPRoute
+	for _, ip := range entry.ResolvedIPs {
+		if route := entry.Route(ip); route != nil && now.Sub(route.LastSeen) < window {
+			routes = append(routes, route)
+		}
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].LastSeen.After(routes[j].LastSeen)
+	})
+
+	ips := make([]string, 0, len(routes))
+	for _, route := range routes {
+		ips = append(ips, route.IP)
+	}
+
+	return ips
+}
+
+// accumulatedIPs returns the IPs that the given RouteEntry is moved to when the given IPs are resolved. The resolved
+// ones always come first and are never dropped, the least recently seen ones are dropped if an accumulating entry
+// exceeds the maximum number of the IPs
+func (s *State) accumulatedIPs(entry *RouteEntry, resolved []string, now time.Time) []string {
+	recent := difference(s.recentIPs(entry, now), resolved)
+	if entry.Accumulate && s.accumulateMaxIPs > 0 {
+		limit := s.accumulateMaxIPs - len(resolved)
+		if limit < 0 {
+			limit = 0
+		}
+
+		if len(recent) > limit {
+			recent = recent[:limit]
+		}
+	}
+
+	return union(resolved, recent)
+}
@@ -0,0 +1,90 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_RefreshIPs_GracePeriod(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+	st.SetGracePeriod(10 * time.Minute)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"})))
+	entry := st.GetEntry("example.com")
+	seen := entry.Route("2.2.2.2").LastSeen
+
+	// a rotating subset keeps the IPs of the previous answers routed
+	assert.NoError(t, st.refreshIPs(entry, []string{"1.1.1.1", "4.4.4.4"}, seen.Add(time.Minute)))
+	assert.ElementsMatch(t, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3", "4.4.4.4"}, entry.ResolvedIPs)
+	assert.Len(t, routes, 4)
+	assert.Equal(t, seen, entry.Route("2.2.2.2").LastSeen)
+
+	assert.NoError(t, st.refreshIPs(entry, []string{"1.1.1.1", "4.4.4.4"}, seen.Add(11*time.Minute)))
+	assert.ElementsMatch(t, []string{"1.1.1.1", "4.4.4.4"}, entry.ResolvedIPs)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "4.4.4.4": true}, routes)
+	assert.Equal(t, IPStatusRemoved, entry.Route("2.2.2.2").Status)
+}
+
+func TestState_RefreshIPs_Accumulate(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+	st.SetAccumulation(time.Hour, 3)
+
+	entry := NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"})
+	entry.Accumulate = true
+	assert.NoError(t, st.InstallEntry(entry))
+	entry = st.GetEntry("example.com")
+	start := entry.Route("1.1.1.1").LastSeen
+
+	// rotating answers are accumulated within the window
+	assert.NoError(t, st.refreshIPs(entry, []string{"2.2.2.2"}, start.Add(10*time.Minute)))
+	assert.NoError(t, st.refreshIPs(entry, []string{"3.3.3.3"}, start.Add(20*time.Minute)))
+	assert.ElementsMatch(t, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, entry.ResolvedIPs)
+
+	// the least recently seen IP is dropped when the cap is exceeded, the resolved ones are always kept
+	assert.NoError(t, st.refreshIPs(entry, []string{"4.4.4.4"}, start.Add(30*time.Minute)))
+	assert.ElementsMatch(t, []string{"2.2.2.2", "3.3.3.3", "4.4.4.4"}, entry.ResolvedIPs)
+	assert.Equal(t, IPStatusRemoved, entry.Route("1.1.1.1").Status)
+
+	// IPs that are not seen for the window are aged out
+	assert.NoError(t, st.refreshIPs(entry, []string{"4.4.4.4"}, start.Add(75*time.Minute)))
+	assert.ElementsMatch(t, []string{"3.3.3.3", "4.4.4.4"}, entry.ResolvedIPs)
+	assert.Equal(t, map[string]bool{"3.3.3.3": true, "4.4.4.4": true}, routes)
+
+	// entries that do not accumulate replace their IPs
+	plain := NewRouteEntry("example.org", "10.0.0.1", []string{"5.5.5.5"})
+	assert.NoError(t, st.InstallEntry(plain))
+	plain = st.GetEntry("example.org")
+	assert.NoError(t, st.refreshIPs(plain, []string{"6.6.6.6"}, time.Now()))
+	assert.Equal(t, []string{"6.6.6.6"}, plain.ResolvedIPs)
+}
+
+func TestState_RefreshIPs_AddsBeforeRemoving(t *testing.T) {
+	fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+
+	var ops []string
+	addRoute = func(ip, gateway string) error {
+		ops = append(ops, "add "+ip)
+		return nil
+	}
+
+	removeRoute = func(ip string) error {
+		ops = append(ops, "remove "+ip)
+		return nil
+	}
+
+	// unchanged IPs are not touched at all
+	entry := st.GetEntry("example.com")
+	assert.NoError(t, st.refreshIPs(entry, []string{"2.2.2.2", "1.1.1.1"}, time.Now()))
+	assert.Empty(t, ops)
+
+	assert.NoError(t, st.refreshIPs(entry, []string{"1.1.1.1", "5.5.5.5"}, time.Now()))
+	assert.Equal(t, []string{"add 5.5.5.5", "remove 2.2.2.2"}, ops)
+	assert.True(t, utils.SlicesEqual([]string{"1.1.1.1", "5.5.5.5"}, entry.ResolvedIPs))
+}
@@ -0,0 +1,196 @@
+package state
+
+import (
+	"time"
+)
+
+// IPStatus is the status of the route of a single IP of a RouteEntry
+type IPStatus string
+
+const (
+	// IPStatusInstalled means the route of the IP is in the routing table
+	IPStatusInstalled IPStatus = "installed"
+	// IPStatusPending means the IP is resolved but its route is not installed yet, such as the IPs of the entries in
+	// the disabled groups
+	IPStatusPending IPStatus = "pending"
+	// IPStatusFailed means the route of the IP could not be installed, it is retried on the next refresh
+	IPStatusFailed IPStatus = "failed"
+	// IPStatusRemoved means the route of the IP is removed from the routing table
+	IPStatusRemoved IPStatus = "removed"
+)
+
+// removedRouteRetention is the duration that the removed routes are kept in RouteEntry.Routes for debugging
+const removedRouteRetention = 24 * time.Hour
+
+// IPRoute is the status of the route of a single IP of a RouteEntry
+type IPRoute struct {
+	IP     string   `json:"ip"`
+	Status IPStatus `json:"status"`
+	// LastError is the error of the last failed operation on the route, it is cleared when the route is installed
+	LastError string `json:"lastError,omitempty"`
+	// Attempts is the number of the consecutive failed attempts to install the route
+	Attempts int `json:"attempts,omitempty"`
+	// FirstSeen is the first time that the IP is resolved for the domain
+	FirstSeen time.Time `json:"firstSeen"`
+	// LastSeen is the last time that the IP is resolved for the domain
+	LastSeen time.Time `json:"lastSeen"`
+	// Resolvers are the DNS servers that returned the IP on the last resolution of the domain
+	Resolvers []string `json:"resolvers,omitempty"`
+}
+
+// Route returns the IPRoute of the given IP, nil if the IP has never been resolved for the RouteEntry
+func (e *RouteEntry) Route(ip string) *IPRoute {
+	for _, route := range e.Routes {
+		if route.IP == ip {
+			return route
+		}
+	}
+
+	return nil
+}
+
+// FailedIPs returns the IPs whose routes could not be installed
+func (e *RouteEntry) FailedIPs() []string {
+	var ips []string
+	for _, route := range e.Routes {
+		if route.Status == IPStatusFailed {
+			ips = append(ips, route.IP)
+		}
+	}
+
+	return ips
+}
+
+// routeOf returns the IPRoute of the given IP, it is created as pending if the IP is not known yet
+func (e *RouteEntry) routeOf(ip string, now time.Time) *IPRoute {
+	route := e.Route(ip)
+	if route == nil {
+		route = &IPRoute{IP: ip, Status: IPStatusPending, FirstSeen: now, LastSeen: now}
+		e.Routes = append(e.Routes, route)
+	}
+
+	return route
+}
+
+// markSeen records that the given IPs are resolved for the RouteEntry at the given time, IPs that are seen for the
+// first time are pending
+func (e *RouteEntry) markSeen(ips []string, now time.Time) {
+	for _, ip := range ips {
+		e.routeOf(ip, now).LastSeen = now
+	}
+}
+
+// recordResolvers records the DNS servers that returned the given IPs on the last resolution, sources map the IPs to
+// their DNS servers. IPs without any source, such as the IP addresses and CIDR blocks, are left as they are
+func (e *RouteEntry) recordResolvers(ips []string, sources map[string][]string, now time.Time) {
+	for _, ip := range ips {
+		if resolvers := sources[ip]; len(resolvers) > 0 {
+			e.routeOf(ip, now).Resolvers = resolvers
+		}
+	}
+}
+
+// markInstalled records that the route of the given IP is installed
+func (e *RouteEntry) markInstalled(ip string, now time.Time) {
+	route := e.routeOf(ip, now)
+	route.Status = IPStatusInstalled
+	route.LastError = ""
+	route.Attempts = 0
+}
+
+// markFailed records that the route of the given IP could not be changed. A route that could not be removed is kept
+// as installed, since it is still in the routing table
+func (e *RouteEntry) markFailed(ip string, err error, now time.Time) {
+	route := e.routeOf(ip, now)
+	route.LastError = err.Error()
+	if route.Status != IPStatusInstalled {
+		route.Status = IPStatusFailed
+		route.Attempts++
+	}
+}
+
+// markRemoved records that the route of the given IP is removed
+func (e *RouteEntry) markRemoved(ip string) {
+	if route := e.Route(ip); route != nil {
+		route.Status = IPStatusRemoved
+		route.LastError = ""
+		route.Attempts = 0
+	}
+}
+
+// pruneRoutes drops the routes that are removed and not seen for removedRouteRetention
+func (e *RouteEntry) pruneRoutes(now time.Time) {
+	routes := make([]*IPRoute, 0, len(e.Routes))
+	for _, route := range e.Routes {
+		if route.Status != IPStatusRemoved || now.Sub(route.LastSeen) < removedRouteRetention {
+			routes = append(routes, route)
+		}
+	}
+
+	e.Routes = routes
+}
+
+// recordRoutes records the outcome of moving the routes of the RouteEntry to the given resolved IPs. activeIPs are the
+// IPs that are routed afterward and routeErr holds the IPs that failed, if any. Routes that are neither routed nor
+// resolved anymore are marked as removed
+func (e *RouteEntry) recordRoutes(ips, activeIPs []string, routeErr *RouteError, now time.Time) {
+	e.markSeen(ips, now)
+
+	resolved := make(map[string]bool, len(ips))
+	for _, ip := range ips {
+		resolved[ip] = true
+	}
+
+	active := make(map[string]bool, len(activeIPs))
+	for _, ip := range activeIPs {
+		active[ip] = true
+		e.markInstalled(ip, now)
+	}
+
+	if routeErr != nil {
+		for ip, err := range routeErr.Failed {
+			e.markFailed(ip, err, now)
+		}
+	}
+
+	for _, route := range e.Routes {
+		switch {
+		case active[route.IP]:
+		case route.Status == IPStatusInstalled:
+			e.markRemoved(route.IP)
+		case route.Status != IPStatusRemoved && !resolved[route.IP] && (routeErr == nil || routeErr.Failed[route.IP] == nil):
+			e.markRemoved(route.IP)
+		}
+	}
+
+	e.pruneRoutes(now)
+}
+
+// cloneRoutes returns a deep copy of the Routes of the RouteEntry, so that the copy can be changed without changing
+// the entry in the State
+func (e *RouteEntry) cloneRoutes() []*IPRoute {
+	routes := make([]*IPRoute, 0, len(e.Routes))
+	for _, route := range e.Routes {
+		clone := *route
+		routes = append(routes, &clone)
+	}
+
+	return routes
+}
+
+// backfillRoutes records the routes of the entries that are written before the statuses of the routes are tracked,
+// IPs of the active entries are assumed to be installed
+func (s *State) backfillRoutes(now time.Time) {
+	for _, entry := range s.Entries {
+		if len(entry.Routes) > 0 {
+			continue
+		}
+
+		entry.markSeen(entry.ResolvedIPs, now)
+		if s.IsEntryActive(entry) {
+			for _, ip := range entry.ResolvedIPs {
+				entry.markInstalled(ip, now)
+			}
+		}
+	}
+}
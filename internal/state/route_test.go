@@ -0,0 +1,106 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteEntry_RecordRoutes(t *testing.T) {
+	now := time.Now()
+	entry := NewRouteEntry("example.com", "10.0.0.1", nil)
+
+	entry.recordRoutes([]string{"1.1.1.1", "2.2.2.2"}, []string{"1.1.1.1"}, &RouteError{
+		Failed: map[string]error{"2.2.2.2": errors.New("exit status 2")},
+	}, now)
+	assert.Equal(t, IPStatusInstalled, entry.Route("1.1.1.1").Status)
+	assert.Equal(t, IPStatusFailed, entry.Route("2.2.2.2").Status)
+	assert.Equal(t, "exit status 2", entry.Route("2.2.2.2").LastError)
+	assert.Equal(t, 1, entry.Route("2.2.2.2").Attempts)
+	assert.Equal(t, []string{"2.2.2.2"}, entry.FailedIPs())
+
+	later := now.Add(time.Minute)
+	entry.recordRoutes([]string{"2.2.2.2", "3.3.3.3"}, []string{"2.2.2.2", "3.3.3.3"}, nil, later)
+	assert.Equal(t, IPStatusRemoved, entry.Route("1.1.1.1").Status)
+	assert.Equal(t, IPStatusInstalled, entry.Route("2.2.2.2").Status)
+	assert.Empty(t, entry.Route("2.2.2.2").LastError)
+	assert.Zero(t, entry.Route("2.2.2.2").Attempts)
+	assert.Equal(t, now, entry.Route("2.2.2.2").FirstSeen)
+	assert.Equal(t, later, entry.Route("2.2.2.2").LastSeen)
+	assert.Empty(t, entry.FailedIPs())
+
+	// removed routes are dropped after the retention
+	entry.recordRoutes([]string{"2.2.2.2", "3.3.3.3"}, []string{"2.2.2.2", "3.3.3.3"}, nil, now.Add(removedRouteRetention))
+	assert.Nil(t, entry.Route("1.1.1.1"))
+	assert.Len(t, entry.Routes, 2)
+}
+
+func TestState_InstallEntry_RecordsResolvers(t *testing.T) {
+	fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+
+	assert.NoError(t, st.InstallEntry(NewResolvedEntry("example.com", "10.0.0.1", &utils.Resolution{
+		IPs:     []string{"1.1.1.1"},
+		Sources: map[string][]string{"1.1.1.1": {"8.8.8.8:53", "9.9.9.9:53"}, "3.3.3.3": {"9.9.9.9:53"}},
+	})))
+	entry := st.GetEntry("example.com")
+	assert.Equal(t, []string{"8.8.8.8:53", "9.9.9.9:53"}, entry.Route("1.1.1.1").Resolvers)
+	// IPs that are left out of the resolution are not tracked
+	assert.Nil(t, entry.Route("3.3.3.3"))
+
+	// the resolvers of the new resolution are carried over to the existing entry
+	assert.NoError(t, st.InstallEntry(NewResolvedEntry("example.com", "10.0.0.1", &utils.Resolution{
+		IPs:     []string{"1.1.1.1", "2.2.2.2"},
+		Sources: map[string][]string{"1.1.1.1": {"8.8.8.8:53"}, "2.2.2.2": {"8.8.8.8:53"}},
+	})))
+	entry = st.GetEntry("example.com")
+	assert.Equal(t, []string{"8.8.8.8:53"}, entry.Route("1.1.1.1").Resolvers)
+	assert.Equal(t, []string{"8.8.8.8:53"}, entry.Route("2.2.2.2").Resolvers)
+}
+
+func TestState_CheckIPChanges_RetriesFailedRoutes(t *testing.T) {
+	routes := fakeRoutes(t, "5.5.5.5")
+	st := newTestState(t, RouteModeBestEffort)
+
+	// IP literals are resolved to themselves, so the refresh does not need DNS
+	var routeErr *RouteError
+	assert.ErrorAs(t, st.InstallEntry(NewRouteEntry("5.5.5.5", "10.0.0.1", []string{"5.5.5.5"})), &routeErr)
+
+	entry := st.GetEntry("5.5.5.5")
+	assert.Empty(t, entry.ResolvedIPs)
+	assert.Equal(t, IPStatusFailed, entry.Route("5.5.5.5").Status)
+	assert.True(t, st.LastRefresh().IsZero())
+
+	assert.NoError(t, st.CheckIPChanges())
+	assert.Equal(t, 2, st.GetEntry("5.5.5.5").Route("5.5.5.5").Attempts)
+	assert.False(t, st.LastRefresh().IsZero())
+
+	addRoute = func(ip, gateway string) error {
+		routes[ip] = true
+		return nil
+	}
+
+	assert.NoError(t, st.CheckIPChanges())
+	entry = st.GetEntry("5.5.5.5")
+	assert.Equal(t, []string{"5.5.5.5"}, entry.ResolvedIPs)
+	assert.Equal(t, IPStatusInstalled, entry.Route("5.5.5.5").Status)
+	assert.True(t, routes["5.5.5.5"])
+
+	// statuses survive the restarts of the daemon
+	reloaded := newTestState(t, RouteModeBestEffort)
+	reloaded.path = st.path
+	assert.NoError(t, reloaded.Reload())
+	assert.Equal(t, IPStatusInstalled, reloaded.GetEntry("5.5.5.5").Route("5.5.5.5").Status)
+}
+
+func TestState_Reload_BackfillsRoutes(t *testing.T) {
+	st := newTestState(t, RouteModeTransactional)
+	st.Entries = append(st.Entries, NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"}))
+	assert.NoError(t, st.Write())
+
+	assert.NoError(t, st.Reload())
+	assert.Equal(t, IPStatusInstalled, st.GetEntry("example.com").Route("1.1.1.1").Status)
+}
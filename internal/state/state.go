@@ -2,11 +2,13 @@ package state
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
 	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
@@ -17,16 +19,36 @@ import (
 // State is the struct that holds the state of the application
 type State struct {
 	Entries []*RouteEntry `json:"entries"`
-	logger  zerolog.Logger
-	path    string
+	Groups  []*Group      `json:"groups"`
+	// Subscriptions are the records of the external lists that are synced into their managed groups
+	Subscriptions []*Subscription `json:"subscriptions,omitempty"`
+	logger        zerolog.Logger
+	path          string
+	// mode is the RouteMode of the route operations of the entries
+	mode RouteMode
+	// gracePeriod is the duration that the IPs are kept routed after they are last resolved
+	gracePeriod time.Duration
+	// accumulateWindow is the duration that the IPs of the accumulating entries are kept routed after they are last
+	// resolved
+	accumulateWindow time.Duration
+	// accumulateMaxIPs is the maximum number of the IPs that an accumulating entry keeps routed, 0 means no limit
+	accumulateMaxIPs int
+	// lastRefresh is the last time that the IPs of the entries are checked for changes, zero if they are not yet
+	lastRefresh time.Time
+	// events is the events.Bus that the changes on the entries are published to, nil if they are not published
+	events *events.Bus
+	// mu serializes the operations of the IPC, gRPC and the background jobs on the State, it is held by the callers
+	// through Lock and Unlock since most of the operations span multiple State calls
+	mu sync.Mutex
 }
 
-// NewState creates a new State with an empty list of RouteEntry
+// NewState creates a new State with an empty list of RouteEntry and Group
 func NewState(logger zerolog.Logger, path string) *State {
 	return &State{
-		[]*RouteEntry{},
-		logger,
-		path,
+		Entries: []*RouteEntry{},
+		Groups:  []*Group{},
+		logger:  logger,
+		path:    path,
 	}
 }
 
@@ -35,6 +57,21 @@ type RouteEntry struct {
 	Domain      string   `json:"domain"`
 	Gateway     string   `json:"gateway"`
 	ResolvedIPs []string `json:"resolvedIPs"`
+	// Group is the name of the Group that the entry belongs to, empty if the entry is not grouped
+	Group string `json:"group,omitempty"`
+	// ExpiresAt is the time that the entry will be removed automatically, nil if the entry is permanent
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Source is the origin of the entry, empty for the entries that are added over CLI
+	Source string `json:"source,omitempty"`
+	// Tags and Comment are the free-form labels and the note of the entry, they are kept for the exported lists
+	Tags    []string `json:"tags,omitempty"`
+	Comment string   `json:"comment,omitempty"`
+	// Accumulate keeps the IPs that are resolved within the accumulation window routed instead of replacing them with
+	// the latest answer, for the domains behind DNS round-robin
+	Accumulate bool `json:"accumulate,omitempty"`
+	// Routes are the statuses of the routes of the IPs that are resolved for the entry, ResolvedIPs are the ones that
+	// are routed. Failed ones are retried on the next refresh
+	Routes []*IPRoute `json:"routes,omitempty"`
 }
 
 // NewRouteEntry creates a new RouteEntry with the given domain, gateway and resolvedIPs
@@ -46,14 +83,40 @@ func NewRouteEntry(domain, gateway string, resolvedIPs []string) *RouteEntry {
 	}
 }
 
+// NewResolvedEntry creates a new RouteEntry with the IPs of the given utils.Resolution, the DNS servers that returned
+// the IPs are recorded on their routes
+func NewResolvedEntry(domain, gateway string, res *utils.Resolution) *RouteEntry {
+	entry := NewRouteEntry(domain, gateway, res.IPs)
+	entry.recordResolvers(res.IPs, res.Sources, time.Now())
+
+	return entry
+}
+
+// Lock acquires the lock of the State
+func (s *State) Lock() {
+	s.mu.Lock()
+}
+
+// Unlock releases the lock of the State
+func (s *State) Unlock() {
+	s.mu.Unlock()
+}
+
+// IsExpired returns true if the RouteEntry is a temporary one and its expiry time has passed at the given time
+func (e *RouteEntry) IsExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
 func (s *State) CheckIPChanges() error {
+	s.lastRefresh = time.Now()
+
 	if len(s.Entries) == 0 {
 		s.logger.Info().Msg("no entries found in the state, skipping ip check")
 		return nil
 	}
 
 	if applyNeeded := s.updateEntries(); applyNeeded {
-		s.logger.Info().Msg("ip changes detected, applying internal state")
+		s.logger.Info().Msg("entries are refreshed, applying internal state")
 		return s.Write()
 	}
 
@@ -65,49 +128,66 @@ func (s *State) CheckIPChanges() error {
 func (s *State) updateEntries() bool {
 	var applyNeeded bool
 	for _, entry := range s.Entries {
-		ipList, err := utils.ResolveDomain(entry.Domain)
+		// routes of the disabled groups are not installed, they will be resolved again when the group is enabled
+		if !s.IsEntryActive(entry) {
+			continue
+		}
+
+		res, err := utils.Resolve(entry.Domain)
 		if err != nil {
 			s.logger.Error().Err(err).Str("domain", entry.Domain).Msg("failed to resolve domain")
 			continue
 		}
 
-		if !utils.SlicesEqual(ipList, entry.ResolvedIPs) {
-			s.logger.Info().Str("domain", entry.Domain).Msg("ip changes detected, applying changes to the routing table")
-			s.removeOldRoutes(entry)
-			entry.ResolvedIPs = ipList
-			applyNeeded = true
-			s.addNewRoutes(entry)
+		// the last seen times of the IPs are updated even if nothing changes
+		applyNeeded = true
+
+		now := time.Now()
+		if err := s.refreshIPs(entry, res.IPs, now); err != nil {
+			s.logger.Error().Err(err).Str("domain", entry.Domain).Msg(constants.FailedToRefreshEntry)
 		}
+
+		entry.recordResolvers(res.IPs, res.Sources, now)
 	}
 
 	return applyNeeded
 }
 
+// removeOldRoutes removes the routes of the entry from the routing table, entry keeps its IPs so that the routes can be
+// installed again. Routes that cannot be removed are recorded with their errors
 func (s *State) removeOldRoutes(entry *RouteEntry) {
+	now := time.Now()
+	routeErr := &RouteError{Domain: entry.Domain, Failed: make(map[string]error)}
 	for _, ip := range entry.ResolvedIPs {
-		if err := utils.RemoveRoute(ip); err != nil {
-			fmt.Println(err)
+		if _, err := s.uninstallRoute(entry.Domain, ip); err != nil {
+			s.logger.Error().Err(err).Str("domain", entry.Domain).Str("ip", ip).Msg(constants.FailedToRemoveRoute)
+			entry.markFailed(ip, err, now)
+			routeErr.Failed[ip] = err
+
+			continue
 		}
+
+		entry.markRemoved(ip)
 	}
-}
 
-func (s *State) addNewRoutes(entry *RouteEntry) {
-	for _, ip := range entry.ResolvedIPs {
-		if err := utils.AddRoute(ip, entry.Gateway); err != nil {
-			fmt.Println(err)
-		}
+	if len(routeErr.Failed) > 0 {
+		s.publishRouteFailure(entry.Gateway, routeErr)
 	}
+
+	entry.pruneRoutes(now)
 }
 
-// AddEntry adds a new RouteEntry to the State. If the entry already exists, it updates the RouteEntry.ResolvedIPs
+// AddEntry adds a new RouteEntry to the State. If the entry already exists, it updates the RouteEntry.ResolvedIPs and
+// RouteEntry.ExpiresAt, so that adding an existing entry again with an expiry extends it
 func (s *State) AddEntry(entry *RouteEntry) error {
 	for _, e := range s.Entries {
 		if e.Domain == entry.Domain {
-			if utils.SlicesEqual(e.ResolvedIPs, entry.ResolvedIPs) {
+			if utils.SlicesEqual(e.ResolvedIPs, entry.ResolvedIPs) && expiriesEqual(e.ExpiresAt, entry.ExpiresAt) {
 				return errors.New(constants.EntryAlreadyExists)
 			}
 
 			e.ResolvedIPs = entry.ResolvedIPs
+			e.ExpiresAt = entry.ExpiresAt
 			return s.Write()
 		}
 	}
@@ -130,6 +210,61 @@ func (s *State) RemoveEntry(domain string) error {
 	return errors.New(constants.EntryNotFound)
 }
 
+// UpdateEntry applies the given update to the RouteEntry with the given domain and writes the State, the entry is left
+// as it is if the write fails. The updated entry is returned
+func (s *State) UpdateEntry(domain string, update func(entry *RouteEntry)) (*RouteEntry, error) {
+	entry := s.GetEntry(domain)
+	if entry == nil {
+		return nil, errors.New(constants.EntryNotFound)
+	}
+
+	updated := *entry
+	updated.Routes = entry.cloneRoutes()
+	update(&updated)
+
+	if err := s.commit(&updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// RemoveExpiredEntries removes the routes of the expired entries from the routing table and the entries from the
+// State, it returns the removed entries
+func (s *State) RemoveExpiredEntries(now time.Time) ([]*RouteEntry, error) {
+	var expired []*RouteEntry
+	remaining := make([]*RouteEntry, 0, len(s.Entries))
+	for _, entry := range s.Entries {
+		if !entry.IsExpired(now) {
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		expired = append(expired, entry)
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	// the expired entries are dropped first, so that the IPs that they share are not seen as routed by each other
+	active := make(map[string]bool, len(expired))
+	for _, entry := range expired {
+		active[entry.Domain] = s.IsEntryActive(entry)
+	}
+
+	s.Entries = remaining
+	for _, entry := range expired {
+		if active[entry.Domain] {
+			s.removeOldRoutes(entry)
+		}
+
+		s.publishEntry(events.EntryRemoved, entry)
+	}
+
+	return expired, s.Write()
+}
+
 // GetEntry returns the RouteEntry for the given domain from the State
 func (s *State) GetEntry(domain string) *RouteEntry {
 	for i := range s.Entries {
@@ -158,16 +293,44 @@ func (s *State) Reload() error {
 		return err
 	}
 
-	err = json.Unmarshal(content, s)
-	return err
+	// unmarshal into a fresh value, decoding into the existing slices would reuse the old RouteEntry pointers
+	// and keep the stale values of the fields that are omitted in the file
+	loaded := new(State)
+	if err := json.Unmarshal(content, loaded); err != nil {
+		return err
+	}
+
+	s.Entries = loaded.Entries
+	s.Groups = loaded.Groups
+	s.Subscriptions = loaded.Subscriptions
+	s.backfillRoutes(time.Now())
+
+	return nil
+}
+
+// WriteError is returned when the State cannot be written to its file, it wraps the cause such as a *fs.PathError
+type WriteError struct {
+	Err error
 }
 
-// Write writes the State to the given path
+func (e *WriteError) Error() string {
+	return constants.FailedToWriteState + ": " + e.Err.Error()
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Write writes the State to the given path, the errors are returned as *WriteError
 func (s *State) Write() error {
 	data, err := json.Marshal(s)
 	if err != nil {
-		return err
+		return &WriteError{Err: err}
 	}
 
-	return os.WriteFile(s.path, data, 0644)
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return &WriteError{Err: err}
+	}
+
+	return nil
 }
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteEntry_IsExpired(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	assert.False(t, NewRouteEntry("example.com", "10.0.0.1", nil).IsExpired(now))
+	assert.True(t, (&RouteEntry{ExpiresAt: &past}).IsExpired(now))
+	assert.True(t, (&RouteEntry{ExpiresAt: &now}).IsExpired(now))
+	assert.False(t, (&RouteEntry{ExpiresAt: &future}).IsExpired(now))
+}
+
+func TestState_AddEntry_ExtendsExpiry(t *testing.T) {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	expiresAt := time.Now().Add(time.Hour)
+
+	entry := NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"})
+	entry.ExpiresAt = &expiresAt
+	assert.NoError(t, st.AddEntry(entry))
+	assert.EqualError(t, st.AddEntry(entry), constants.EntryAlreadyExists)
+
+	// adding the same entry again without an expiry makes it permanent
+	assert.NoError(t, st.AddEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1"})))
+	assert.Nil(t, st.GetEntry("example.com").ExpiresAt)
+}
+
+func TestState_RemoveExpiredEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), constants.StateFileName)
+	st := NewState(logging.GetLogger(), path)
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	// entries are kept in a disabled group, so that no routes are touched while testing
+	assert.NoError(t, st.CreateGroup("downloads"))
+	st.GetGroup("downloads").Enabled = false
+
+	expired := &RouteEntry{Domain: "expired.com", Group: "downloads", ExpiresAt: &past}
+	temporary := &RouteEntry{Domain: "temporary.com", Group: "downloads", ExpiresAt: &future}
+	permanent := &RouteEntry{Domain: "permanent.com", Group: "downloads"}
+	st.Entries = append(st.Entries, expired, temporary, permanent)
+
+	removed, err := st.RemoveExpiredEntries(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []*RouteEntry{expired}, removed)
+	assert.Equal(t, []*RouteEntry{temporary, permanent}, st.Entries)
+
+	// expiry should survive a reload of the state
+	loaded := NewState(logging.GetLogger(), path)
+	assert.NoError(t, loaded.Reload())
+	assert.True(t, loaded.GetEntry("temporary.com").ExpiresAt.Equal(future))
+
+	removed, err = st.RemoveExpiredEntries(now)
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+// SyncStatus is the outcome of the last sync of a Subscription
+type SyncStatus string
+
+const (
+	// SyncStatusOK means the list is fetched and the managed group is synced to it
+	SyncStatusOK SyncStatus = "ok"
+	// SyncStatusNotModified means the server answered that the list has not changed since its last ETag
+	SyncStatusNotModified SyncStatus = "not-modified"
+	// SyncStatusFailed means the list could not be fetched or parsed, the managed group is left as it is
+	SyncStatusFailed SyncStatus = "failed"
+)
+
+// Subscription is the record of an external list whose destinations are kept in a managed Group
+type Subscription struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Group is the managed Group that holds the destinations of the list
+	Group string `json:"group"`
+	// ETag is the entity tag of the last fetched list, it is sent back so that an unchanged list is not downloaded again
+	ETag string `json:"etag,omitempty"`
+	// LastSync is the time of the last attempt, LastSuccess is the last time that the list is fetched or found unchanged
+	LastSync    *time.Time `json:"lastSync,omitempty"`
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	Status      SyncStatus `json:"status,omitempty"`
+	// LastError is the error of the last attempt, empty if it succeeded
+	LastError string `json:"lastError,omitempty"`
+	// Destinations is the number of the valid destinations of the list, Invalid is the number of its invalid lines
+	Destinations int `json:"destinations"`
+	Invalid      int `json:"invalid,omitempty"`
+}
+
+// GetSubscription returns the Subscription with the given name from the State
+func (s *State) GetSubscription(name string) *Subscription {
+	for _, sub := range s.Subscriptions {
+		if sub.Name == name {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+// SetSubscription records the given Subscription in the State, replacing the one with the same name, and writes it
+func (s *State) SetSubscription(sub *Subscription) error {
+	s.setSubscription(sub)
+
+	return s.Write()
+}
+
+// setSubscription replaces the Subscription with the same name as the given one, or appends it
+func (s *State) setSubscription(sub *Subscription) {
+	for i := range s.Subscriptions {
+		if s.Subscriptions[i].Name == sub.Name {
+			s.Subscriptions[i] = sub
+			return
+		}
+	}
+
+	s.Subscriptions = append(s.Subscriptions, sub)
+}
+
+// SyncSubscription records the given Subscription and brings its managed Group to the given destinations, see
+// syncManagedGroup. The ETag of the Subscription is cleared if any destination cannot be added or removed, so that the
+// next sync fetches the whole list and tries them again. The added and the removed destinations are returned
+func (s *State) SyncSubscription(sub *Subscription, destinations []string, gateway string) ([]string, []string, error) {
+	// entries of a previous group are moved, so that renaming the group does not resolve the list again
+	previous := sub.Group
+	if existing := s.GetSubscription(sub.Name); existing != nil {
+		previous = existing.Group
+	}
+
+	added, removed := s.syncManagedGroup(constants.SourceSubscription, sub.Group, previous, destinations, gateway)
+	if !s.isGroupSynced(constants.SourceSubscription, sub.Group, destinations) {
+		sub.ETag = ""
+	}
+
+	s.setSubscription(sub)
+
+	return added, removed, s.Write()
+}
+
+// RemoveSubscription removes the Subscription with the given name, the destinations that came from its list and its
+// managed Group. It returns the removed destinations
+func (s *State) RemoveSubscription(name string) ([]string, error) {
+	sub := s.GetSubscription(name)
+	if sub == nil {
+		return nil, nil
+	}
+
+	// the subscription is kept to be removed again if any of its entries is kept
+	removed, err := s.removeManagedEntries(constants.SourceSubscription, sub.Group)
+	if err != nil {
+		return removed, err
+	}
+
+	s.removeManagedGroup(constants.SourceSubscription, sub.Group)
+
+	subscriptions := make([]*Subscription, 0, len(s.Subscriptions))
+	for _, other := range s.Subscriptions {
+		if other.Name != name {
+			subscriptions = append(subscriptions, other)
+		}
+	}
+
+	s.Subscriptions = subscriptions
+
+	return removed, s.Write()
+}
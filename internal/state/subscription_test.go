@@ -0,0 +1,66 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_SyncSubscription(t *testing.T) {
+	path := filepath.Join(t.TempDir(), constants.StateFileName)
+	st := NewState(logging.GetLogger(), path)
+
+	// every entry is kept in a disabled group, so that no routes are touched while testing
+	st.Groups = append(st.Groups, &Group{Name: "m365", Source: constants.SourceSubscription},
+		&Group{Name: "office", Source: constants.SourceSubscription})
+	cliEntry := &RouteEntry{Domain: "10.1.0.0/16", Group: "office"}
+	st.Entries = append(st.Entries, cliEntry)
+
+	sub := &Subscription{Name: "microsoft", URL: "https://example.com/m365.txt", Group: "m365", Status: SyncStatusOK}
+	added, removed, err := st.SyncSubscription(sub, []string{"10.2.0.0/16", "10.3.0.1", "10.1.0.0/16"}, "192.168.1.1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"10.2.0.0/16", "10.3.0.1"}, added)
+	assert.Empty(t, removed)
+	assert.Equal(t, constants.SourceSubscription, st.GetEntry("10.3.0.1").Source)
+	assert.Equal(t, "m365", st.GetEntry("10.3.0.1").Group)
+	assert.False(t, st.GetGroup("m365").Enabled)
+	// entries that are added over CLI are left alone
+	assert.Equal(t, "office", st.GetEntry("10.1.0.0/16").Group)
+	assert.Empty(t, st.GetEntry("10.1.0.0/16").Source)
+
+	// the entries follow the group of the subscription, the ones that are not listed anymore are removed
+	sub = &Subscription{Name: "microsoft", URL: sub.URL, Group: "office", Status: SyncStatusOK}
+	added, removed, err = st.SyncSubscription(sub, []string{"10.2.0.0/16"}, "192.168.1.1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"10.3.0.1"}, removed)
+	assert.Equal(t, "office", st.GetEntry("10.2.0.0/16").Group)
+	assert.Nil(t, st.GetGroup("m365"))
+
+	// the records survive a restart
+	loaded := NewState(logging.GetLogger(), path)
+	if assert.NoError(t, loaded.Reload()) && assert.Len(t, loaded.Subscriptions, 1) {
+		assert.Equal(t, "office", loaded.GetSubscription("microsoft").Group)
+	}
+
+	removed, err = st.RemoveSubscription("microsoft")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.2.0.0/16"}, removed)
+	assert.Empty(t, st.Subscriptions)
+	assert.Equal(t, []*RouteEntry{cliEntry}, st.Entries)
+	// the group still holds the entry that is added over CLI
+	assert.NotNil(t, st.GetGroup("office"))
+
+	removed, err = st.RemoveSubscription("microsoft")
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
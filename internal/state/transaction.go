@@ -0,0 +1,569 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/pkg/errors"
+)
+
+// RouteMode is how the failures of the route operations of a single RouteEntry are handled
+type RouteMode string
+
+const (
+	// RouteModeTransactional rolls the routes of an entry back if any of them fails and leaves the State unchanged, so
+	// that the State always matches the routing table
+	RouteModeTransactional RouteMode = "transactional"
+	// RouteModeBestEffort keeps the routes that succeed and records only the IPs that are actually routed
+	RouteModeBestEffort RouteMode = "best-effort"
+)
+
+// Valid returns true if the RouteMode is a known one, empty RouteMode means RouteModeTransactional
+func (m RouteMode) Valid() bool {
+	switch m {
+	case "", RouteModeTransactional, RouteModeBestEffort:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	// addRoute and removeRoute change the routing table, they are replaced in tests
+	addRoute    = utils.AddRoute
+	removeRoute = utils.RemoveRoute
+)
+
+// RouteError is returned when the routes of some IPs of a RouteEntry fail
+type RouteError struct {
+	// Domain is the domain of the RouteEntry
+	Domain string
+	// Failed maps the IPs to the errors of their route operations
+	Failed map[string]error
+	// RolledBack is true if the routes that succeeded are rolled back
+	RolledBack bool
+}
+
+func (e *RouteError) Error() string {
+	ips := e.ips()
+	failures := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		failures = append(failures, fmt.Sprintf("%s: %s", ip, e.Failed[ip]))
+	}
+
+	msg := fmt.Sprintf("%s for %s (%s)", constants.FailedToApplyRoutes, e.Domain, strings.Join(failures, ", "))
+	if e.RolledBack {
+		msg += ", rolled back"
+	}
+
+	return msg
+}
+
+// Unwrap returns the errors of the failed IPs in order, so that their causes such as the missing privileges can be
+// checked with errors.Is
+func (e *RouteError) Unwrap() []error {
+	ips := e.ips()
+	errs := make([]error, 0, len(ips))
+	for _, ip := range ips {
+		errs = append(errs, e.Failed[ip])
+	}
+
+	return errs
+}
+
+// RouteMode returns the RouteMode of the route operations
+func (s *State) RouteMode() RouteMode {
+	if s.mode == "" {
+		return RouteModeTransactional
+	}
+
+	return s.mode
+}
+
+// ips returns the failed IPs in order
+func (e *RouteError) ips() []string {
+	ips := make([]string, 0, len(e.Failed))
+	for ip := range e.Failed {
+		ips = append(ips, ip)
+	}
+
+	sort.Strings(ips)
+
+	return ips
+}
+
+// SetRouteMode sets the RouteMode of the route operations, empty RouteMode means RouteModeTransactional
+func (s *State) SetRouteMode(mode RouteMode) {
+	if mode == "" {
+		mode = RouteModeTransactional
+	}
+
+	s.mode = mode
+}
+
+// InstallEntry installs the routes of the given RouteEntry and commits it to the State. If the entry already exists,
+// the routes of its new IPs are installed first and the routes of its stale IPs are removed after that. In
+// RouteModeTransactional, the entry is committed only if every route succeeds, otherwise the routes are rolled back and
+// the State is unchanged. In RouteModeBestEffort, the entry is committed with the IPs that are actually routed. A
+// *RouteError is returned if any of the routes fails
+func (s *State) InstallEntry(entry *RouteEntry) error {
+	existing := s.GetEntry(entry.Domain)
+	if existing != nil && utils.SlicesEqual(existing.ResolvedIPs, entry.ResolvedIPs) &&
+		expiriesEqual(existing.ExpiresAt, entry.ExpiresAt) && existing.Accumulate == entry.Accumulate {
+		return errors.New(constants.EntryAlreadyExists)
+	}
+
+	target := entry
+	var oldIPs []string
+	if existing != nil {
+		target = existing
+		oldIPs = existing.ResolvedIPs
+	}
+
+	now := time.Now()
+	committed := *target
+	committed.Routes = target.cloneRoutes()
+	committed.ExpiresAt = entry.ExpiresAt
+	committed.Accumulate = entry.Accumulate
+	committed.ResolvedIPs = entry.ResolvedIPs
+
+	// IPs of the inactive entries are resolved but not routed, so they are pending
+	active := s.IsEntryActive(target)
+	committed.markSeen(entry.ResolvedIPs, now)
+	if existing != nil {
+		// DNS servers of the new resolution are recorded on the routes of the given entry
+		for _, route := range entry.Routes {
+			if len(route.Resolvers) > 0 {
+				committed.routeOf(route.IP, now).Resolvers = route.Resolvers
+			}
+		}
+	}
+
+	var routeErr *RouteError
+	if active {
+		// the IPs of an existing entry that are seen recently are kept, just like on a refresh
+		ips := entry.ResolvedIPs
+		if existing != nil {
+			ips = s.accumulatedIPs(&committed, entry.ResolvedIPs, now)
+		}
+
+		committed.ResolvedIPs, routeErr = s.applyRoutes(target.Domain, target.Gateway, oldIPs, ips)
+		if routeErr != nil && routeErr.RolledBack {
+			return routeErr
+		}
+
+		committed.recordRoutes(entry.ResolvedIPs, committed.ResolvedIPs, routeErr, now)
+	}
+
+	if err := s.commit(&committed); err != nil {
+		if active {
+			// the routing table is restored to the State that is kept in memory
+			_, _ = s.applyRoutes(target.Domain, target.Gateway, committed.ResolvedIPs, oldIPs)
+		}
+
+		return err
+	}
+
+	if existing == nil {
+		s.publishEntry(events.EntryAdded, &committed)
+	} else {
+		s.publishIPChanges(&committed, oldIPs)
+	}
+
+	if routeErr != nil {
+		return routeErr
+	}
+
+	return nil
+}
+
+// installPending resolves the domain of the given new RouteEntry and adds it with InstallEntry, the IPs of the given
+// entry are ignored. An entry whose domain cannot be resolved or whose routes are rolled back is added pending without
+// any IP, so that CheckIPChanges resolves it and installs its routes on every refresh. It reports whether the entry is
+// added, the error of the resolution or of the routes is returned either way
+func (s *State) installPending(entry *RouteEntry) (bool, error) {
+	pending := *entry
+	pending.ResolvedIPs = []string{}
+
+	res, resolveErr := utils.Resolve(entry.Domain)
+	if resolveErr == nil {
+		resolved := NewResolvedEntry(entry.Domain, entry.Gateway, res)
+		resolved.Group, resolved.Source, resolved.Accumulate = entry.Group, entry.Source, entry.Accumulate
+		entry = resolved
+	} else {
+		entry = &pending
+	}
+
+	err := s.InstallEntry(entry)
+	var routeErr *RouteError
+	if errors.As(err, &routeErr) && routeErr.RolledBack {
+		if pendingErr := s.InstallEntry(&pending); pendingErr != nil {
+			return false, pendingErr
+		}
+
+		return true, err
+	}
+
+	if err != nil && !errors.As(err, &routeErr) {
+		return false, err
+	}
+
+	if resolveErr != nil {
+		return true, resolveErr
+	}
+
+	return true, err
+}
+
+// UninstallEntry removes the routes of the RouteEntry with the given domain and removes it from the State. In
+// RouteModeTransactional, the entry is removed only if every route is removed, otherwise the removed routes are
+// installed again and the State is unchanged. In RouteModeBestEffort, the entry is kept with the IPs that are still
+// routed if any of the routes fails. The removed entry is returned
+func (s *State) UninstallEntry(domain string) (*RouteEntry, error) {
+	entry := s.GetEntry(domain)
+	if entry == nil {
+		return nil, errors.New(constants.EntryNotFound)
+	}
+
+	if s.IsEntryActive(entry) {
+		remaining, routeErr := s.applyRoutes(entry.Domain, entry.Gateway, entry.ResolvedIPs, nil)
+		if routeErr != nil {
+			if !routeErr.RolledBack {
+				updated := *entry
+				updated.Routes = entry.cloneRoutes()
+				updated.ResolvedIPs = remaining
+				updated.recordRoutes(nil, remaining, routeErr, time.Now())
+				if err := s.commit(&updated); err != nil {
+					return nil, err
+				}
+
+				s.publishIPChanges(&updated, entry.ResolvedIPs)
+			}
+
+			return nil, routeErr
+		}
+	}
+
+	if err := s.drop(domain); err != nil {
+		if s.IsEntryActive(entry) {
+			_, _ = s.applyRoutes(entry.Domain, entry.Gateway, nil, entry.ResolvedIPs)
+		}
+
+		return nil, err
+	}
+
+	s.publishEntry(events.EntryRemoved, entry)
+
+	return entry, nil
+}
+
+// RestoreRoutes installs the routes of the routed IPs of the active entries again, such as after a reboot that cleared
+// the routing table, gateway replaces the gateway of the entries unless it is empty. Routes that are still in the
+// routing table are kept. The IPs whose routes fail are recorded as failed and dropped from the routed ones, so that
+// CheckIPChanges installs them again on the next refresh. The State is written if any entry changes
+func (s *State) RestoreRoutes(gateway string) error {
+	now := time.Now()
+	var changed bool
+	for _, entry := range s.Entries {
+		if !s.IsEntryActive(entry) {
+			continue
+		}
+
+		if gateway != "" && entry.Gateway != gateway {
+			entry.Gateway = gateway
+			changed = true
+		}
+
+		// the other entries are not asked for the shared IPs, since their routes are not restored yet either
+		routeErr := &RouteError{Domain: entry.Domain, Failed: make(map[string]error)}
+		routed := make([]string, 0, len(entry.ResolvedIPs))
+		for _, ip := range entry.ResolvedIPs {
+			if err := addRoute(ip, entry.Gateway); err != nil && !errors.Is(err, utils.ErrRouteExists) {
+				routeErr.Failed[ip] = err
+				continue
+			}
+
+			routed = append(routed, ip)
+		}
+
+		if len(routeErr.Failed) == 0 {
+			continue
+		}
+
+		s.publishRouteFailure(entry.Gateway, routeErr)
+		s.logger.Warn().Str("domain", entry.Domain).Err(routeErr).Msg(constants.AppliedRoutesPartially)
+
+		for ip, err := range routeErr.Failed {
+			// the routes are not in the routing table anymore, so they are failed rather than installed
+			entry.routeOf(ip, now).Status = IPStatusPending
+			entry.markFailed(ip, err, now)
+		}
+
+		entry.ResolvedIPs = routed
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return s.Write()
+}
+
+// activateEntry installs the routes of the given IPs of a RouteEntry that becomes active, such as the entries of a
+// Group that is enabled. The entry is updated in place with the IPs that are routed afterward, the State is written by
+// the caller. In RouteModeTransactional, the entry keeps the IPs unrouted if any of the routes fails
+func (s *State) activateEntry(entry *RouteEntry, ips []string, now time.Time) *RouteError {
+	routed, routeErr := s.applyRoutes(entry.Domain, entry.Gateway, nil, ips)
+	entry.recordRoutes(ips, routed, routeErr, now)
+	entry.ResolvedIPs = ips
+	if routeErr == nil || !routeErr.RolledBack {
+		entry.ResolvedIPs = routed
+	}
+
+	return routeErr
+}
+
+// deactivateEntry removes the routes of a RouteEntry that becomes inactive, such as the entries of a Group that is
+// disabled. The entry keeps its IPs so that its routes can be installed again, the State is written by the caller. In
+// RouteModeTransactional, the removed routes are installed again if any of them fails
+func (s *State) deactivateEntry(entry *RouteEntry, now time.Time) *RouteError {
+	remaining, routeErr := s.applyRoutes(entry.Domain, entry.Gateway, entry.ResolvedIPs, nil)
+	entry.recordRoutes(nil, remaining, routeErr, now)
+
+	return routeErr
+}
+
+// writeRoutes writes the State after the routes of some entries are changed in place and returns the given
+// *RouteError of the entries whose routes failed, if any
+func (s *State) writeRoutes(routeErr *RouteError) error {
+	if err := s.Write(); err != nil {
+		return err
+	}
+
+	if routeErr != nil {
+		return routeErr
+	}
+
+	return nil
+}
+
+// refreshEntry moves the routes of the given active RouteEntry to the target IPs, the routes of the new IPs are
+// installed before the routes of the stale IPs are removed. resolved are the IPs that are resolved for the entry, the
+// others in target are kept for the grace period. The entry is updated in place, the State is written by the caller
+func (s *State) refreshEntry(entry *RouteEntry, resolved, target []string, now time.Time) error {
+	activeIPs, routeErr := s.applyRoutes(entry.Domain, entry.Gateway, entry.ResolvedIPs, target)
+
+	// failures are recorded even if they are rolled back, so that they can be inspected with list
+	entry.recordRoutes(resolved, activeIPs, routeErr, now)
+	if routeErr != nil && routeErr.RolledBack {
+		return routeErr
+	}
+
+	oldIPs := entry.ResolvedIPs
+	entry.ResolvedIPs = activeIPs
+	s.publishIPChanges(entry, oldIPs)
+	if routeErr != nil {
+		return routeErr
+	}
+
+	return nil
+}
+
+// commit replaces the RouteEntry of the same domain in the State with the given one, or appends it if it does not
+// exist, and writes the State. The in-memory State is restored if the write fails
+func (s *State) commit(entry *RouteEntry) error {
+	previous := s.Entries
+	entries := make([]*RouteEntry, 0, len(s.Entries)+1)
+	var replaced bool
+	for _, e := range s.Entries {
+		if e.Domain == entry.Domain {
+			entries = append(entries, entry)
+			replaced = true
+
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	if !replaced {
+		entries = append(entries, entry)
+	}
+
+	s.Entries = entries
+	if err := s.Write(); err != nil {
+		s.Entries = previous
+		return err
+	}
+
+	return nil
+}
+
+// drop removes the RouteEntry of the given domain from the State and writes the State. The in-memory State is restored
+// if the write fails
+func (s *State) drop(domain string) error {
+	previous := s.Entries
+	entries := make([]*RouteEntry, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		if e.Domain != domain {
+			entries = append(entries, e)
+		}
+	}
+
+	s.Entries = entries
+	if err := s.Write(); err != nil {
+		s.Entries = previous
+		return err
+	}
+
+	return nil
+}
+
+// applyRoutes moves the routes of a domain from the old IPs to the new IPs, the routes of the new IPs are installed
+// first and the routes of the stale IPs are removed after that. It returns the IPs that are routed afterward. In
+// RouteModeTransactional, every change is rolled back on a failure, so the old IPs are returned
+func (s *State) applyRoutes(domain, gateway string, oldIPs, newIPs []string) ([]string, *RouteError) {
+	toAdd, toRemove := difference(newIPs, oldIPs), difference(oldIPs, newIPs)
+	routeErr := &RouteError{Domain: domain, Failed: make(map[string]error)}
+
+	// installed and deleted are the routes that are changed in the routing table, only they are rolled back
+	var added, removed, installed, deleted []string
+	for _, ip := range toAdd {
+		changed, err := s.installRoute(domain, ip, gateway)
+		if err != nil {
+			routeErr.Failed[ip] = err
+			continue
+		}
+
+		added = append(added, ip)
+		if changed {
+			installed = append(installed, ip)
+		}
+	}
+
+	if len(routeErr.Failed) == 0 || s.mode == RouteModeBestEffort {
+		for _, ip := range toRemove {
+			changed, err := s.uninstallRoute(domain, ip)
+			if err != nil {
+				routeErr.Failed[ip] = err
+				continue
+			}
+
+			removed = append(removed, ip)
+			if changed {
+				deleted = append(deleted, ip)
+			}
+		}
+	}
+
+	if len(routeErr.Failed) == 0 {
+		return union(difference(oldIPs, removed), added), nil
+	}
+
+	s.publishRouteFailure(gateway, routeErr)
+
+	if s.mode == RouteModeBestEffort {
+		s.logger.Warn().Str("domain", domain).Err(routeErr).Msg(constants.AppliedRoutesPartially)
+		return union(difference(oldIPs, removed), added), routeErr
+	}
+
+	for _, ip := range installed {
+		if err := removeRoute(ip); err != nil {
+			s.logger.Error().Str("domain", domain).Str("ip", ip).Err(err).Msg(constants.FailedToRollbackRoute)
+		}
+	}
+
+	for _, ip := range deleted {
+		if err := addRoute(ip, gateway); err != nil {
+			s.logger.Error().Str("domain", domain).Str("ip", ip).Err(err).Msg(constants.FailedToRollbackRoute)
+		}
+	}
+
+	routeErr.RolledBack = true
+
+	return oldIPs, routeErr
+}
+
+// installRoute installs the route of the given IP of a domain. The routing table is left as it is if another active
+// entry routes the IP already or if it has the route for another reason. It returns true if the route is installed
+// by the call, so that a rollback does not remove the routes that the others need
+func (s *State) installRoute(domain, ip, gateway string) (bool, error) {
+	if s.isRoutedByOthers(domain, ip) {
+		return false, nil
+	}
+
+	if err := addRoute(ip, gateway); err != nil {
+		if errors.Is(err, utils.ErrRouteExists) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// uninstallRoute removes the route of the given IP of a domain, unless another active entry still routes the IP. A
+// route that is not in the routing table anymore, such as after a reboot, counts as removed. It returns true if the
+// route is removed by the call
+func (s *State) uninstallRoute(domain, ip string) (bool, error) {
+	if s.isRoutedByOthers(domain, ip) {
+		return false, nil
+	}
+
+	if err := removeRoute(ip); err != nil {
+		if errors.Is(err, utils.ErrRouteNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isRoutedByOthers returns true if an active RouteEntry of another domain routes the given IP, the IPs of the
+// destinations behind the same CDN are shared by their entries
+func (s *State) isRoutedByOthers(domain, ip string) bool {
+	for _, entry := range s.Entries {
+		if entry.Domain == domain || !s.IsEntryActive(entry) {
+			continue
+		}
+
+		for _, routed := range entry.ResolvedIPs {
+			if routed == ip {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// difference returns the elements of a that are not in b, in the order of a
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, v := range b {
+		exclude[v] = true
+	}
+
+	var result []string
+	for _, v := range a {
+		if !exclude[v] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// union returns the elements of a followed by the elements of b that are not in a
+func union(a, b []string) []string {
+	return append(append([]string{}, a...), difference(b, a)...)
+}
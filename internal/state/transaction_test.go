@@ -0,0 +1,250 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRoutes replaces the routing table with an in-memory one, routes of the IPs in failing cannot be changed. Like
+// the kernel, it refuses to add the routes that exist and to remove the ones that do not
+func fakeRoutes(t *testing.T, failing ...string) map[string]bool {
+	routes := make(map[string]bool)
+	fails := make(map[string]bool)
+	for _, ip := range failing {
+		fails[ip] = true
+	}
+
+	addRoute = func(ip, gateway string) error {
+		if fails[ip] {
+			return errors.New("failed to add route")
+		}
+
+		if routes[ip] {
+			return errors.Wrap(utils.ErrRouteExists, "failed to add route")
+		}
+
+		routes[ip] = true
+
+		return nil
+	}
+
+	removeRoute = func(ip string) error {
+		if fails[ip] {
+			return errors.New("failed to remove route")
+		}
+
+		if !routes[ip] {
+			return errors.Wrap(utils.ErrRouteNotFound, "failed to remove route")
+		}
+
+		delete(routes, ip)
+
+		return nil
+	}
+
+	t.Cleanup(func() {
+		addRoute, removeRoute = utils.AddRoute, utils.RemoveRoute
+	})
+
+	return routes
+}
+
+func newTestState(t *testing.T, mode RouteMode) *State {
+	st := NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	st.SetRouteMode(mode)
+
+	return st
+}
+
+func TestState_InstallEntry_Transactional(t *testing.T) {
+	routes := fakeRoutes(t, "3.3.3.3")
+	st := newTestState(t, RouteModeTransactional)
+
+	err := st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}))
+
+	var routeErr *RouteError
+	assert.ErrorAs(t, err, &routeErr)
+	assert.True(t, routeErr.RolledBack)
+	assert.Contains(t, routeErr.Failed, "3.3.3.3")
+	assert.Empty(t, routes)
+	assert.Nil(t, st.GetEntry("example.com"))
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true}, routes)
+	assert.EqualError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"2.2.2.2", "1.1.1.1"})), constants.EntryAlreadyExists)
+
+	// a failing new IP of an existing entry keeps the routes and the IPs of the entry as they are
+	err = st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "3.3.3.3"}))
+	assert.ErrorAs(t, err, &routeErr)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true}, routes)
+	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, st.GetEntry("example.com").ResolvedIPs)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "4.4.4.4"})))
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "4.4.4.4": true}, routes)
+}
+
+func TestState_InstallEntry_BestEffort(t *testing.T) {
+	routes := fakeRoutes(t, "3.3.3.3")
+	st := newTestState(t, RouteModeBestEffort)
+
+	err := st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}))
+
+	var routeErr *RouteError
+	assert.ErrorAs(t, err, &routeErr)
+	assert.False(t, routeErr.RolledBack)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true}, routes)
+	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, st.GetEntry("example.com").ResolvedIPs)
+}
+
+func TestState_UninstallEntry(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+
+	// the removed route is installed again if the other one cannot be removed
+	removeRoute = func(ip string) error {
+		if ip == "2.2.2.2" {
+			return errors.New("failed to remove route")
+		}
+
+		delete(routes, ip)
+
+		return nil
+	}
+
+	_, err := st.UninstallEntry("example.com")
+	var routeErr *RouteError
+	assert.ErrorAs(t, err, &routeErr)
+	assert.True(t, routeErr.RolledBack)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true}, routes)
+	assert.NotNil(t, st.GetEntry("example.com"))
+
+	st.SetRouteMode(RouteModeBestEffort)
+	_, err = st.UninstallEntry("example.com")
+	assert.ErrorAs(t, err, &routeErr)
+	assert.Equal(t, []string{"2.2.2.2"}, st.GetEntry("example.com").ResolvedIPs)
+
+	st.SetRouteMode(RouteModeTransactional)
+	removeRoute = func(ip string) error {
+		delete(routes, ip)
+		return nil
+	}
+
+	entry, err := st.UninstallEntry("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", entry.Domain)
+	assert.Empty(t, routes)
+	assert.Nil(t, st.GetEntry("example.com"))
+
+	_, err = st.UninstallEntry("example.com")
+	assert.EqualError(t, err, constants.EntryNotFound)
+}
+
+func TestState_RefreshEntry(t *testing.T) {
+	routes := fakeRoutes(t, "9.9.9.9")
+	st := newTestState(t, RouteModeTransactional)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+	entry := st.GetEntry("example.com")
+
+	assert.Error(t, st.refreshEntry(entry, []string{"1.1.1.1", "9.9.9.9"}, []string{"1.1.1.1", "9.9.9.9"}, time.Now()))
+	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, entry.ResolvedIPs)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true}, routes)
+
+	assert.NoError(t, st.refreshEntry(entry, []string{"1.1.1.1", "3.3.3.3"}, []string{"1.1.1.1", "3.3.3.3"}, time.Now()))
+	assert.Equal(t, []string{"1.1.1.1", "3.3.3.3"}, entry.ResolvedIPs)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "3.3.3.3": true}, routes)
+}
+
+func TestState_InstallEntry_ExistingRoutes(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+
+	// a route that is in the routing table already, such as one that is left behind, does not fail the entry
+	routes["1.1.1.1"] = true
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, st.GetEntry("example.com").ResolvedIPs)
+
+	// routes that are gone, such as after a reboot, do not fail the removal
+	delete(routes, "1.1.1.1")
+	delete(routes, "2.2.2.2")
+	_, err := st.UninstallEntry("example.com")
+	assert.NoError(t, err)
+	assert.Nil(t, st.GetEntry("example.com"))
+
+	// a rolled back entry does not remove the routes that it did not install
+	failing := fakeRoutes(t, "3.3.3.3")
+	failing["1.1.1.1"] = true
+	err = st.InstallEntry(NewRouteEntry("example.com", "10.0.0.1", []string{"1.1.1.1", "3.3.3.3"}))
+	var routeErr *RouteError
+	assert.ErrorAs(t, err, &routeErr)
+	assert.True(t, routeErr.RolledBack)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true}, failing)
+}
+
+func TestState_SharedRoutes(t *testing.T) {
+	routes := fakeRoutes(t)
+	st := newTestState(t, RouteModeTransactional)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("a.example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("b.example.com", "10.0.0.1", []string{"1.1.1.1", "3.3.3.3"})))
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "2.2.2.2": true, "3.3.3.3": true}, routes)
+
+	// the route of the shared IP is kept while another entry needs it
+	_, err := st.UninstallEntry("a.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"1.1.1.1": true, "3.3.3.3": true}, routes)
+
+	_, err = st.UninstallEntry("b.example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, routes)
+
+	// disabling a group removes the IPs that its entries share
+	assert.NoError(t, st.CreateGroup("cdn"))
+	for _, domain := range []string{"a.example.com", "b.example.com"} {
+		entry := NewRouteEntry(domain, "10.0.0.1", []string{"1.1.1.1"})
+		entry.Group = "cdn"
+		assert.NoError(t, st.InstallEntry(entry))
+	}
+
+	assert.NoError(t, st.DisableGroup("cdn"))
+	assert.Empty(t, routes)
+}
+
+func TestState_RestoreRoutes(t *testing.T) {
+	st := newTestState(t, RouteModeTransactional)
+	routes := fakeRoutes(t)
+
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("a.example.com", "10.0.0.1", []string{"1.1.1.1", "2.2.2.2"})))
+	assert.NoError(t, st.InstallEntry(NewRouteEntry("b.example.com", "10.0.0.1", []string{"1.1.1.1"})))
+	assert.NoError(t, st.CreateGroup("office"))
+	st.GetGroup("office").Enabled = false
+	grouped := NewRouteEntry("c.example.com", "10.0.0.1", []string{"3.3.3.3"})
+	grouped.Group = "office"
+	st.Entries = append(st.Entries, grouped)
+
+	// a reboot clears the routing table
+	routes = fakeRoutes(t, "2.2.2.2")
+	assert.NoError(t, st.RestoreRoutes("192.168.1.1"))
+
+	// the shared IP is restored, the inactive entries are left alone and the failed IPs are not routed anymore
+	assert.Equal(t, map[string]bool{"1.1.1.1": true}, routes)
+	a := st.GetEntry("a.example.com")
+	assert.Equal(t, []string{"1.1.1.1"}, a.ResolvedIPs)
+	assert.Equal(t, "192.168.1.1", a.Gateway)
+	assert.Equal(t, IPStatusFailed, a.Route("2.2.2.2").Status)
+	assert.Equal(t, IPStatusInstalled, a.Route("1.1.1.1").Status)
+	assert.Equal(t, "10.0.0.1", st.GetEntry("c.example.com").Gateway)
+
+	// routes that are still in the routing table are kept
+	assert.NoError(t, st.RestoreRoutes(""))
+	assert.Equal(t, map[string]bool{"1.1.1.1": true}, routes)
+}
@@ -1,6 +1,9 @@
 package state
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // FromStringSlice deserializes the input string into a slice of DomainInfo structs.
 func FromStringSlice(input string) ([]*RouteEntry, error) {
@@ -21,3 +24,12 @@ func ToStringSlice(domainInfos []*RouteEntry) (string, error) {
 
 	return string(bytes), nil
 }
+
+// expiriesEqual checks if the given expiry times are both permanent or point to the same time
+func expiriesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
@@ -0,0 +1,405 @@
+// Package subscription keeps the destinations of the external lists, such as the endpoints of a SaaS that a team
+// maintains, in the managed groups of the state.State. The lists are fetched from local files or HTTP(S) URLs on a
+// schedule, the unchanged ones are not downloaded again thanks to their ETags
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/bypasslist"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+)
+
+// maxListSize is the maximum size of a fetched list, larger lists are rejected rather than truncated
+const maxListSize = 10 << 20
+
+// defaultGateway returns the gateway of the added destinations, it is replaced in the tests
+var defaultGateway = utils.GetDefaultNonVPNGateway
+
+// Config is a subscribed list
+type Config struct {
+	Name string
+	// URL is an HTTP(S) URL, a file:// URL or the absolute path of a local file
+	URL string
+	// Format is the format of the list, it is detected from the URL and the content if it is empty
+	Format bypasslist.Format
+	// Group is the managed group of the destinations of the list
+	Group string
+	// Interval is the duration between two syncs of the list
+	Interval time.Duration
+}
+
+// fetched is a list that is read from the URL of a Config
+type fetched struct {
+	content []byte
+	etag    string
+	// notModified is set if the server answers that the list has not changed since the given ETag
+	notModified bool
+}
+
+// Manager syncs the subscribed lists into their managed groups on their intervals
+type Manager struct {
+	st     *state.State
+	logger zerolog.Logger
+	client *http.Client
+	// mu guards configs and next, the syncs themselves take the lock of the state.State
+	mu      sync.Mutex
+	configs map[string]*Config
+	// next is the time of the next sync of every list
+	next map[string]time.Time
+	// wake interrupts the wait of Run when the lists are changed
+	wake chan struct{}
+}
+
+// NewManager returns a Manager without any list, the lists are given with SetSubscriptions
+func NewManager(st *state.State, logger zerolog.Logger) *Manager {
+	return &Manager{
+		st:      st,
+		logger:  logger,
+		client:  &http.Client{Timeout: constants.SubscriptionFetchTimeout},
+		configs: make(map[string]*Config),
+		next:    make(map[string]time.Time),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// SetSubscriptions replaces the subscribed lists. New and changed lists are synced by Run right away, unless they are
+// synced within their interval before a restart. Lists that are not subscribed anymore are removed from the
+// state.State together with their destinations and managed groups
+func (m *Manager) SetSubscriptions(configs []*Config) {
+	m.mu.Lock()
+
+	subscribed := make(map[string]*Config, len(configs))
+	for _, cfg := range configs {
+		// unchanged lists keep their schedules
+		if previous, ok := m.configs[cfg.Name]; ok && *previous == *cfg {
+			subscribed[cfg.Name] = previous
+			continue
+		}
+
+		subscribed[cfg.Name] = cfg
+		m.next[cfg.Name] = m.restoredNext(cfg)
+	}
+
+	for name := range m.next {
+		if subscribed[name] == nil {
+			delete(m.next, name)
+		}
+	}
+
+	m.configs = subscribed
+	m.mu.Unlock()
+
+	m.st.Lock()
+	var names []string
+	for _, sub := range m.st.Subscriptions {
+		if subscribed[sub.Name] == nil {
+			names = append(names, sub.Name)
+		}
+	}
+
+	for _, name := range names {
+		removed, err := m.st.RemoveSubscription(name)
+		if err != nil {
+			m.logger.Error().Err(err).Str("subscription", name).Msg(constants.FailedToRemoveSubscription)
+			continue
+		}
+
+		m.logger.Info().Str("subscription", name).Strs("removed", removed).Msg(constants.RemovedSubscription)
+	}
+	m.st.Unlock()
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// restoredNext returns the time of the next sync of the given list from its record in the state.State, so that the
+// lists are not fetched again on every restart. Lists whose URL or group is changed are synced right away
+func (m *Manager) restoredNext(cfg *Config) time.Time {
+	m.st.Lock()
+	defer m.st.Unlock()
+
+	sub := m.st.GetSubscription(cfg.Name)
+	if sub == nil || sub.URL != cfg.URL || sub.Group != cfg.Group || sub.LastSync == nil || sub.Status == state.SyncStatusFailed {
+		return time.Time{}
+	}
+
+	return sub.LastSync.Add(cfg.Interval)
+}
+
+// Run syncs the lists when they are due until the given context is done
+func (m *Manager) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-m.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		for _, cfg := range m.due(time.Now()) {
+			// failures are logged and recorded by Sync, the list is tried again on its next interval
+			_ = m.Sync(ctx, cfg)
+
+			m.mu.Lock()
+			if _, ok := m.next[cfg.Name]; ok && m.configs[cfg.Name] == cfg {
+				m.next[cfg.Name] = time.Now().Add(cfg.Interval)
+			}
+			m.mu.Unlock()
+		}
+
+		timer.Reset(m.wait(time.Now()))
+	}
+}
+
+// due returns the lists whose syncs are due at the given time
+func (m *Manager) due(now time.Time) []*Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*Config
+	for name, next := range m.next {
+		if !next.After(now) {
+			due = append(due, m.configs[name])
+		}
+	}
+
+	return due
+}
+
+// wait returns the duration until the next sync of any list, an hour if there is no list
+func (m *Manager) wait(now time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wait := time.Hour
+	for _, next := range m.next {
+		if d := next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// Sync fetches the given list and syncs its managed group, the outcome is recorded in the state.State. The list is
+// not downloaded again if the server answers that it has not changed since the last sync
+func (m *Manager) Sync(ctx context.Context, cfg *Config) error {
+	logger := m.logger.With().Str("subscription", cfg.Name).Str("url", cfg.URL).Logger()
+
+	now := time.Now()
+	sub := &state.Subscription{Name: cfg.Name, URL: cfg.URL, Group: cfg.Group, LastSync: &now}
+
+	// group of the destinations that are synced last, it is recorded until the destinations are moved to the new one
+	group := cfg.Group
+
+	m.st.Lock()
+	if previous := m.st.GetSubscription(cfg.Name); previous != nil {
+		group = previous.Group
+
+		// the ETag is not sent if the group is changed, the destinations are moved to the new group on a full sync
+		if previous.URL == cfg.URL {
+			sub.LastSuccess, sub.Destinations, sub.Invalid = previous.LastSuccess, previous.Destinations, previous.Invalid
+			if previous.Group == cfg.Group && previous.Status != state.SyncStatusFailed {
+				sub.ETag = previous.ETag
+			}
+		}
+	}
+	m.st.Unlock()
+
+	list, etag, err := m.fetchList(ctx, cfg, sub.ETag)
+
+	var gw string
+	if err == nil && list != nil {
+		gw, err = defaultGateway()
+	}
+
+	m.st.Lock()
+	defer m.st.Unlock()
+
+	if err != nil {
+		sub.Group, sub.Status, sub.LastError = group, state.SyncStatusFailed, err.Error()
+		if recordErr := m.st.SetSubscription(sub); recordErr != nil {
+			logger.Error().Err(recordErr).Msg(constants.FailedToRecordSubscription)
+		}
+
+		logger.Error().Err(err).Msg(constants.FailedToSyncSubscription)
+
+		return err
+	}
+
+	sub.LastSuccess = &now
+	if list == nil {
+		sub.Status = state.SyncStatusNotModified
+		logger.Info().Msg(constants.SubscriptionNotModified)
+
+		return m.st.SetSubscription(sub)
+	}
+
+	destinations := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		destinations = append(destinations, item.Destination)
+	}
+
+	for _, problem := range list.Invalid {
+		logger.Warn().Int("line", problem.Line).Str("text", problem.Text).Str("reason", problem.Reason).
+			Msg(constants.SkippedInvalidListLine)
+	}
+
+	sub.Status, sub.ETag = state.SyncStatusOK, etag
+	sub.Destinations, sub.Invalid = len(destinations), len(list.Invalid)
+
+	added, removed, err := m.st.SyncSubscription(sub, destinations, gw)
+	if err != nil {
+		logger.Error().Err(err).Msg(constants.FailedToSyncSubscription)
+		return err
+	}
+
+	logger.Info().Int("destinations", len(destinations)).Strs("added", added).Strs("removed", removed).
+		Msg(constants.SyncedSubscription)
+
+	return nil
+}
+
+// fetchList fetches and parses the given list, it returns nil without an error if the list is not modified since the
+// given ETag. The ETag of the fetched list is returned
+func (m *Manager) fetchList(ctx context.Context, cfg *Config, etag string) (*bypasslist.List, string, error) {
+	f, err := m.fetch(ctx, cfg.URL, etag)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if f.notModified {
+		return nil, etag, nil
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = bypasslist.Detect(listName(cfg.URL), f.content)
+	}
+
+	list, err := bypasslist.Parse(format, f.content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// an error page that is served with 200 would otherwise empty the group
+	if len(list.Items) == 0 && len(list.Invalid) > 0 {
+		return nil, "", errors.Errorf("list has no valid destination, %d lines are invalid", len(list.Invalid))
+	}
+
+	return list, f.etag, nil
+}
+
+// fetch reads the list at the given URL, the ETag is sent with If-None-Match to the HTTP(S) URLs
+func (m *Manager) fetch(ctx context.Context, rawURL, etag string) (*fetched, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	if u.Scheme == "" || u.Scheme == "file" {
+		content, err := readFile(u.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &fetched{content: content}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid request")
+	}
+
+	req.Header.Set("User-Agent", "split-the-tunnel/"+version.Get().GitVersion)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch list")
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return &fetched{notModified: true}, nil
+	default:
+		return nil, fmt.Errorf("failed to fetch list: unexpected status %s", resp.Status)
+	}
+
+	content, err := readAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetched{content: content, etag: resp.Header.Get("ETag")}, nil
+}
+
+// readFile reads the local list at the given path
+func readFile(name string) ([]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read list")
+	}
+
+	defer file.Close()
+
+	return readAll(file)
+}
+
+// readAll reads the given list up to maxListSize
+func readAll(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxListSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read list")
+	}
+
+	if len(content) > maxListSize {
+		return nil, errors.Errorf("list is larger than %d bytes", maxListSize)
+	}
+
+	return content, nil
+}
+
+// listName returns the file name of the given URL, which tells the format of the list by its extension
+func listName(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return path.Base(u.Path)
+	}
+
+	return ""
+}
@@ -0,0 +1,201 @@
+package subscription
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil/dnstest"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// listServer is an HTTP stub of a list that honors If-None-Match
+type listServer struct {
+	mu      sync.Mutex
+	content string
+	etag    string
+	status  int
+	// etags are the If-None-Match headers of the requests
+	etags []string
+}
+
+func (l *listServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.etags = append(l.etags, r.Header.Get("If-None-Match"))
+	switch {
+	case l.status != 0:
+		w.WriteHeader(l.status)
+	case l.etag != "" && r.Header.Get("If-None-Match") == l.etag:
+		w.WriteHeader(http.StatusNotModified)
+	default:
+		w.Header().Set("ETag", l.etag)
+		_, _ = w.Write([]byte(l.content))
+	}
+}
+
+// set replaces the list that is served
+func (l *listServer) set(content, etag string, status int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.content, l.etag, l.status = content, etag, status
+}
+
+// newTestManager returns a Manager of an empty state.State whose given groups are disabled, so that no routes are
+// touched while testing
+func newTestManager(t *testing.T, groups ...string) (*Manager, *state.State) {
+	st := state.NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	for _, group := range groups {
+		st.Groups = append(st.Groups, &state.Group{Name: group, Source: constants.SourceSubscription})
+	}
+
+	defaultGateway = func() (string, error) {
+		return "192.168.1.1", nil
+	}
+
+	t.Cleanup(func() {
+		defaultGateway = utils.GetDefaultNonVPNGateway
+	})
+
+	return NewManager(st, logging.GetLogger()), st
+}
+
+func TestManager_Sync_HTTP(t *testing.T) {
+	m, st := newTestManager(t, "m365")
+	server := &listServer{content: "# endpoints\n10.1.0.0/16\n10.2.0.1\nnot a destination\n", etag: `"v1"`}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cfg := &Config{Name: "microsoft", URL: ts.URL + "/endpoints.txt", Group: "m365", Interval: time.Hour}
+	if !assert.NoError(t, m.Sync(context.Background(), cfg)) {
+		return
+	}
+
+	sub := st.GetSubscription("microsoft")
+	assert.Equal(t, state.SyncStatusOK, sub.Status)
+	assert.Equal(t, `"v1"`, sub.ETag)
+	assert.Equal(t, 2, sub.Destinations)
+	assert.Equal(t, 1, sub.Invalid)
+	assert.Equal(t, "m365", st.GetEntry("10.2.0.1").Group)
+	assert.Equal(t, constants.SourceSubscription, st.GetEntry("10.1.0.0/16").Source)
+
+	// the unchanged list is not downloaded again
+	assert.NoError(t, m.Sync(context.Background(), cfg))
+	assert.Equal(t, state.SyncStatusNotModified, st.GetSubscription("microsoft").Status)
+	assert.Equal(t, []string{"", `"v1"`}, server.etags)
+
+	server.set("10.1.0.0/16\n", `"v2"`, 0)
+	assert.NoError(t, m.Sync(context.Background(), cfg))
+	assert.Nil(t, st.GetEntry("10.2.0.1"))
+	assert.Equal(t, `"v2"`, st.GetSubscription("microsoft").ETag)
+
+	// a failed sync leaves the group as it is
+	server.set("", "", http.StatusServiceUnavailable)
+	assert.Error(t, m.Sync(context.Background(), cfg))
+
+	sub = st.GetSubscription("microsoft")
+	assert.Equal(t, state.SyncStatusFailed, sub.Status)
+	assert.Equal(t, "failed to fetch list: unexpected status 503 Service Unavailable", sub.LastError)
+	assert.NotNil(t, sub.LastSuccess)
+	assert.NotNil(t, st.GetEntry("10.1.0.0/16"))
+}
+
+func TestManager_Sync_Unresolved(t *testing.T) {
+	m, st := newTestManager(t)
+	testutil.FakeRouteCommands(t)
+	utils.SetDNSServers([]string{dnstest.ClosedAddress(t)})
+	t.Cleanup(func() { utils.SetDNSServers(nil) })
+
+	server := &listServer{content: "api.stub.test\n", etag: `"v1"`}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cfg := &Config{Name: "api", URL: ts.URL, Group: "api", Interval: time.Hour}
+	if !assert.NoError(t, m.Sync(context.Background(), cfg)) {
+		return
+	}
+
+	// the destination that cannot be resolved is added pending
+	entry := st.GetEntry("api.stub.test")
+	if !assert.NotNil(t, entry) {
+		return
+	}
+
+	assert.Empty(t, entry.ResolvedIPs)
+
+	// the unchanged list is not downloaded again, the pending destination is installed on the next refresh
+	assert.NoError(t, m.Sync(context.Background(), cfg))
+	assert.Equal(t, state.SyncStatusNotModified, st.GetSubscription("api").Status)
+
+	dns := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.5"))
+	utils.SetDNSServers([]string{dns.Address()})
+
+	assert.NoError(t, st.CheckIPChanges())
+	assert.Equal(t, []string{"10.0.0.5"}, st.GetEntry("api.stub.test").ResolvedIPs)
+}
+
+func TestManager_Sync_File(t *testing.T) {
+	m, st := newTestManager(t, "office")
+	name := filepath.Join(t.TempDir(), "office.json")
+	if err := os.WriteFile(name, []byte(`{"routes": [{"destination": "10.3.0.0/16"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{Name: "office", URL: "file://" + name, Group: "office", Interval: time.Hour}
+	if assert.NoError(t, m.Sync(context.Background(), cfg)) {
+		assert.Equal(t, "office", st.GetEntry("10.3.0.0/16").Group)
+	}
+
+	// an error page is not taken as an empty list
+	if err := os.WriteFile(name, []byte("<html>\n<body>maintenance</body>\n</html>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg = &Config{Name: "office", URL: name, Format: "text", Group: "office", Interval: time.Hour}
+	assert.EqualError(t, m.Sync(context.Background(), cfg), "list has no valid destination, 3 lines are invalid")
+	assert.NotNil(t, st.GetEntry("10.3.0.0/16"))
+
+	assert.Error(t, m.Sync(context.Background(), &Config{Name: "missing", URL: filepath.Join(t.TempDir(), "missing.txt"), Group: "office"}))
+	assert.Equal(t, state.SyncStatusFailed, st.GetSubscription("missing").Status)
+}
+
+func TestManager_Run(t *testing.T) {
+	m, st := newTestManager(t, "m365")
+	ts := httptest.NewServer(&listServer{content: "10.1.0.0/16\n", etag: `"v1"`})
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Run(ctx)
+
+	m.SetSubscriptions([]*Config{{Name: "microsoft", URL: ts.URL, Group: "m365", Interval: time.Hour}})
+	assert.Eventually(t, func() bool {
+		st.Lock()
+		defer st.Unlock()
+
+		return st.GetEntry("10.1.0.0/16") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the list that is not subscribed anymore is removed with its destinations
+	m.SetSubscriptions(nil)
+
+	st.Lock()
+	defer st.Unlock()
+
+	assert.Empty(t, st.Subscriptions)
+	assert.Nil(t, st.GetEntry("10.1.0.0/16"))
+	assert.Nil(t, st.GetGroup("m365"))
+}
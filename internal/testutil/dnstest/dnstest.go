@@ -0,0 +1,121 @@
+// Package dnstest is a stub DNS server for the tests that resolve domains
+package dnstest
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Server is a local DNS server that answers the A queries with the IPs that are returned by its answer function, which
+// gets the number of the queries that are answered before
+type Server struct {
+	conn   net.PacketConn
+	mu     sync.Mutex
+	n      int
+	answer func(n int) []string
+}
+
+// NewServer starts a Server on a random local port, it is stopped on the cleanup of the test
+func NewServer(t *testing.T, answer func(n int) []string) *Server {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{conn: conn, answer: answer}
+	t.Cleanup(func() { _ = conn.Close() })
+	go s.serve()
+
+	return s
+}
+
+// StaticAnswer returns an answer function of the Server that always returns the given IPs
+func StaticAnswer(ips ...string) func(int) []string {
+	return func(int) []string { return ips }
+}
+
+// ClosedAddress returns a local address that nothing listens on
+func ClosedAddress(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := conn.LocalAddr().String()
+	_ = conn.Close()
+
+	return address
+}
+
+// SilentAddress returns a local address that receives the queries and never answers them
+func SilentAddress(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn.LocalAddr().String()
+}
+
+// Address returns the host:port address of the Server
+func (s *Server) Address() string {
+	return s.conn.LocalAddr().String()
+}
+
+// Queries returns the number of the A queries that the Server has answered
+func (s *Server) Queries() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.n
+}
+
+func (s *Server) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil || len(msg.Questions) == 0 {
+			continue
+		}
+
+		question := msg.Questions[0]
+		msg.Header.Response = true
+		msg.Header.Authoritative = true
+		msg.Header.RCode = dnsmessage.RCodeSuccess
+		msg.Answers = nil
+		msg.Additionals = nil
+
+		if question.Type == dnsmessage.TypeA {
+			s.mu.Lock()
+			ips := s.answer(s.n)
+			s.n++
+			s.mu.Unlock()
+
+			for _, ip := range ips {
+				var a [4]byte
+				copy(a[:], net.ParseIP(ip).To4())
+				msg.Answers = append(msg.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+					Body:   &dnsmessage.AResource{A: a},
+				})
+			}
+		}
+
+		packed, err := msg.Pack()
+		if err != nil {
+			continue
+		}
+
+		_, _ = s.conn.WriteTo(packed, addr)
+	}
+}
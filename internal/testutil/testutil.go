@@ -0,0 +1,40 @@
+// Package testutil holds the helpers that the tests of several packages share
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+)
+
+// NewState returns an empty state.State in a temporary directory with the given entries, the entries are added
+// without installing their routes
+func NewState(t *testing.T, entries ...*state.RouteEntry) *state.State {
+	t.Helper()
+
+	st := state.NewState(logging.GetLogger(), filepath.Join(t.TempDir(), constants.StateFileName))
+	for _, entry := range entries {
+		if err := st.AddEntry(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return st
+}
+
+// FakeRouteCommands puts a sudo on the PATH that succeeds without running anything until the end of the test, so that
+// the routes of the active entries are installed and removed without touching the routing table
+func FakeRouteCommands(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sudo"), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
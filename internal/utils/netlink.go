@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// MainTable is the routing table that the routes of the destinations are installed to
+	MainTable = unix.RT_TABLE_MAIN
+	// rtMsgLen and fibRuleHdrLen are the lengths of the headers of the route and the rule messages, the attributes
+	// follow them
+	rtMsgLen      = unix.SizeofRtMsg
+	fibRuleHdrLen = 12
+	// netlinkBufferLen is the size of the buffer that the reply of a route lookup is received into
+	netlinkBufferLen = 1 << 16
+)
+
+// tableNames are the names of the reserved routing tables, the others are shown with their IDs
+var tableNames = map[int]string{
+	unix.RT_TABLE_DEFAULT: "default",
+	unix.RT_TABLE_MAIN:    "main",
+	unix.RT_TABLE_LOCAL:   "local",
+}
+
+// ruleActions are the names of the actions of the rules that do not look the route up
+var ruleActions = map[uint8]string{
+	unix.FR_ACT_GOTO:        "goto",
+	unix.FR_ACT_NOP:         "nop",
+	unix.FR_ACT_BLACKHOLE:   "blackhole",
+	unix.FR_ACT_UNREACHABLE: "unreachable",
+	unix.FR_ACT_PROHIBIT:    "prohibit",
+}
+
+// TableName returns the name of the given routing table like ip route does, such as main for 254
+func TableName(table int) string {
+	if name, ok := tableNames[table]; ok {
+		return name
+	}
+
+	return strconv.Itoa(table)
+}
+
+// RouteDecision is the route that the kernel picks for the traffic to an IP, like ip route get reports it
+type RouteDecision struct {
+	IP        string
+	Interface string
+	// Gateway is the next hop, empty if the IP is on a directly connected network
+	Gateway string
+	// Source is the preferred source address of the traffic
+	Source string
+	// Table is the routing table that the route is found in, which is picked by the policy routing rules
+	Table int
+}
+
+// String returns the decision in the format of ip route get, such as 1.1.1.1 via 192.168.1.1 dev eth0 table main
+func (d *RouteDecision) String() string {
+	s := d.IP
+	if d.Gateway != "" {
+		s += " via " + d.Gateway
+	}
+
+	s += " dev " + d.Interface
+	if d.Source != "" {
+		s += " src " + d.Source
+	}
+
+	return s + " table " + TableName(d.Table)
+}
+
+// RoutingRule is an IPv4 policy routing rule, like ip rule lists it
+type RoutingRule struct {
+	Priority int
+	// Table is the routing table that the rule looks the route up in, 0 for the rules with other actions
+	Table int
+	// Action is the action of the rules that do not look the route up, such as unreachable
+	Action string
+	// Invert matches the traffic that the selectors of the rule do not match
+	Invert bool
+	Src    *net.IPNet
+	Dst    *net.IPNet
+	// Mark and Mask select the traffic with the firewall mark, Mark is 0 if the rule does not look at the mark
+	Mark uint32
+	Mask uint32
+	// InputInterface selects the traffic that is received on the interface
+	InputInterface string
+	// SuppressPrefixLength rejects the routes whose prefix is not longer than it, -1 if it is not set
+	SuppressPrefixLength int
+}
+
+// String returns the rule in the format of ip rule, such as 32766: from all lookup main
+func (r *RoutingRule) String() string {
+	parts := []string{strconv.Itoa(r.Priority) + ":"}
+	if r.Invert {
+		parts = append(parts, "not")
+	}
+
+	from := "all"
+	if r.Src != nil {
+		from = r.Src.String()
+	}
+
+	parts = append(parts, "from", from)
+	if r.Dst != nil {
+		parts = append(parts, "to", r.Dst.String())
+	}
+
+	if r.Mark != 0 {
+		mark := fmt.Sprintf("fwmark %#x", r.Mark)
+		if r.Mask != 0xffffffff {
+			mark += fmt.Sprintf("/%#x", r.Mask)
+		}
+
+		parts = append(parts, mark)
+	}
+
+	if r.InputInterface != "" {
+		parts = append(parts, "iif", r.InputInterface)
+	}
+
+	if r.Table != 0 {
+		parts = append(parts, "lookup", TableName(r.Table))
+	} else if r.Action != "" {
+		parts = append(parts, r.Action)
+	}
+
+	if r.SuppressPrefixLength >= 0 {
+		parts = append(parts, "suppress_prefixlength", strconv.Itoa(r.SuppressPrefixLength))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// GetRouteDecision asks the kernel for the route of the traffic to the given IPv4 address with RTM_GETROUTE, the
+// policy routing rules are applied like for the traffic that originates from the host
+func GetRouteDecision(ip net.IP) (*RouteDecision, error) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return nil, errors.Errorf("%s is not an IPv4 address", ip)
+	}
+
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open netlink socket")
+	}
+	defer unix.Close(fd)
+
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, errors.Wrap(err, "failed to bind netlink socket")
+	}
+
+	if err := unix.Sendto(fd, newRouteRequest(ip4), 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, errors.Wrap(err, "failed to send route lookup")
+	}
+
+	buf := make([]byte, netlinkBufferLen)
+	n, _, err := unix.Recvfrom(fd, buf, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive route lookup")
+	}
+
+	decision, err := parseRouteDecision(buf[:n])
+	if err != nil {
+		return nil, err
+	}
+
+	decision.IP = ip4.String()
+
+	return decision, nil
+}
+
+// newRouteRequest returns the RTM_GETROUTE message of the given IPv4 address
+func newRouteRequest(ip net.IP) []byte {
+	attrLen := unix.SizeofRtAttr + net.IPv4len
+	buf := make([]byte, unix.SizeofNlMsghdr+rtMsgLen+attrLen)
+
+	binary.NativeEndian.PutUint32(buf[0:4], uint32(len(buf)))
+	binary.NativeEndian.PutUint16(buf[4:6], unix.RTM_GETROUTE)
+	binary.NativeEndian.PutUint16(buf[6:8], unix.NLM_F_REQUEST)
+	binary.NativeEndian.PutUint32(buf[8:12], 1)
+
+	msg := buf[unix.SizeofNlMsghdr:]
+	msg[0] = unix.AF_INET
+	msg[1] = 32
+	binary.NativeEndian.PutUint32(msg[8:12], unix.RTM_F_LOOKUP_TABLE)
+
+	attr := msg[rtMsgLen:]
+	binary.NativeEndian.PutUint16(attr[0:2], uint16(attrLen))
+	binary.NativeEndian.PutUint16(attr[2:4], unix.RTA_DST)
+	copy(attr[unix.SizeofRtAttr:], ip)
+
+	return buf
+}
+
+// parseRouteDecision parses the reply of an RTM_GETROUTE request, the interface is looked up by its index
+func parseRouteDecision(b []byte) (*RouteDecision, error) {
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse route lookup")
+	}
+
+	for _, m := range msgs {
+		switch m.Header.Type {
+		case unix.NLMSG_ERROR:
+			if len(m.Data) >= 4 {
+				if errno := int32(binary.NativeEndian.Uint32(m.Data[0:4])); errno != 0 {
+					return nil, errors.Wrap(syscall.Errno(-errno), "kernel has no route")
+				}
+			}
+		case unix.RTM_NEWROUTE:
+			if len(m.Data) < rtMsgLen {
+				continue
+			}
+
+			if routeType := m.Data[7]; routeType != unix.RTN_UNICAST && routeType != unix.RTN_LOCAL {
+				return nil, errors.Errorf("kernel has a route of type %d, the traffic is dropped", routeType)
+			}
+
+			decision := &RouteDecision{Table: int(m.Data[4])}
+			for _, attr := range parseAttrs(m.Data[rtMsgLen:]) {
+				switch attr.Type {
+				case unix.RTA_OIF:
+					index := int(binary.NativeEndian.Uint32(attr.Value))
+					decision.Interface = strconv.Itoa(index)
+					if iface, err := net.InterfaceByIndex(index); err == nil {
+						decision.Interface = iface.Name
+					}
+				case unix.RTA_GATEWAY:
+					decision.Gateway = net.IP(attr.Value).String()
+				case unix.RTA_PREFSRC:
+					decision.Source = net.IP(attr.Value).String()
+				case unix.RTA_TABLE:
+					decision.Table = int(binary.NativeEndian.Uint32(attr.Value))
+				}
+			}
+
+			return decision, nil
+		}
+	}
+
+	return nil, errors.New("kernel did not answer the route lookup")
+}
+
+// GetRoutingRules returns the IPv4 policy routing rules in the order that the kernel applies them
+func GetRoutingRules() ([]*RoutingRule, error) {
+	b, err := syscall.NetlinkRIB(unix.RTM_GETRULE, unix.AF_INET)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list routing rules")
+	}
+
+	return parseRoutingRules(b)
+}
+
+// parseRoutingRules parses the RTM_NEWRULE messages of a rule dump and sorts them by their priorities
+func parseRoutingRules(b []byte) ([]*RoutingRule, error) {
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse routing rules")
+	}
+
+	var rules []*RoutingRule
+	for _, m := range msgs {
+		if m.Header.Type != unix.RTM_NEWRULE || len(m.Data) < fibRuleHdrLen {
+			continue
+		}
+
+		rule := &RoutingRule{
+			Invert:               binary.NativeEndian.Uint32(m.Data[8:12])&unix.FIB_RULE_INVERT != 0,
+			SuppressPrefixLength: -1,
+		}
+
+		if m.Data[7] == unix.FR_ACT_TO_TBL {
+			rule.Table = int(m.Data[4])
+		} else {
+			rule.Action = ruleActions[m.Data[7]]
+		}
+
+		dstLen, srcLen := int(m.Data[1]), int(m.Data[2])
+		for _, attr := range parseAttrs(m.Data[fibRuleHdrLen:]) {
+			switch attr.Type {
+			case unix.FRA_PRIORITY:
+				rule.Priority = int(binary.NativeEndian.Uint32(attr.Value))
+			case unix.FRA_TABLE:
+				if m.Data[7] == unix.FR_ACT_TO_TBL {
+					rule.Table = int(binary.NativeEndian.Uint32(attr.Value))
+				}
+			case unix.FRA_FWMARK:
+				rule.Mark = binary.NativeEndian.Uint32(attr.Value)
+			case unix.FRA_FWMASK:
+				rule.Mask = binary.NativeEndian.Uint32(attr.Value)
+			case unix.FRA_SUPPRESS_PREFIXLEN:
+				// the kernel reports -1 as all ones when it is not set
+				rule.SuppressPrefixLength = int(int32(binary.NativeEndian.Uint32(attr.Value)))
+			case unix.FRA_IIFNAME:
+				rule.InputInterface = strings.TrimRight(string(attr.Value), "\x00")
+			case unix.FRA_SRC:
+				rule.Src = &net.IPNet{IP: net.IP(attr.Value), Mask: net.CIDRMask(srcLen, 32)}
+			case unix.FRA_DST:
+				rule.Dst = &net.IPNet{IP: net.IP(attr.Value), Mask: net.CIDRMask(dstLen, 32)}
+			}
+		}
+
+		if rule.Mark != 0 && rule.Mask == 0 {
+			rule.Mask = 0xffffffff
+		}
+
+		rules = append(rules, rule)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority < rules[j].Priority })
+
+	return rules, nil
+}
+
+// LookupRule returns the first of the given rules that looks the routes up in the given table, which is the rule that
+// picked the table of a RouteDecision unless more than one rule points at the table. It returns nil if none does
+func LookupRule(rules []*RoutingRule, table int) *RoutingRule {
+	for _, rule := range rules {
+		if rule.Table == table {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+// netlinkAttr is a routing attribute of a netlink message
+type netlinkAttr struct {
+	Type  uint16
+	Value []byte
+}
+
+// parseAttrs parses the routing attributes that follow the header of a netlink message, it stops at the first
+// malformed one
+func parseAttrs(b []byte) []netlinkAttr {
+	var attrs []netlinkAttr
+	for len(b) >= unix.SizeofRtAttr {
+		length := int(binary.NativeEndian.Uint16(b[0:2]))
+		if length < unix.SizeofRtAttr || length > len(b) {
+			break
+		}
+
+		attrs = append(attrs, netlinkAttr{Type: binary.NativeEndian.Uint16(b[2:4]), Value: b[unix.SizeofRtAttr:length]})
+
+		// attributes are aligned to 4 bytes
+		aligned := (length + unix.RTA_ALIGNTO - 1) &^ (unix.RTA_ALIGNTO - 1)
+		if aligned > len(b) {
+			break
+		}
+
+		b = b[aligned:]
+	}
+
+	return attrs
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"encoding/binary"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// attr returns a routing attribute with the given value, padded to 4 bytes
+func attr(typ uint16, value []byte) []byte {
+	b := make([]byte, unix.SizeofRtAttr+len(value), (unix.SizeofRtAttr+len(value)+3)&^3)
+	binary.NativeEndian.PutUint16(b[0:2], uint16(len(b)))
+	binary.NativeEndian.PutUint16(b[2:4], typ)
+	copy(b[unix.SizeofRtAttr:], value)
+
+	return b[:cap(b)]
+}
+
+// u32 returns the given value in the byte order of the host
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.NativeEndian.PutUint32(b, v)
+
+	return b
+}
+
+// message returns a netlink message of the given type with the given header and attributes
+func message(typ uint16, header []byte, attrs ...[]byte) []byte {
+	data := append([]byte{}, header...)
+	for _, a := range attrs {
+		data = append(data, a...)
+	}
+
+	b := make([]byte, unix.SizeofNlMsghdr, unix.SizeofNlMsghdr+len(data))
+	binary.NativeEndian.PutUint32(b[0:4], uint32(unix.SizeofNlMsghdr+len(data)))
+	binary.NativeEndian.PutUint16(b[4:6], typ)
+
+	return append(b, data...)
+}
+
+func TestNewRouteRequest(t *testing.T) {
+	req := newRouteRequest(net.ParseIP("1.1.1.1").To4())
+
+	msgs, err := syscall.ParseNetlinkMessage(req)
+	if !assert.NoError(t, err) || !assert.Len(t, msgs, 1) {
+		return
+	}
+
+	assert.Equal(t, uint16(unix.RTM_GETROUTE), msgs[0].Header.Type)
+
+	attrs := parseAttrs(msgs[0].Data[rtMsgLen:])
+	if assert.Len(t, attrs, 1) {
+		assert.Equal(t, uint16(unix.RTA_DST), attrs[0].Type)
+		assert.Equal(t, "1.1.1.1", net.IP(attrs[0].Value).String())
+	}
+}
+
+func TestParseRouteDecision(t *testing.T) {
+	header := make([]byte, rtMsgLen)
+	header[0], header[1], header[4], header[7] = unix.AF_INET, 32, unix.RT_TABLE_MAIN, unix.RTN_UNICAST
+
+	// the interface index is not expected to exist, it is reported as is
+	reply := message(unix.RTM_NEWROUTE, header,
+		attr(unix.RTA_TABLE, u32(51820)),
+		attr(unix.RTA_OIF, u32(1<<30)),
+		attr(unix.RTA_GATEWAY, net.ParseIP("192.168.1.1").To4()),
+		attr(unix.RTA_PREFSRC, net.ParseIP("192.168.1.20").To4()))
+
+	decision, err := parseRouteDecision(reply)
+	if assert.NoError(t, err) {
+		decision.IP = "1.1.1.1"
+		assert.Equal(t, "1073741824", decision.Interface)
+		assert.Equal(t, 51820, decision.Table)
+		assert.Equal(t, "1.1.1.1 via 192.168.1.1 dev 1073741824 src 192.168.1.20 table 51820", decision.String())
+	}
+
+	header[7] = unix.RTN_UNREACHABLE
+	_, err = parseRouteDecision(message(unix.RTM_NEWROUTE, header))
+	assert.Error(t, err)
+
+	errno := -int32(unix.ENETUNREACH)
+	_, err = parseRouteDecision(message(unix.NLMSG_ERROR, u32(uint32(errno))))
+	assert.ErrorIs(t, err, syscall.ENETUNREACH)
+}
+
+func TestParseRoutingRules(t *testing.T) {
+	rule := func(table uint8, action uint8, flags uint32, dstLen uint8, attrs ...[]byte) []byte {
+		header := make([]byte, fibRuleHdrLen)
+		header[0], header[1], header[4], header[7] = unix.AF_INET, dstLen, table, action
+		binary.NativeEndian.PutUint32(header[8:12], flags)
+
+		return message(unix.RTM_NEWRULE, header, attrs...)
+	}
+
+	var dump []byte
+	dump = append(dump, rule(unix.RT_TABLE_MAIN, unix.FR_ACT_TO_TBL, 0, 0,
+		attr(unix.FRA_PRIORITY, u32(32766)))...)
+	dump = append(dump, rule(unix.RT_TABLE_LOCAL, unix.FR_ACT_TO_TBL, 0, 0)...)
+	// the rules of wg-quick
+	dump = append(dump, rule(0, unix.FR_ACT_TO_TBL, unix.FIB_RULE_INVERT, 0,
+		attr(unix.FRA_PRIORITY, u32(32764)), attr(unix.FRA_TABLE, u32(51820)), attr(unix.FRA_FWMARK, u32(0xca6c)))...)
+	dump = append(dump, rule(unix.RT_TABLE_MAIN, unix.FR_ACT_TO_TBL, 0, 0,
+		attr(unix.FRA_PRIORITY, u32(32765)), attr(unix.FRA_SUPPRESS_PREFIXLEN, u32(0)))...)
+	dump = append(dump, rule(0, unix.FR_ACT_UNREACHABLE, 0, 8,
+		attr(unix.FRA_PRIORITY, u32(100)), attr(unix.FRA_DST, net.ParseIP("10.0.0.0").To4()),
+		attr(unix.FRA_IIFNAME, []byte("eth0\x00")))...)
+
+	rules, err := parseRoutingRules(dump)
+	if !assert.NoError(t, err) || !assert.Len(t, rules, 5) {
+		return
+	}
+
+	expected := []string{
+		"0: from all lookup local",
+		"100: from all to 10.0.0.0/8 iif eth0 unreachable",
+		"32764: not from all fwmark 0xca6c lookup 51820",
+		"32765: from all lookup main suppress_prefixlength 0",
+		"32766: from all lookup main",
+	}
+
+	for i, rule := range rules {
+		assert.Equal(t, expected[i], rule.String())
+	}
+
+	assert.Equal(t, rules[2], LookupRule(rules, 51820))
+	assert.Equal(t, rules[3], LookupRule(rules, MainTable))
+	assert.Nil(t, LookupRule(rules, 100))
+}
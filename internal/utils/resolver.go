@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dnsPort        = "53"
+	dnsDialTimeout = 5 * time.Second
+	// SystemResolver is the name of the system resolver in the Resolution.Sources
+	SystemResolver = "system"
+)
+
+// hostnamePattern matches the domain names, labels consist of letters, digits and hyphens. Wildcard labels are not
+// matched, since the destinations are resolved to the IPs to route and a wildcard cannot be resolved
+var hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.)*[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.?$`)
+
+// IsValidDestination checks if the given destination is a domain, an IP address or a CIDR block
+func IsValidDestination(destination string) bool {
+	if _, _, err := net.ParseCIDR(destination); err == nil {
+		return true
+	}
+
+	if net.ParseIP(destination) != nil {
+		return true
+	}
+
+	return len(destination) <= 253 && hostnamePattern.MatchString(destination)
+}
+
+// ResolveStrategy is how the answers of the DNS servers are combined
+type ResolveStrategy string
+
+const (
+	// ResolveStrategyFirst uses the answer of the first DNS server that answers, the others are tried in order only if
+	// the previous ones fail
+	ResolveStrategyFirst ResolveStrategy = "first"
+	// ResolveStrategyUnion queries every DNS server and uses every IP that any of them returns
+	ResolveStrategyUnion ResolveStrategy = "union"
+	// ResolveStrategyMajority queries every DNS server and uses the IPs that more than half of the answering servers
+	// return
+	ResolveStrategyMajority ResolveStrategy = "majority"
+)
+
+// Valid returns true if the ResolveStrategy is a known one, empty ResolveStrategy means ResolveStrategyFirst
+func (s ResolveStrategy) Valid() bool {
+	switch s {
+	case "", ResolveStrategyFirst, ResolveStrategyUnion, ResolveStrategyMajority:
+		return true
+	default:
+		return false
+	}
+}
+
+// Resolution is the outcome of resolving a domain
+type Resolution struct {
+	// IPs are the resolved IPv4 addresses, in the order that they are first returned
+	IPs []string
+	// Sources maps the IPs to the DNS servers that returned them, it is empty for the IP addresses and CIDR blocks
+	Sources map[string][]string
+}
+
+// dnsServer is a DNS server with the resolver that queries only that server
+type dnsServer struct {
+	address  string
+	resolver *net.Resolver
+}
+
+// dnsQueryTimeout is the deadline of a single query to a DNS server, so that a server that does not answer cannot hold
+// the callers that resolve under the lock of the state. It is replaced in tests
+var dnsQueryTimeout = 5 * time.Second
+
+var (
+	resolverMu sync.RWMutex
+	servers    = []*dnsServer{{address: SystemResolver, resolver: net.DefaultResolver}}
+	strategy   = ResolveStrategyFirst
+	queries    = 1
+)
+
+// SetDNSServers makes ResolveDomain query the given DNS servers instead of the system resolver. Servers can be given
+// with or without a port, an empty list restores the system resolver
+func SetDNSServers(addrs []string) {
+	next := make([]*dnsServer, 0, len(addrs))
+	for _, addr := range addrs {
+		next = append(next, newDNSServer(DNSServerAddress(addr)))
+	}
+
+	if len(next) == 0 {
+		next = append(next, &dnsServer{address: SystemResolver, resolver: net.DefaultResolver})
+	}
+
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+
+	servers = next
+}
+
+// SetResolveStrategy sets how the answers of the DNS servers are combined and how many times each server is queried
+// on a single resolution. Repeated queries collect the IPs of the servers that return a different subset on each
+// answer. Empty ResolveStrategy means ResolveStrategyFirst and n below 1 means a single query
+func SetResolveStrategy(s ResolveStrategy, n int) {
+	if s == "" {
+		s = ResolveStrategyFirst
+	}
+
+	if n < 1 {
+		n = 1
+	}
+
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+
+	strategy = s
+	queries = n
+}
+
+// DNSServerAddress returns the host:port address of the given DNS server, port 53 is used if it is not given
+func DNSServerAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+
+	return net.JoinHostPort(server, dnsPort)
+}
+
+// newDNSServer returns a dnsServer that sends the queries only to the given address
+func newDNSServer(address string) *dnsServer {
+	return &dnsServer{
+		address: address,
+		resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				dialer := net.Dialer{Timeout: dnsDialTimeout}
+				return dialer.DialContext(ctx, network, address)
+			},
+		},
+	}
+}
+
+// DNSServers returns the addresses of the DNS servers that Resolve queries, SystemResolver if the system resolver is
+// used
+func DNSServers() []string {
+	dnsServers, _, _ := resolverConfig()
+
+	addrs := make([]string, 0, len(dnsServers))
+	for _, server := range dnsServers {
+		addrs = append(addrs, server.address)
+	}
+
+	return addrs
+}
+
+// QueryDNSServer resolves the IPv4 addresses of the given domain only with the given DNS server, SystemResolver
+// queries the system resolver
+func QueryDNSServer(server, domain string) ([]string, error) {
+	if server == SystemResolver {
+		return (&dnsServer{address: SystemResolver, resolver: net.DefaultResolver}).lookup(domain, 1)
+	}
+
+	return newDNSServer(DNSServerAddress(server)).lookup(domain, 1)
+}
+
+// resolverConfig returns the DNS servers and the strategy that are used by Resolve
+func resolverConfig() ([]*dnsServer, ResolveStrategy, int) {
+	resolverMu.RLock()
+	defer resolverMu.RUnlock()
+
+	return servers, strategy, queries
+}
+
+// Resolve returns the IPv4 addresses of the given domain with the DNS servers that returned them, the DNS servers are
+// queried with the configured ResolveStrategy. IP addresses and CIDR blocks are returned as is, so that they can be
+// routed like the domains
+func Resolve(domain string) (*Resolution, error) {
+	if _, ipNet, err := net.ParseCIDR(domain); err == nil {
+		return &Resolution{IPs: []string{ipNet.String()}}, nil
+	}
+
+	if ip := net.ParseIP(domain); ip != nil {
+		return &Resolution{IPs: []string{ip.String()}}, nil
+	}
+
+	dnsServers, strategy, queries := resolverConfig()
+	answers := make([][]string, len(dnsServers))
+	errs := make([]error, len(dnsServers))
+
+	if strategy == ResolveStrategyFirst {
+		for i, server := range dnsServers {
+			if answers[i], errs[i] = server.lookup(domain, queries); errs[i] == nil {
+				break
+			}
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i, server := range dnsServers {
+			wg.Add(1)
+			go func(i int, server *dnsServer) {
+				defer wg.Done()
+				answers[i], errs[i] = server.lookup(domain, queries)
+			}(i, server)
+		}
+
+		wg.Wait()
+	}
+
+	res := &Resolution{Sources: make(map[string][]string)}
+	var answered int
+	var lastErr error
+	for i, server := range dnsServers {
+		if errs[i] != nil {
+			lastErr = errs[i]
+			continue
+		}
+
+		// the servers after the first answering one are not queried with ResolveStrategyFirst
+		if answers[i] == nil {
+			continue
+		}
+
+		answered++
+		for _, ip := range answers[i] {
+			if _, ok := res.Sources[ip]; !ok {
+				res.IPs = append(res.IPs, ip)
+			}
+
+			res.Sources[ip] = append(res.Sources[ip], server.address)
+		}
+	}
+
+	if answered == 0 {
+		return nil, lastErr
+	}
+
+	if strategy == ResolveStrategyMajority {
+		ips := make([]string, 0, len(res.IPs))
+		for _, ip := range res.IPs {
+			if len(res.Sources[ip])*2 > answered {
+				ips = append(ips, ip)
+			}
+		}
+
+		if len(ips) == 0 {
+			return nil, errors.Errorf("no majority answer for %s from %d dns servers", domain, answered)
+		}
+
+		res.IPs = ips
+	}
+
+	return res, nil
+}
+
+// lookup queries the dnsServer n times and returns the IPv4 addresses of the given domain that are returned on any
+// of the queries, every query is bounded by dnsQueryTimeout. An error is returned only if every query fails
+func (d *dnsServer) lookup(domain string, n int) ([]string, error) {
+	ips := []string{}
+	seen := make(map[string]bool)
+	var answered bool
+	var lastErr error
+	for i := 0; i < n; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsQueryTimeout)
+		addrs, err := d.resolver.LookupIP(ctx, "ip4", domain)
+		cancel()
+
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		answered = true
+		for _, addr := range addrs {
+			if ip := addr.To4(); ip != nil && !seen[ip.String()] {
+				seen[ip.String()] = true
+				ips = append(ips, ip.String())
+			}
+		}
+	}
+
+	if !answered {
+		return nil, errors.Wrapf(lastErr, "dns server %s", d.address)
+	}
+
+	return ips, nil
+}
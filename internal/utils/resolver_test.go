@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil/dnstest"
+	"github.com/stretchr/testify/assert"
+)
+
+// useResolvers makes Resolve use the given DNS servers and strategy until the end of the test
+func useResolvers(t *testing.T, strategy ResolveStrategy, queries int, addresses ...string) {
+	SetDNSServers(addresses)
+	SetResolveStrategy(strategy, queries)
+
+	t.Cleanup(func() {
+		SetDNSServers(nil)
+		SetResolveStrategy("", 1)
+	})
+}
+
+func TestResolve_First(t *testing.T) {
+	server := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1", "10.0.0.2"))
+	other := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.3"))
+	useResolvers(t, ResolveStrategyFirst, 1, dnstest.ClosedAddress(t), server.Address(), other.Address())
+
+	res, err := Resolve("stub.test")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the failing server is skipped and the ones after the first answering server are not queried
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, res.IPs)
+	assert.Equal(t, map[string][]string{"10.0.0.1": {server.Address()}, "10.0.0.2": {server.Address()}}, res.Sources)
+	assert.Zero(t, other.Queries())
+}
+
+func TestResolve_Union(t *testing.T) {
+	a := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1"))
+	b := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.2", "10.0.0.1"))
+	useResolvers(t, ResolveStrategyUnion, 1, a.Address(), b.Address(), dnstest.ClosedAddress(t))
+
+	res, err := Resolve("stub.test")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, res.IPs)
+	assert.Equal(t, []string{a.Address(), b.Address()}, res.Sources["10.0.0.1"])
+	assert.Equal(t, []string{b.Address()}, res.Sources["10.0.0.2"])
+}
+
+func TestResolve_Majority(t *testing.T) {
+	a := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1", "10.0.0.2"))
+	b := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1", "10.0.0.3"))
+	c := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.2", "10.0.0.1"))
+	useResolvers(t, ResolveStrategyMajority, 1, a.Address(), b.Address(), c.Address())
+
+	res, err := Resolve("stub.test")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the IPs that are returned by only one of the three servers are dropped, their sources are still reported
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, res.IPs)
+	assert.Equal(t, []string{b.Address()}, res.Sources["10.0.0.3"])
+}
+
+func TestResolve_MajoritySplit(t *testing.T) {
+	a := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1"))
+	b := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.2"))
+	useResolvers(t, ResolveStrategyMajority, 1, a.Address(), b.Address())
+
+	// none of the IPs is returned by more than half of the servers
+	_, err := Resolve("stub.test")
+	assert.EqualError(t, err, "no majority answer for stub.test from 2 dns servers")
+}
+
+func TestResolve_Timeout(t *testing.T) {
+	server := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1"))
+	useResolvers(t, ResolveStrategyUnion, 1, dnstest.SilentAddress(t), server.Address())
+
+	timeout := dnsQueryTimeout
+	dnsQueryTimeout = 200 * time.Millisecond
+	t.Cleanup(func() { dnsQueryTimeout = timeout })
+
+	// the server that does not answer is given up on the deadline of the query
+	start := time.Now()
+	res, err := Resolve("stub.test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"10.0.0.1"}, res.IPs)
+	}
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestResolve_RepeatedQueries(t *testing.T) {
+	rotating := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	server := dnstest.NewServer(t, func(n int) []string { return []string{rotating[n%len(rotating)]} })
+	useResolvers(t, ResolveStrategyFirst, 3, server.Address())
+
+	res, err := Resolve("stub.test")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.ElementsMatch(t, rotating, res.IPs)
+}
+
+func TestResolve_Failures(t *testing.T) {
+	useResolvers(t, ResolveStrategyUnion, 1, dnstest.ClosedAddress(t), dnstest.ClosedAddress(t))
+
+	_, err := Resolve("stub.test")
+	assert.Error(t, err)
+
+	// IP addresses and CIDR blocks are not resolved
+	res, err := Resolve("10.10.0.0/16")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.10.0.0/16"}, res.IPs)
+	assert.Empty(t, res.Sources)
+}
+
+func TestQueryDNSServer(t *testing.T) {
+	server := dnstest.NewServer(t, dnstest.StaticAnswer("10.0.0.1"))
+	useResolvers(t, ResolveStrategyFirst, 1, server.Address(), "192.0.2.53")
+
+	assert.Equal(t, []string{server.Address(), "192.0.2.53:53"}, DNSServers())
+
+	ips, err := QueryDNSServer(server.Address(), "stub.test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, ips)
+
+	_, err = QueryDNSServer(dnstest.ClosedAddress(t), "stub.test")
+	assert.Error(t, err)
+}
+
+func TestIsValidDestination(t *testing.T) {
+	for _, destination := range []string{"example.com", "www.example.com.", "_sip._tcp.example.com", "10.0.0.1",
+		"10.0.0.0/8", "2001:db8::1", "2001:db8::/32"} {
+		assert.True(t, IsValidDestination(destination), destination)
+	}
+
+	// wildcards cannot be resolved to the IPs to route
+	for _, destination := range []string{"*.example.com", "*", "not a domain", "-example.com", "example..com", ""} {
+		assert.False(t, IsValidDestination(destination), destination)
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+)
+
+const (
+	// routingTablePath is the IPv4 routing table of the kernel
+	routingTablePath = "/proc/net/route"
+	// neighborTablePath is the IPv4 neighbor (ARP) table of the kernel
+	neighborTablePath = "/proc/net/arp"
+	// arpFlagComplete is the flag of the neighbor entries whose hardware address is resolved
+	arpFlagComplete = 0x2
+)
+
+// KernelRoute is a single IPv4 route in the routing table of the kernel
+type KernelRoute struct {
+	Interface   string
+	Destination *net.IPNet
+	// Gateway is empty for the directly connected routes
+	Gateway string
+	Metric  int
+}
+
+// Default returns true if the route is a default route
+func (r *KernelRoute) Default() bool {
+	ones, _ := r.Destination.Mask.Size()
+	return ones == 0
+}
+
+// String returns the route in the format of ip route, such as 10.8.0.0/16 via 10.8.0.1 dev tun0
+func (r *KernelRoute) String() string {
+	if r.Gateway == "" {
+		return r.Destination.String() + " dev " + r.Interface
+	}
+
+	return r.Destination.String() + " via " + r.Gateway + " dev " + r.Interface
+}
+
+// GetRoutingTable returns the IPv4 routes of the main routing table of the kernel
+func GetRoutingTable() ([]*KernelRoute, error) {
+	file, err := os.Open(routingTablePath)
+	if err != nil {
+		return nil, errors.Wrap(err, constants.FailedToOpenRoutingInfoFile)
+	}
+	defer file.Close()
+
+	return parseRoutingTable(file)
+}
+
+// parseRoutingTable parses the routes in the format of /proc/net/route, the lines that cannot be parsed are skipped
+func parseRoutingTable(r io.Reader) ([]*KernelRoute, error) {
+	var routes []*KernelRoute
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[0] == "Iface" {
+			continue
+		}
+
+		destination, err1 := parseHexIP(fields[1])
+		gateway, err2 := parseHexIP(fields[2])
+		mask, err3 := hex.DecodeString(fields[7])
+		metric, err4 := strconv.Atoi(fields[6])
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || len(mask) != 4 {
+			continue
+		}
+
+		// mask is in the byte order of the host like the addresses
+		binary.BigEndian.PutUint32(mask, binary.LittleEndian.Uint32(mask))
+
+		route := &KernelRoute{
+			Interface:   fields[0],
+			Destination: &net.IPNet{IP: net.ParseIP(destination).To4(), Mask: net.IPMask(mask)},
+			Metric:      metric,
+		}
+
+		if gateway != "0.0.0.0" {
+			route.Gateway = gateway
+		}
+
+		routes = append(routes, route)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading file")
+	}
+
+	return routes, nil
+}
+
+// GetDefaultNonVPNRoute returns the default route with the highest metric, assuming that the VPN installs its default
+// route with a lower one
+func GetDefaultNonVPNRoute() (*KernelRoute, error) {
+	routes, err := GetRoutingTable()
+	if err != nil {
+		return nil, err
+	}
+
+	return defaultNonVPNRoute(routes)
+}
+
+// defaultNonVPNRoute returns the default route with a gateway and the highest metric among the given routes
+func defaultNonVPNRoute(routes []*KernelRoute) (*KernelRoute, error) {
+	var best *KernelRoute
+	for _, route := range routes {
+		if route.Default() && route.Gateway != "" && (best == nil || route.Metric > best.Metric) {
+			best = route
+		}
+	}
+
+	if best == nil {
+		return nil, errors.New(constants.NonVPNGatewayNotFound)
+	}
+
+	return best, nil
+}
+
+// LookupRoute returns the route that the kernel picks for the given IP among the given routes, which is the most
+// specific one with the lowest metric. It returns nil if none of the routes matches
+func LookupRoute(routes []*KernelRoute, ip net.IP) *KernelRoute {
+	var best *KernelRoute
+	bestOnes := -1
+	for _, route := range routes {
+		if !route.Destination.Contains(ip) {
+			continue
+		}
+
+		ones, _ := route.Destination.Mask.Size()
+		if ones > bestOnes || (ones == bestOnes && route.Metric < best.Metric) {
+			best, bestOnes = route, ones
+		}
+	}
+
+	return best
+}
+
+// IsNeighborResolved returns true if the hardware address of the given IP is resolved in the neighbor table of the
+// kernel, which means that the IP answered recently on a directly connected network
+func IsNeighborResolved(ip string) (bool, error) {
+	file, err := os.Open(neighborTablePath)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open neighbor table")
+	}
+	defer file.Close()
+
+	return isNeighborResolved(file, ip)
+}
+
+// isNeighborResolved looks for the given IP in the neighbor table in the format of /proc/net/arp
+func isNeighborResolved(r io.Reader, ip string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[0] != ip {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+		if err != nil {
+			continue
+		}
+
+		if flags&arpFlagComplete != 0 {
+			return true, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, errors.Wrap(err, "error reading file")
+	}
+
+	return false, nil
+}
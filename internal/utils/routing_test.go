@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// routingTable is a routing table in the format of /proc/net/route with a VPN that routes 10.8.0.0/16 and a default
+// route over tun0 with a lower metric than the one of eth0
+const routingTable = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+tun0	00000000	00000000	0001	0	0	50	00000000	0	0	0
+eth0	00000000	0101A8C0	0003	0	0	100	00000000	0	0	0
+eth0	0001A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+tun0	0000080A	00000000	0001	0	0	50	0000FFFF	0	0	0
+eth0	0100080A	0101A8C0	0007	0	0	0	FFFFFFFF	0	0	0
+`
+
+func TestParseRoutingTable(t *testing.T) {
+	routes, err := parseRoutingTable(strings.NewReader(routingTable))
+	if !assert.NoError(t, err) || !assert.Len(t, routes, 5) {
+		return
+	}
+
+	assert.True(t, routes[0].Default())
+	assert.Empty(t, routes[0].Gateway)
+	assert.Equal(t, "192.168.1.0/24", routes[2].Destination.String())
+	assert.Equal(t, "10.8.0.0/16", routes[3].Destination.String())
+	assert.Equal(t, "0.0.0.0/0 via 192.168.1.1 dev eth0", routes[1].String())
+	assert.Equal(t, "10.8.0.0/16 dev tun0", routes[3].String())
+
+	def, err := defaultNonVPNRoute(routes)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "eth0", def.Interface)
+		assert.Equal(t, "192.168.1.1", def.Gateway)
+	}
+
+	_, err = defaultNonVPNRoute(routes[2:])
+	assert.Error(t, err)
+}
+
+func TestLookupRoute(t *testing.T) {
+	routes, err := parseRoutingTable(strings.NewReader(routingTable))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		ip          string
+		destination string
+		iface       string
+	}{
+		{"10.8.0.1", "10.8.0.1/32", "eth0"},
+		{"10.8.0.2", "10.8.0.0/16", "tun0"},
+		{"192.168.1.20", "192.168.1.0/24", "eth0"},
+		// default route with the lowest metric wins
+		{"1.1.1.1", "0.0.0.0/0", "tun0"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ip, func(t *testing.T) {
+			route := LookupRoute(routes, net.ParseIP(c.ip))
+			if assert.NotNil(t, route) {
+				assert.Equal(t, c.destination, route.Destination.String())
+				assert.Equal(t, c.iface, route.Interface)
+			}
+		})
+	}
+
+	assert.Nil(t, LookupRoute(routes[2:3], net.ParseIP("1.1.1.1")))
+}
+
+func TestIsNeighborResolved(t *testing.T) {
+	arp := `IP address       HW type     Flags       HW address            Mask     Device
+192.168.1.1      0x1         0x2         02:fc:00:00:00:05     *        eth0
+192.168.1.7      0x1         0x0         00:00:00:00:00:00     *        eth0
+`
+
+	for ip, expected := range map[string]bool{"192.168.1.1": true, "192.168.1.7": false, "192.168.1.9": false} {
+		resolved, err := isNeighborResolved(strings.NewReader(arp), ip)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, resolved, ip)
+	}
+}
@@ -1,14 +1,11 @@
 package utils
 
 import (
-	"bufio"
 	"encoding/hex"
 	"fmt"
-	"net"
 	"os"
 	"os/exec"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
@@ -16,62 +13,25 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ResolveDomain returns the IPv4 addresses of the given domain. IP addresses and CIDR blocks are returned as is,
+// so that they can be routed like the domains
 func ResolveDomain(domain string) ([]string, error) {
-	ips, err := net.LookupIP(domain)
+	res, err := Resolve(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	var ipStrings []string
-	for _, ip := range ips {
-		if ip.To4() != nil {
-			ipStrings = append(ipStrings, ip.String())
-		}
-	}
-
-	return ipStrings, nil
+	return res.IPs, nil
 }
 
+// GetDefaultNonVPNGateway returns the gateway of the default route with the highest metric, see GetDefaultNonVPNRoute
 func GetDefaultNonVPNGateway() (string, error) {
-	file, err := os.Open("/proc/net/route")
+	route, err := GetDefaultNonVPNRoute()
 	if err != nil {
-		return "", errors.Wrap(err, constants.FailedToOpenRoutingInfoFile)
-	}
-	defer file.Close()
-
-	var bestGateway string
-	highestMetric := -1
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fields := strings.Fields(scanner.Text())
-		if len(fields) >= 8 && fields[1] == "00000000" {
-			metric, err := strconv.Atoi(fields[6])
-			if err != nil {
-				continue // Ignore lines with invalid metric
-			}
-
-			// Looking for the highest metric, assuming it's non-VPN
-			if metric > highestMetric {
-				highestMetric = metric
-
-				bestGateway, err = parseHexIP(fields[2])
-				if err != nil {
-					continue // Ignore lines with invalid gateway IPs
-				}
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return "", errors.Wrap(err, "error reading file")
+		return "", err
 	}
 
-	if bestGateway == "" {
-		return "", fmt.Errorf(constants.NonVPNGatewayNotFound)
-	}
-
-	return bestGateway, nil
+	return route.Gateway, nil
 }
 
 func parseHexIP(hexStr string) (string, error) {
@@ -107,22 +67,64 @@ func SlicesEqual(a, b []string) bool {
 	return true
 }
 
+// permissionErrors are the outputs of sudo and ip that mean the daemon is not permitted to change the routing table
+var permissionErrors = []string{"Operation not permitted", "a password is required", "is not in the sudoers file"}
+
+var (
+	// ErrRouteExists is wrapped by the errors of AddRoute if the routing table already has a route of the IP
+	ErrRouteExists = errors.New("route already exists")
+	// ErrRouteNotFound is wrapped by the errors of RemoveRoute if the routing table has no route of the IP, such as
+	// after a reboot
+	ErrRouteNotFound = errors.New("route does not exist")
+)
+
+// runRouteCommand runs the given ip route command, see routeCommandError for the errors that it returns
+func runRouteCommand(args ...string) error {
+	out, err := exec.Command("sudo", append([]string{"ip", "route"}, args...)...).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	return routeCommandError(strings.TrimSpace(string(out)), err)
+}
+
+// routeCommandError returns the error of a failed ip route command with the given output. Failures that are caused by
+// the missing privileges wrap os.ErrPermission, the existing and the missing routes wrap ErrRouteExists and
+// ErrRouteNotFound
+func routeCommandError(output string, err error) error {
+	for _, msg := range permissionErrors {
+		if strings.Contains(output, msg) {
+			return fmt.Errorf("%s: %w", output, os.ErrPermission)
+		}
+	}
+
+	switch {
+	case strings.Contains(output, "File exists"):
+		return fmt.Errorf("%s: %w", output, ErrRouteExists)
+	case strings.Contains(output, "No such process"):
+		return fmt.Errorf("%s: %w", output, ErrRouteNotFound)
+	case output != "":
+		return errors.Wrap(err, output)
+	default:
+		return err
+	}
+}
+
 // AddRoute adds a new route to the routing table
 func AddRoute(ip, gateway string) error {
-	cmd := exec.Command("sudo", "ip", "route", "add", ip, "via", gateway)
-
-	if err := cmd.Run(); err != nil {
+	if err := runRouteCommand("add", ip, "via", gateway); err != nil {
 		return errors.Wrap(err, "failed to add route")
 	}
 
 	return nil
 }
 
+// RouteBackend is the mechanism that AddRoute and RemoveRoute change the routing table with
+const RouteBackend = "iproute2"
+
 // RemoveRoute removes a route from the routing table
 func RemoveRoute(ip string) error {
-	cmd := exec.Command("sudo", "ip", "route", "del", ip)
-
-	if err := cmd.Run(); err != nil {
+	if err := runRouteCommand("del", ip); err != nil {
 		return errors.Wrap(err, "failed to remove route")
 	}
 
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteCommandError(t *testing.T) {
+	exitErr := &exec.ExitError{}
+
+	err := routeCommandError("RTNETLINK answers: File exists", exitErr)
+	assert.ErrorIs(t, err, ErrRouteExists)
+	assert.Contains(t, err.Error(), "File exists")
+
+	assert.ErrorIs(t, routeCommandError("RTNETLINK answers: No such process", exitErr), ErrRouteNotFound)
+	assert.ErrorIs(t, routeCommandError("RTNETLINK answers: Operation not permitted", exitErr), os.ErrPermission)
+	assert.ErrorIs(t, routeCommandError("sudo: a password is required", exitErr), os.ErrPermission)
+
+	err = routeCommandError("Error: inet prefix is expected rather than \"nope\".", exitErr)
+	assert.False(t, errors.Is(err, ErrRouteExists) || errors.Is(err, ErrRouteNotFound) || errors.Is(err, os.ErrPermission))
+	assert.ErrorIs(t, err, exitErr)
+	assert.Same(t, exitErr, routeCommandError("", exitErr))
+}
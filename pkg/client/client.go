@@ -0,0 +1,377 @@
+// Package client is the Go client of the split-the-tunnel daemon. It wraps the RouteManager gRPC service on the unix
+// socket of the daemon with the methods that take and return plain Go types:
+//
+//	c, err := client.New(client.Options{})
+//	if err != nil {
+//		return err
+//	}
+//
+//	defer c.Close()
+//
+//	ips, err := c.Add(ctx, "example.com", client.AddOptions{TTL: time.Hour})
+//
+// The errors are returned as *Error, IsCode tells the business errors of the daemon apart.
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/paths"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/version"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// DefaultTimeout is the deadline of the calls that come without one, unless Options.Timeout is set
+	DefaultTimeout = 30 * time.Second
+	// DefaultRetries is the number of the retries of the read-only calls that cannot reach the daemon, unless
+	// Options.Retries is set
+	DefaultRetries = 3
+	// retryBackoff is the wait before the first retry, it doubles on every retry
+	retryBackoff = 200 * time.Millisecond
+)
+
+// Options are the options of a Client, the zero value connects to the daemon of the system with the defaults
+type Options struct {
+	// SocketPath is the path of the gRPC socket of the daemon. It is resolved from Workspace if it is empty, then from
+	// the STT_GRPC_SOCKET_PATH and STT_WORKSPACE environment variables and then from the system-wide default like the
+	// CLI does
+	SocketPath string
+	// Workspace is the workspace directory of the daemon, see the --workspace flag of the daemon
+	Workspace string
+	// Timeout is the deadline of the calls whose context has none, DefaultTimeout is used if it is 0 and a negative
+	// value disables it. Watch is never given a deadline
+	Timeout time.Duration
+	// Retries is the number of the retries of the read-only calls when the daemon cannot be reached, such as while it
+	// is restarting. DefaultRetries is used if it is 0 and a negative value disables the retries. The calls that
+	// change the routes are never retried, they might have been applied
+	Retries int
+	// UserAgent identifies the client in the logs of the daemon, such as my-tool/v1.0.0
+	UserAgent string
+}
+
+// socketPath resolves the path of the gRPC socket of the daemon
+func (o Options) socketPath() string {
+	if o.SocketPath != "" {
+		return o.SocketPath
+	}
+
+	if o.Workspace != "" {
+		return filepath.Join(o.Workspace, constants.GrpcSocketFileName)
+	}
+
+	if socketPath := os.Getenv(paths.EnvName("grpc-socket-path")); socketPath != "" {
+		return socketPath
+	}
+
+	return paths.Resolve(os.Getenv(paths.EnvName("workspace"))).GrpcSocketPath
+}
+
+// Client is a client of the split-the-tunnel daemon, it is safe for concurrent use
+type Client struct {
+	conn       *grpc.ClientConn
+	rm         pb.RouteManagerClient
+	socketPath string
+	timeout    time.Duration
+	retries    int
+}
+
+// New returns a Client of the daemon with the given Options. The connection is established lazily on the first call,
+// so New does not fail if the daemon is not running yet
+func New(opts Options) (*Client, error) {
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = "stt-client/" + version.Get().GitVersion
+	}
+
+	c := &Client{socketPath: opts.socketPath(), timeout: opts.Timeout, retries: opts.Retries}
+	if c.timeout == 0 {
+		c.timeout = DefaultTimeout
+	}
+
+	if c.retries == 0 {
+		c.retries = DefaultRetries
+	}
+
+	conn, err := grpc.NewClient("unix://"+c.socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, errors.Wrap(err, constants.FailedToConnectToDaemon)
+	}
+
+	c.conn = conn
+	c.rm = pb.NewRouteManagerClient(conn)
+
+	return c, nil
+}
+
+// Close closes the connection of the Client
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// SocketPath returns the resolved path of the gRPC socket of the daemon
+func (c *Client) SocketPath() string {
+	return c.socketPath
+}
+
+// withTimeout returns the given context with the deadline of the Client if it has none
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.timeout < 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// retry calls the given read-only call until it reaches the daemon, the context or the retries of the Client run out
+func (c *Client) retry(ctx context.Context, call func() error) error {
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= c.retries || !isUnreachable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+// AddOptions are the options of Add
+type AddOptions struct {
+	// TTL makes the route temporary, it is removed after the given duration. Zero means permanent
+	TTL time.Duration
+	// Accumulate keeps the IPs that are resolved within the accumulation window of the daemon routed, for the
+	// destinations behind DNS round-robin
+	Accumulate bool
+}
+
+// Add routes the given domain, IP address or CIDR block outside the VPN and returns its routed IPs
+func (c *Client) Add(ctx context.Context, destination string, opts AddOptions) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req := &pb.AddRouteRequest{Destination: destination, Accumulate: opts.Accumulate}
+	if opts.TTL != 0 {
+		req.Ttl = durationpb.New(opts.TTL)
+	}
+
+	resp, err := c.rm.AddRoute(ctx, req)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return resp.GetPayload().GetIps(), nil
+}
+
+// Remove removes the routes of the given destination and returns the IPs whose routes are removed
+func (c *Client) Remove(ctx context.Context, destination string) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.rm.RemoveRoute(ctx, &pb.RemoveRouteRequest{Destination: destination})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return resp.GetPayload().GetIps(), nil
+}
+
+// List returns the routed destinations
+func (c *Client) List(ctx context.Context) ([]*Route, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.ListRoutesResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.ListRoutes(ctx, &pb.ListRoutesRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	routes := make([]*Route, 0, len(resp.GetPayload().GetRoutes()))
+	for _, route := range resp.GetPayload().GetRoutes() {
+		routes = append(routes, newRoute(route))
+	}
+
+	return routes, nil
+}
+
+// Get returns the given destination, the error has the CodeRouteNotFound code if it is not routed
+func (c *Client) Get(ctx context.Context, destination string) (*Route, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.GetRouteResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.GetRoute(ctx, &pb.GetRouteRequest{Destination: destination})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return newRoute(resp.GetPayload().GetRoute()), nil
+}
+
+// UpdateOptions are the options of Update, the fields that are nil are left as they are
+type UpdateOptions struct {
+	// TTL replaces the expiry of the route with the given duration from now, zero makes it permanent
+	TTL *time.Duration
+	// Accumulate replaces the accumulate mode of the route
+	Accumulate *bool
+}
+
+// Update changes the expiry and the accumulate mode of the given destination without resolving it again, and returns
+// the updated route. The error has the CodeRouteNotFound code if it is not routed
+func (c *Client) Update(ctx context.Context, destination string, opts UpdateOptions) (*Route, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req := &pb.UpdateRouteRequest{Destination: destination}
+	if opts.TTL != nil {
+		req.Ttl = durationpb.New(*opts.TTL)
+	}
+
+	if opts.Accumulate != nil {
+		req.Accumulate = wrapperspb.Bool(*opts.Accumulate)
+	}
+
+	resp, err := c.rm.UpdateRoute(ctx, req)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return newRoute(resp.GetPayload().GetRoute()), nil
+}
+
+// Purge removes every destination and returns their results in the order that they are purged. A destination that
+// cannot be removed has its Err set, the returned error is about the call itself
+func (c *Client) Purge(ctx context.Context) ([]*PurgedRoute, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.rm.Purge(ctx, &pb.PurgeRequest{})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	purged := make([]*PurgedRoute, 0, len(resp.GetPayload().GetRoutes()))
+	for _, route := range resp.GetPayload().GetRoutes() {
+		purged = append(purged, newPurgedRoute(route))
+	}
+
+	return purged, nil
+}
+
+// Status returns the status of the daemon
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.StatusResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.Status(ctx, &pb.StatusRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return newStatus(resp.GetPayload()), nil
+}
+
+// Trace resolves the given host the same way as the destinations and explains whether the traffic to its IPs bypasses
+// VPN, the error has the CodeResolveFailed code if the host cannot be resolved
+func (c *Client) Trace(ctx context.Context, host string) (*Trace, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.TraceRouteResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.TraceRoute(ctx, &pb.TraceRouteRequest{Host: host})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return newTrace(resp.GetPayload()), nil
+}
+
+// Export returns the routed destinations as a list in the given format, JSON if it is ListFormatAuto
+func (c *Client) Export(ctx context.Context, format ListFormat) (string, error) {
+	pbFormat, err := listFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.ExportRoutesResponse
+	err = c.retry(ctx, func() (err error) {
+		resp, err = c.rm.ExportRoutes(ctx, &pb.ExportRoutesRequest{Format: pbFormat})
+		return err
+	})
+	if err != nil {
+		return "", DecodeError(err)
+	}
+
+	return resp.GetPayload().GetContent(), nil
+}
+
+// ImportOptions are the options of Import
+type ImportOptions struct {
+	// Format is the format of the list, it is detected by the daemon if it is ListFormatAuto
+	Format ListFormat
+	// Replace removes the destinations that are not in the list, except for the ones of the config file
+	Replace bool
+	// DryRun returns the actions that Import would take without taking them
+	DryRun bool
+}
+
+// Import adds the destinations of the given list that are not routed yet and returns the result of every line
+func (c *Client) Import(ctx context.Context, content string, opts ImportOptions) ([]*ImportedRoute, error) {
+	format, err := listFormat(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req := &pb.ImportRoutesRequest{Format: format, Content: content, Mode: pb.ImportMode_IMPORT_MODE_MERGE, DryRun: opts.DryRun}
+	if opts.Replace {
+		req.Mode = pb.ImportMode_IMPORT_MODE_REPLACE
+	}
+
+	resp, err := c.rm.ImportRoutes(ctx, req)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	imported := make([]*ImportedRoute, 0, len(resp.GetPayload().GetRoutes()))
+	for _, route := range resp.GetPayload().GetRoutes() {
+		imported = append(imported, newImportedRoute(route))
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/events"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/logging"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/paths"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/preset"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/server"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/state"
+	"github.com/bilalcaliskan/split-the-tunnel/internal/testutil"
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// serve serves the gRPC server of the daemon with the given state.State and events.Bus on the given socket
+func serve(t *testing.T, socketPath string, st *state.State, bus *events.Bus) {
+	lis, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer, _ := server.NewGRPCServer(server.NewServer(st, bus, logging.GetLogger()), server.GRPCOptions{}, logging.GetLogger())
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+}
+
+// newTestClient serves the gRPC server of the daemon with the given entries in a temporary workspace and returns a
+// Client of it
+func newTestClient(t *testing.T, bus *events.Bus, entries ...*state.RouteEntry) *Client {
+	workspace := t.TempDir()
+	st := state.NewState(logging.GetLogger(), filepath.Join(workspace, constants.StateFileName))
+	for _, entry := range entries {
+		if err := st.AddEntry(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	serve(t, filepath.Join(workspace, constants.GrpcSocketFileName), st, bus)
+
+	c, err := New(Options{Workspace: workspace})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestOptions_SocketPath(t *testing.T) {
+	t.Setenv("STT_GRPC_SOCKET_PATH", "")
+	t.Setenv("STT_WORKSPACE", "")
+	assert.Equal(t, paths.Resolve("").GrpcSocketPath, Options{}.socketPath())
+
+	t.Setenv("STT_WORKSPACE", "/tmp/env-workspace")
+	assert.Equal(t, "/tmp/env-workspace/grpc.sock", Options{}.socketPath())
+
+	t.Setenv("STT_GRPC_SOCKET_PATH", "/tmp/env.sock")
+	assert.Equal(t, "/tmp/env.sock", Options{}.socketPath())
+
+	assert.Equal(t, "/tmp/workspace/grpc.sock", Options{Workspace: "/tmp/workspace"}.socketPath())
+	assert.Equal(t, "/tmp/grpc.sock", Options{SocketPath: "/tmp/grpc.sock", Workspace: "/tmp/workspace"}.socketPath())
+}
+
+func TestClient(t *testing.T) {
+	failed := state.NewRouteEntry("example.org", "192.168.1.1", []string{"2.2.2.2"})
+	failed.Routes = []*state.IPRoute{{IP: "2.2.2.2", Status: state.IPStatusFailed, LastError: "exit status 2"}}
+	c := newTestClient(t, nil, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}), failed)
+	ctx := context.Background()
+
+	routes, err := c.List(ctx)
+	if assert.NoError(t, err) && assert.Len(t, routes, 2) {
+		assert.Equal(t, "example.com", routes[0].Destination)
+		assert.Equal(t, []string{"1.1.1.1"}, routes[0].RoutedIPs)
+		assert.Nil(t, routes[0].ExpiresAt)
+	}
+
+	route, err := c.Get(ctx, "example.org")
+	if assert.NoError(t, err) && assert.Len(t, route.IPs, 1) {
+		assert.Equal(t, IPStatusFailed, route.IPs[0].Status)
+		assert.Equal(t, "exit status 2", route.IPs[0].LastError)
+	}
+
+	_, err = c.Get(ctx, "example.net")
+	assert.True(t, IsCode(err, CodeRouteNotFound), "unexpected error: %v", err)
+	assert.Equal(t, codes.NotFound, err.(*Error).GRPCCode)
+
+	_, err = c.Add(ctx, "example.net", AddOptions{TTL: -time.Minute})
+	if assert.Error(t, err) {
+		assert.True(t, IsCode(err, CodeInvalidTTL), "unexpected error: %v", err)
+		assert.Equal(t, codes.InvalidArgument, err.(*Error).GRPCCode)
+		assert.Equal(t, []FieldViolation{{Field: "ttl", Description: "TTL cannot be negative"}}, err.(*Error).Violations)
+	}
+
+	status, err := c.Status(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, status.Routes)
+		assert.Equal(t, 1, status.FailedIPs)
+		assert.Equal(t, 2, status.ActiveRoutes)
+		assert.Nil(t, status.LastRefresh)
+		assert.False(t, status.StartedAt.IsZero())
+	}
+
+	_, err = c.Trace(ctx, "")
+	assert.True(t, IsCode(err, CodeInvalidDestination), "unexpected error: %v", err)
+
+	imported, err := c.Import(ctx, "example.com\nexample.com\n", ImportOptions{DryRun: true})
+	if assert.NoError(t, err) && assert.Len(t, imported, 2) {
+		assert.Equal(t, ImportActionExists, imported[0].Action)
+		assert.Equal(t, ImportActionDuplicate, imported[1].Action)
+		assert.Equal(t, 2, imported[1].Line)
+	}
+
+	_, err = c.Export(ctx, ListFormatHosts)
+	assert.True(t, IsCode(err, CodeInvalidRouteList), "unexpected error: %v", err)
+
+	exported, err := c.Export(ctx, ListFormatText)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com\nexample.org\n", exported)
+
+	_, err = c.Export(ctx, "xml")
+	if assert.Error(t, err) {
+		assert.Equal(t, codes.InvalidArgument, err.(*Error).GRPCCode)
+		assert.False(t, err.(*Error).Business())
+	}
+}
+
+func TestClient_Update(t *testing.T) {
+	c := newTestClient(t, nil, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}))
+	ctx := context.Background()
+
+	ttl, accumulate := time.Hour, true
+	route, err := c.Update(ctx, "example.com", UpdateOptions{TTL: &ttl, Accumulate: &accumulate})
+	if assert.NoError(t, err) {
+		assert.NotNil(t, route.ExpiresAt)
+		assert.True(t, route.Accumulate)
+	}
+
+	// the options that are not set are left as they are
+	var permanent time.Duration
+	route, err = c.Update(ctx, "example.com", UpdateOptions{TTL: &permanent})
+	if assert.NoError(t, err) {
+		assert.Nil(t, route.ExpiresAt)
+		assert.True(t, route.Accumulate)
+	}
+
+	_, err = c.Update(ctx, "example.net", UpdateOptions{TTL: &ttl})
+	assert.True(t, IsCode(err, CodeRouteNotFound), "unexpected error: %v", err)
+}
+
+func TestClient_Groups(t *testing.T) {
+	testutil.FakeRouteCommands(t)
+	c := newTestClient(t, nil, state.NewRouteEntry("example.com", "192.168.1.1", []string{"1.1.1.1"}))
+	ctx := context.Background()
+
+	assert.NoError(t, c.CreateGroup(ctx, "work"))
+	assert.True(t, IsCode(c.CreateGroup(ctx, "work"), CodeGroupAlreadyExists))
+	assert.NoError(t, c.AddToGroup(ctx, "work", "example.com"))
+	assert.NoError(t, c.DisableGroup(ctx, "work"))
+
+	groups, err := c.ListGroups(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*Group{{Name: "work", Enabled: false, Destinations: []string{"example.com"}}}, groups)
+	}
+
+	err = c.EnableGroup(ctx, "missing")
+	assert.True(t, IsCode(err, CodeGroupNotFound), "unexpected error: %v", err)
+}
+
+func TestClient_Presets(t *testing.T) {
+	c := newTestClient(t, nil)
+	ctx := context.Background()
+
+	catalog, err := c.ListPresets(ctx)
+	if assert.NoError(t, err) && assert.NotEmpty(t, catalog.Presets) {
+		assert.Equal(t, preset.Builtin().Version, catalog.Version)
+		assert.Equal(t, preset.Builtin().Get(catalog.Presets[0].Name).Destinations, catalog.Presets[0].Destinations)
+		assert.False(t, catalog.Presets[0].Enabled)
+	}
+
+	_, err = c.DisablePreset(ctx, "zoom")
+	assert.True(t, IsCode(err, CodePresetNotFound), "unexpected error: %v", err)
+}
+
+func TestClient_Watch(t *testing.T) {
+	bus := events.NewBus(10)
+	c := newTestClient(t, bus)
+
+	bus.Publish(&events.Event{Type: events.EntryAdded, Domain: "example.com"})
+	bus.Publish(&events.Event{Type: events.IPsChanged, Domain: "example.com", AddedIPs: []string{"1.1.1.1"}})
+
+	_, err := c.Watch(context.Background(), WatchOptions{Types: []EventType{"dns-changed"}})
+	assert.Error(t, err)
+
+	w, err := c.Watch(context.Background(), WatchOptions{Types: []EventType{EventIPsChanged}, Replay: 5})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	e, err := w.Next()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(2), e.Sequence)
+		assert.Equal(t, EventIPsChanged, e.Type)
+		assert.Equal(t, []string{"1.1.1.1"}, e.AddedIPs)
+	}
+
+	w.Close()
+	_, err = w.Next()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_Retry(t *testing.T) {
+	workspace := t.TempDir()
+	c, err := New(Options{Workspace: workspace, Retries: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	// daemon is not running, the failure to reach it is not a business error
+	_, err = c.List(context.Background())
+	if assert.Error(t, err) {
+		assert.Equal(t, codes.Unavailable, err.(*Error).GRPCCode)
+		assert.False(t, err.(*Error).Business())
+	}
+
+	c.retries = 6
+	time.AfterFunc(300*time.Millisecond, func() {
+		serve(t, filepath.Join(workspace, constants.GrpcSocketFileName),
+			state.NewState(logging.GetLogger(), filepath.Join(workspace, constants.StateFileName)), nil)
+	})
+
+	// daemon starts while the client is retrying
+	routes, err := c.List(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, routes)
+}
+
+func TestEnums(t *testing.T) {
+	// every business error code of the proto has a Code
+	all := []Code{CodeInvalidDestination, CodeRouteNotFound, CodeRouteAlreadyExists, CodeGroupNotFound,
+		CodeGroupAlreadyExists, CodeInvalidGroup, CodeInternalError, CodeResolveFailed, CodeRouteFailed,
+		CodeGatewayNotFound, CodePermissionDenied, CodeStateWriteFailed, CodeInvalidRouteList, CodePresetNotFound,
+		CodeInvalidTTL}
+	assert.Len(t, all, len(pb.StatusCode_name)-1)
+	for _, code := range all {
+		value, ok := pb.StatusCode_value[string(code)]
+		if assert.True(t, ok, code) {
+			assert.Equal(t, code, newCode(pb.StatusCode(value)))
+		}
+	}
+
+	assert.Empty(t, newCode(pb.StatusCode_STATUS_UNSPECIFIED))
+
+	for format, expected := range map[ListFormat]pb.RouteListFormat{
+		ListFormatAuto:  pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED,
+		ListFormatJSON:  pb.RouteListFormat_ROUTE_LIST_FORMAT_JSON,
+		ListFormatText:  pb.RouteListFormat_ROUTE_LIST_FORMAT_TEXT,
+		ListFormatCSV:   pb.RouteListFormat_ROUTE_LIST_FORMAT_CSV,
+		ListFormatHosts: pb.RouteListFormat_ROUTE_LIST_FORMAT_HOSTS,
+	} {
+		actual, err := listFormat(format)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, format)
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bilalcaliskan/split-the-tunnel/internal/constants"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FieldViolation is an invalid field of a request
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is an error of a call, either returned by the daemon or a failure to reach it
+type Error struct {
+	// Code is the business error code of the daemon, empty for the errors that are not returned by the daemon itself,
+	// such as the connection failures
+	Code Code
+	// GRPCCode is the standard gRPC code of the error
+	GRPCCode codes.Code
+	Message  string
+	// Metadata is the subject of the failed request, such as the destination or the group
+	Metadata map[string]string
+	// Violations are the invalid fields of the request
+	Violations []FieldViolation
+}
+
+// DecodeError decodes the status and the details of the given gRPC error into an *Error, it returns nil for nil error
+func DecodeError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	e := &Error{GRPCCode: st.Code(), Message: st.Message()}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == constants.ErrorDomain {
+				e.Code = Code(d.GetReason())
+				e.Metadata = d.GetMetadata()
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				e.Violations = append(e.Violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+
+	return e
+}
+
+func (e *Error) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Message
+	}
+
+	fields := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		fields = append(fields, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(fields, ", "))
+}
+
+// Business returns true if the error is returned by the daemon for the request, rather than a failure to reach it
+func (e *Error) Business() bool {
+	return e.Code != ""
+}
+
+// IsCode reports whether the given error is an *Error with the given business error code
+func IsCode(err error, code Code) bool {
+	var e *Error
+
+	return errors.As(err, &e) && e.Code == code
+}
+
+// isUnreachable reports whether the given gRPC error is a failure to reach the daemon, the UNAVAILABLE errors of the
+// daemon itself such as GATEWAY_NOT_FOUND come with a business error code
+func isUnreachable(err error) bool {
+	return status.Code(err) == codes.Unavailable && !DecodeError(err).Business()
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+)
+
+// CreateGroup creates a new enabled group with the given name, the error has the CodeGroupAlreadyExists code if it
+// exists
+func (c *Client) CreateGroup(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.rm.CreateGroup(ctx, &pb.CreateGroupRequest{Name: name}); err != nil {
+		return DecodeError(err)
+	}
+
+	return nil
+}
+
+// AddToGroup adds the given destinations to the group with the given name, the routed ones are moved into the group
+func (c *Client) AddToGroup(ctx context.Context, name string, destinations ...string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.rm.AddToGroup(ctx, &pb.AddToGroupRequest{Name: name, Destinations: destinations}); err != nil {
+		return DecodeError(err)
+	}
+
+	return nil
+}
+
+// EnableGroup installs the routes of the destinations of the group with the given name
+func (c *Client) EnableGroup(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.rm.EnableGroup(ctx, &pb.EnableGroupRequest{Name: name}); err != nil {
+		return DecodeError(err)
+	}
+
+	return nil
+}
+
+// DisableGroup removes the routes of the destinations of the group with the given name, the destinations are kept
+func (c *Client) DisableGroup(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.rm.DisableGroup(ctx, &pb.DisableGroupRequest{Name: name}); err != nil {
+		return DecodeError(err)
+	}
+
+	return nil
+}
+
+// ListGroups returns the groups with their destinations
+func (c *Client) ListGroups(ctx context.Context) ([]*Group, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.ListGroupsResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.ListGroups(ctx, &pb.ListGroupsRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	groups := make([]*Group, 0, len(resp.GetPayload().GetGroups()))
+	for _, group := range resp.GetPayload().GetGroups() {
+		groups = append(groups, &Group{Name: group.GetName(), Enabled: group.GetEnabled(), Destinations: group.GetDestinations()})
+	}
+
+	return groups, nil
+}
+
+// ListPresets returns the preset catalog of the daemon with the enabled presets marked
+func (c *Client) ListPresets(ctx context.Context) (*PresetCatalog, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *pb.ListPresetsResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.rm.ListPresets(ctx, &pb.ListPresetsRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	catalog := &PresetCatalog{
+		Version: int(resp.GetPayload().GetCatalogVersion()),
+		Presets: make([]*Preset, 0, len(resp.GetPayload().GetPresets())),
+	}
+
+	for _, p := range resp.GetPayload().GetPresets() {
+		catalog.Presets = append(catalog.Presets, &Preset{
+			Name:         p.GetName(),
+			Description:  p.GetDescription(),
+			Destinations: p.GetDestinations(),
+			Enabled:      p.GetEnabled(),
+			Overridden:   p.GetOverridden(),
+		})
+	}
+
+	return catalog, nil
+}
+
+// EnablePreset expands the preset with the given name into its managed group, an enabled preset is synced to the
+// catalog again. The error has the CodePresetNotFound code if the catalog does not have it
+func (c *Client) EnablePreset(ctx context.Context, name string) (*PresetChange, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.rm.EnablePreset(ctx, &pb.EnablePresetRequest{Name: name})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return &PresetChange{
+		Added:      resp.GetPayload().GetAdded(),
+		Removed:    resp.GetPayload().GetRemoved(),
+		Unresolved: resp.GetPayload().GetUnresolved(),
+	}, nil
+}
+
+// DisablePreset removes the destinations of the preset with the given name together with its managed group. The error
+// has the CodePresetNotFound code if the preset is not enabled
+func (c *Client) DisablePreset(ctx context.Context, name string) (*PresetChange, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.rm.DisablePreset(ctx, &pb.DisablePresetRequest{Name: name})
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+
+	return &PresetChange{Removed: resp.GetPayload().GetRemoved()}, nil
+}
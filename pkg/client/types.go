@@ -0,0 +1,491 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"google.golang.org/grpc/codes"
+)
+
+// IPStatus is the status of the route of a single IP of a Route
+type IPStatus string
+
+const (
+	IPStatusInstalled IPStatus = "installed"
+	IPStatusPending   IPStatus = "pending"
+	IPStatusFailed    IPStatus = "failed"
+	IPStatusRemoved   IPStatus = "removed"
+)
+
+// EventType is the type of an Event
+type EventType string
+
+const (
+	EventEntryAdded     EventType = "entry-added"
+	EventEntryRemoved   EventType = "entry-removed"
+	EventIPsChanged     EventType = "ips-changed"
+	EventRouteFailed    EventType = "route-failed"
+	EventGatewayChanged EventType = "gateway-changed"
+	EventConfigReloaded EventType = "config-reloaded"
+)
+
+// TracePath is the path of the traffic to a TracedIP
+type TracePath string
+
+const (
+	// TracePathBypass is the traffic that leaves over the non-VPN gateway
+	TracePathBypass TracePath = "bypass"
+	// TracePathVPN is the traffic that leaves over any other interface, such as the tunnel of the VPN
+	TracePathVPN TracePath = "vpn"
+	// TracePathUnreachable is the traffic that the kernel has no route for
+	TracePathUnreachable TracePath = "unreachable"
+)
+
+// ImportAction is what Import does with a single ImportedRoute
+type ImportAction string
+
+const (
+	ImportActionAdd       ImportAction = "add"
+	ImportActionExists    ImportAction = "exists"
+	ImportActionRemove    ImportAction = "remove"
+	ImportActionDuplicate ImportAction = "duplicate"
+	ImportActionInvalid   ImportAction = "invalid"
+)
+
+// SyncStatus is the outcome of the last sync of a Subscription
+type SyncStatus string
+
+const (
+	SyncStatusOK          SyncStatus = "ok"
+	SyncStatusNotModified SyncStatus = "not-modified"
+	SyncStatusFailed      SyncStatus = "failed"
+)
+
+// ListFormat is the format of the lists of Export and Import
+type ListFormat string
+
+const (
+	// ListFormatAuto is JSON for Export, and the format that the daemon detects from the content for Import
+	ListFormatAuto  ListFormat = ""
+	ListFormatJSON  ListFormat = "json"
+	ListFormatText  ListFormat = "text"
+	ListFormatCSV   ListFormat = "csv"
+	ListFormatHosts ListFormat = "hosts"
+)
+
+// Code is the business error code of the daemon, it is the name of the StatusCode of the proto
+type Code string
+
+const (
+	CodeInvalidDestination Code = "INVALID_DESTINATION"
+	CodeRouteNotFound      Code = "ROUTE_NOT_FOUND"
+	CodeRouteAlreadyExists Code = "ROUTE_ALREADY_EXISTS"
+	CodeGroupNotFound      Code = "GROUP_NOT_FOUND"
+	CodeGroupAlreadyExists Code = "GROUP_ALREADY_EXISTS"
+	CodeInvalidGroup       Code = "INVALID_GROUP"
+	CodeInternalError      Code = "INTERNAL_ERROR"
+	CodeResolveFailed      Code = "RESOLVE_FAILED"
+	CodeRouteFailed        Code = "ROUTE_FAILED"
+	CodeGatewayNotFound    Code = "GATEWAY_NOT_FOUND"
+	CodePermissionDenied   Code = "PERMISSION_DENIED"
+	CodeStateWriteFailed   Code = "STATE_WRITE_FAILED"
+	CodeInvalidRouteList   Code = "INVALID_ROUTE_LIST"
+	CodePresetNotFound     Code = "PRESET_NOT_FOUND"
+	CodeInvalidTTL         Code = "INVALID_TTL"
+)
+
+const (
+	// ipStatusPrefix, eventTypePrefix, tracePathPrefix, importActionPrefix, syncStatusPrefix and listFormatPrefix are
+	// the prefixes of the names of the enum values in the proto
+	ipStatusPrefix     = "ROUTE_IP_STATUS_"
+	eventTypePrefix    = "ROUTE_EVENT_TYPE_"
+	tracePathPrefix    = "TRACE_PATH_"
+	importActionPrefix = "IMPORT_ACTION_"
+	syncStatusPrefix   = "SUBSCRIPTION_SYNC_STATUS_"
+	listFormatPrefix   = "ROUTE_LIST_FORMAT_"
+)
+
+// listFormat converts the given ListFormat into a pb.RouteListFormat
+func listFormat(format ListFormat) (pb.RouteListFormat, error) {
+	if format == ListFormatAuto {
+		return pb.RouteListFormat_ROUTE_LIST_FORMAT_UNSPECIFIED, nil
+	}
+
+	value, ok := pb.RouteListFormat_value[listFormatPrefix+strings.ToUpper(string(format))]
+	if !ok {
+		return 0, &Error{GRPCCode: codes.InvalidArgument, Message: fmt.Sprintf("unknown list format %q", format)}
+	}
+
+	return pb.RouteListFormat(value), nil
+}
+
+// newCode converts the given pb.StatusCode into a Code
+func newCode(code pb.StatusCode) Code {
+	if code == pb.StatusCode_STATUS_UNSPECIFIED {
+		return ""
+	}
+
+	return Code(code.String())
+}
+
+// enumName returns the kebab-case name of the given enum value name without its prefix, such as ips-changed for
+// ROUTE_EVENT_TYPE_IPS_CHANGED
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, prefix), "_", "-"))
+}
+
+// Route is a destination that bypasses VPN
+type Route struct {
+	// Destination is the domain, IP address or CIDR block
+	Destination string
+	Gateway     string
+	// IPs are the statuses of the routes of the IPs of the destination
+	IPs []*RouteIP
+	// RoutedIPs are the IPs whose routes are installed
+	RoutedIPs  []string
+	Accumulate bool
+	// Group is the name of the group of the destination, empty if it is not grouped
+	Group string
+	// ExpiresAt is the time that the destination is removed automatically, nil if it is permanent
+	ExpiresAt *time.Time
+	// Source is the origin of the destination, such as config for the ones declared in the config file
+	Source string
+	// Active is false if the routes of the destination are not installed since its group is disabled
+	Active bool
+	// Tags and Comment are the labels and the note of the destination, they are set on the imported destinations
+	Tags    []string
+	Comment string
+}
+
+// RouteIP is the status of the route of a single IP of a Route
+type RouteIP struct {
+	IP     string
+	Status IPStatus
+	// LastError is the error of the last failed operation on the route
+	LastError string
+	// Attempts is the number of the consecutive failed attempts to install the route
+	Attempts  int
+	FirstSeen time.Time
+	LastSeen  time.Time
+	// Resolvers are the DNS servers that returned the IP on the last resolution of the destination
+	Resolvers []string
+}
+
+// PurgedRoute is the result of purging a single destination
+type PurgedRoute struct {
+	Destination string
+	// IPs are the IPs whose routes are removed, or the ones that are still routed if Err is set
+	IPs []string
+	// Err is the error of the destination if it could not be purged
+	Err *Error
+}
+
+// ImportedRoute is the result of importing a single destination of a list
+type ImportedRoute struct {
+	Destination string
+	Action      ImportAction
+	// Line is the line of the destination in the list, zero for the removed destinations that are not listed
+	Line int
+	// IPs are the routed IPs of the added destinations, or the IPs of the removed ones
+	IPs []string
+	// Reason explains the duplicate and the invalid lines
+	Reason string
+	// Err is the error of the destination if it could not be added or removed
+	Err *Error
+}
+
+// Status is the status of the daemon
+type Status struct {
+	Version   string
+	GitCommit string
+	StartedAt time.Time
+	Uptime    time.Duration
+	// Gateway is the detected default non-VPN gateway, empty if it cannot be detected
+	Gateway string
+	// GatewayInterface is the network interface of Gateway
+	GatewayInterface string
+	// Backend is the mechanism that changes the routing table
+	Backend string
+	// RouteMode is how the route failures of a single destination are handled
+	RouteMode string
+	Routes    int
+	Groups    int
+	// FailedIPs is the number of the IPs whose routes could not be installed
+	FailedIPs int
+	// ActiveRoutes is the number of the routes that are installed, the others belong to disabled groups
+	ActiveRoutes int
+	// TemporaryRoutes is the number of the routes that expire
+	TemporaryRoutes int
+	// LastRefresh is the last time that the IPs of the routes are checked for changes, nil if they are not yet
+	LastRefresh *time.Time
+	// DNSServers are the DNS servers that the daemon resolves the destinations with, system for the system resolver
+	DNSServers []string
+	// Subscriptions are the outcomes of the last syncs of the subscribed lists
+	Subscriptions []*Subscription
+}
+
+// Subscription is the outcome of the last sync of a subscribed list
+type Subscription struct {
+	Name string
+	URL  string
+	// Group is the managed group that holds the destinations of the list
+	Group  string
+	Status SyncStatus
+	// LastSync is the time of the last attempt, LastSuccess is the last time that the list is fetched or found
+	// unchanged, they are nil if the list is not synced yet
+	LastSync    *time.Time
+	LastSuccess *time.Time
+	// LastError is the error of the last attempt, empty if it succeeded
+	LastError string
+	// Destinations is the number of the valid destinations of the list, Invalid is the number of its invalid lines
+	Destinations int
+	Invalid      int
+}
+
+// Trace is the path of the traffic to the IPs of a host
+type Trace struct {
+	Host string
+	// Route is the destination that covers the host, nil if none does
+	Route *Route
+	// Gateway is the detected default non-VPN gateway, empty if it cannot be detected
+	Gateway string
+	IPs     []*TracedIP
+}
+
+// TracedIP is the path of the traffic to a single IP of a Trace
+type TracedIP struct {
+	IP string
+	// Resolvers are the DNS servers that returned the IP, empty if the host is an IP
+	Resolvers []string
+	// Routed is true if the daemon installed a route for the IP
+	Routed bool
+	Path   TracePath
+	// Interface, Gateway and Source are the outgoing interface, the next hop and the source address that the kernel
+	// picks, Gateway is empty on a directly connected network
+	Interface string
+	Gateway   string
+	Source    string
+	// Table is the routing table that the kernel finds the route in, such as main
+	Table string
+	// Rule is the policy routing rule that selects Table, in the format of ip rule
+	Rule string
+	// KernelRoute is the most specific route of the IP in the main routing table
+	KernelRoute string
+	// Reason explains Path, such as the missing destination or the route of the VPN that shadows the one of Route
+	Reason string
+}
+
+// Group is a named set of destinations that are enabled and disabled together
+type Group struct {
+	Name string
+	// Enabled is false if the routes of the destinations of the group are not installed
+	Enabled      bool
+	Destinations []string
+}
+
+// PresetCatalog is the catalog of the presets of the daemon
+type PresetCatalog struct {
+	// Version is the version of the built-in catalog, the local overrides do not change it
+	Version int
+	Presets []*Preset
+}
+
+// Preset is a named set of the destinations of a common service that is expanded into a managed group when it is
+// enabled
+type Preset struct {
+	Name        string
+	Description string
+	// Destinations are the hostnames, IP addresses and CIDR blocks of the preset
+	Destinations []string
+	Enabled      bool
+	// Overridden is true if the preset is added or patched by a local override file
+	Overridden bool
+}
+
+// PresetChange is the outcome of enabling or disabling a preset
+type PresetChange struct {
+	// Added are the destinations that are added to the managed group, Removed are the ones that are removed from it
+	Added   []string
+	Removed []string
+	// Unresolved are the destinations that could not be resolved or routed yet, they are tried again on every refresh
+	Unresolved []string
+}
+
+// Event is a change on the routes or the state of the daemon
+type Event struct {
+	// Sequence increases by one on every event of the daemon, gaps mean that the events are filtered out
+	Sequence uint64
+	Type     EventType
+	Time     time.Time
+	// Destination is empty for the events that are not about a single destination
+	Destination string
+	// IPs are the routed IPs of the destination, or the failed ones for EventRouteFailed
+	IPs        []string
+	AddedIPs   []string
+	RemovedIPs []string
+	// Gateway is the gateway of the destination, or the new gateway for EventGatewayChanged
+	Gateway         string
+	PreviousGateway string
+	Error           string
+}
+
+// newRoute converts the given pb.Route into a Route
+func newRoute(route *pb.Route) *Route {
+	r := &Route{
+		Destination: route.GetDomain(),
+		Gateway:     route.GetGateway(),
+		RoutedIPs:   route.GetRoutedIps(),
+		Accumulate:  route.GetAccumulate(),
+		Group:       route.GetGroup(),
+		Source:      route.GetSource(),
+		Active:      route.GetActive(),
+		Tags:        route.GetTags(),
+		Comment:     route.GetComment(),
+	}
+
+	if route.GetExpiresAt() != nil {
+		expiresAt := route.GetExpiresAt().AsTime()
+		r.ExpiresAt = &expiresAt
+	}
+
+	for _, ip := range route.GetIps() {
+		r.IPs = append(r.IPs, &RouteIP{
+			IP:        ip.GetIp(),
+			Status:    IPStatus(enumName(ip.GetStatus().String(), ipStatusPrefix)),
+			LastError: ip.GetLastError(),
+			Attempts:  int(ip.GetAttempts()),
+			FirstSeen: ip.GetFirstSeen().AsTime(),
+			LastSeen:  ip.GetLastSeen().AsTime(),
+			Resolvers: ip.GetResolvers(),
+		})
+	}
+
+	return r
+}
+
+// newPurgedRoute converts the given pb.PurgedRoute into a PurgedRoute
+func newPurgedRoute(route *pb.PurgedRoute) *PurgedRoute {
+	purged := &PurgedRoute{Destination: route.GetDestination(), IPs: route.GetIps()}
+	if pbErr := route.GetError(); pbErr != nil {
+		purged.Err = &Error{Code: newCode(pbErr.GetCode()), Message: pbErr.GetDescription()}
+	}
+
+	return purged
+}
+
+// newImportedRoute converts the given pb.ImportedRoute into an ImportedRoute
+func newImportedRoute(route *pb.ImportedRoute) *ImportedRoute {
+	imported := &ImportedRoute{
+		Destination: route.GetDestination(),
+		Action:      ImportAction(enumName(route.GetAction().String(), importActionPrefix)),
+		Line:        int(route.GetLine()),
+		IPs:         route.GetIps(),
+		Reason:      route.GetReason(),
+	}
+
+	if pbErr := route.GetError(); pbErr != nil {
+		imported.Err = &Error{Code: newCode(pbErr.GetCode()), Message: pbErr.GetDescription()}
+	}
+
+	return imported
+}
+
+// newStatus converts the given pb.StatusPayload into a Status
+func newStatus(payload *pb.StatusPayload) *Status {
+	status := &Status{
+		Version:          payload.GetVersion(),
+		GitCommit:        payload.GetGitCommit(),
+		StartedAt:        payload.GetStartedAt().AsTime(),
+		Uptime:           payload.GetUptime().AsDuration(),
+		Gateway:          payload.GetGateway(),
+		GatewayInterface: payload.GetGatewayInterface(),
+		Backend:          payload.GetBackend(),
+		RouteMode:        payload.GetRouteMode(),
+		Routes:           int(payload.GetRoutes()),
+		Groups:           int(payload.GetGroups()),
+		FailedIPs:        int(payload.GetFailedIps()),
+		ActiveRoutes:     int(payload.GetActiveRoutes()),
+		TemporaryRoutes:  int(payload.GetTemporaryRoutes()),
+		DNSServers:       payload.GetDnsServers(),
+	}
+
+	if payload.GetLastRefresh() != nil {
+		lastRefresh := payload.GetLastRefresh().AsTime()
+		status.LastRefresh = &lastRefresh
+	}
+
+	for _, sub := range payload.GetSubscriptions() {
+		status.Subscriptions = append(status.Subscriptions, newSubscription(sub))
+	}
+
+	return status
+}
+
+// newSubscription converts the given pb.SubscriptionStatus into a Subscription
+func newSubscription(sub *pb.SubscriptionStatus) *Subscription {
+	subscription := &Subscription{
+		Name:         sub.GetName(),
+		URL:          sub.GetUrl(),
+		Group:        sub.GetGroup(),
+		Status:       SyncStatus(enumName(sub.GetStatus().String(), syncStatusPrefix)),
+		LastError:    sub.GetLastError(),
+		Destinations: int(sub.GetDestinations()),
+		Invalid:      int(sub.GetInvalid()),
+	}
+
+	if sub.GetLastSync() != nil {
+		lastSync := sub.GetLastSync().AsTime()
+		subscription.LastSync = &lastSync
+	}
+
+	if sub.GetLastSuccess() != nil {
+		lastSuccess := sub.GetLastSuccess().AsTime()
+		subscription.LastSuccess = &lastSuccess
+	}
+
+	return subscription
+}
+
+// newTrace converts the given pb.TraceRoutePayload into a Trace
+func newTrace(payload *pb.TraceRoutePayload) *Trace {
+	trace := &Trace{Host: payload.GetHost(), Gateway: payload.GetGateway()}
+	if payload.GetRoute() != nil {
+		trace.Route = newRoute(payload.GetRoute())
+	}
+
+	for _, ip := range payload.GetIps() {
+		trace.IPs = append(trace.IPs, &TracedIP{
+			IP:          ip.GetIp(),
+			Resolvers:   ip.GetResolvers(),
+			Routed:      ip.GetRouted(),
+			Path:        TracePath(enumName(ip.GetPath().String(), tracePathPrefix)),
+			Interface:   ip.GetInterface(),
+			Gateway:     ip.GetGateway(),
+			Source:      ip.GetSource(),
+			Table:       ip.GetTable(),
+			Rule:        ip.GetRule(),
+			KernelRoute: ip.GetKernelRoute(),
+			Reason:      ip.GetReason(),
+		})
+	}
+
+	return trace
+}
+
+// newEvent converts the given pb.RouteEvent into an Event
+func newEvent(e *pb.RouteEvent) *Event {
+	return &Event{
+		Sequence:        e.GetSequence(),
+		Type:            EventType(enumName(e.GetType().String(), eventTypePrefix)),
+		Time:            e.GetTime().AsTime(),
+		Destination:     e.GetDestination(),
+		IPs:             e.GetIps(),
+		AddedIPs:        e.GetAddedIps(),
+		RemovedIPs:      e.GetRemovedIps(),
+		Gateway:         e.GetGateway(),
+		PreviousGateway: e.GetPreviousGateway(),
+		Error:           e.GetError(),
+	}
+}
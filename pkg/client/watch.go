@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/bilalcaliskan/split-the-tunnel/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchOptions are the filters of Watch
+type WatchOptions struct {
+	// Types filters the events by their types, every type is sent if it is empty
+	Types []EventType
+	// Destinations filters the events by their destinations, the events that are not about a single destination such
+	// as EventGatewayChanged are always sent
+	Destinations []string
+	// Replay is the number of the last matching events to send before the live ones
+	Replay int
+}
+
+// Watcher is a stream of the events of the daemon
+type Watcher struct {
+	stream pb.RouteManager_WatchRoutesClient
+	cancel context.CancelFunc
+}
+
+// Watch starts streaming the events that match the given WatchOptions, the stream lasts until the given context is
+// done or the Watcher is closed
+func (c *Client) Watch(ctx context.Context, opts WatchOptions) (*Watcher, error) {
+	req := &pb.WatchRoutesRequest{Destinations: opts.Destinations, Replay: uint32(max(opts.Replay, 0))}
+	for _, typ := range opts.Types {
+		value, ok := pb.RouteEventType_value[eventTypePrefix+strings.ToUpper(strings.ReplaceAll(string(typ), "-", "_"))]
+		if !ok {
+			return nil, &Error{GRPCCode: codes.InvalidArgument, Message: fmt.Sprintf("unknown event type %q", typ)}
+		}
+
+		req.Types = append(req.Types, pb.RouteEventType(value))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var stream pb.RouteManager_WatchRoutesClient
+	err := c.retry(ctx, func() (err error) {
+		if stream, err = c.rm.WatchRoutes(ctx, req); err != nil {
+			return err
+		}
+
+		// header is sent once the subscription is established, so the unreachable daemon is reported here
+		_, err = stream.Header()
+
+		return err
+	})
+	if err != nil {
+		cancel()
+		return nil, DecodeError(err)
+	}
+
+	return &Watcher{stream: stream, cancel: cancel}, nil
+}
+
+// Next blocks until the next event. It returns context.Canceled once the Watcher is closed or its context is done,
+// io.EOF once the daemon ends the stream, and an *Error if the stream fails, such as when the daemon drops a watcher
+// that falls behind
+func (w *Watcher) Next() (*Event, error) {
+	e, err := w.stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+
+		if status.Code(err) == codes.Canceled {
+			return nil, context.Canceled
+		}
+
+		return nil, DecodeError(err)
+	}
+
+	return newEvent(e), nil
+}
+
+// Close stops the Watcher
+func (w *Watcher) Close() {
+	w.cancel()
+}
@@ -20,9 +20,25 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	RouteManager_AddRoute_FullMethodName    = "/routemanager.RouteManager/AddRoute"
-	RouteManager_RemoveRoute_FullMethodName = "/routemanager.RouteManager/RemoveRoute"
-	RouteManager_ListRoutes_FullMethodName  = "/routemanager.RouteManager/ListRoutes"
+	RouteManager_AddRoute_FullMethodName      = "/routemanager.RouteManager/AddRoute"
+	RouteManager_RemoveRoute_FullMethodName   = "/routemanager.RouteManager/RemoveRoute"
+	RouteManager_ListRoutes_FullMethodName    = "/routemanager.RouteManager/ListRoutes"
+	RouteManager_GetRoute_FullMethodName      = "/routemanager.RouteManager/GetRoute"
+	RouteManager_UpdateRoute_FullMethodName   = "/routemanager.RouteManager/UpdateRoute"
+	RouteManager_Purge_FullMethodName         = "/routemanager.RouteManager/Purge"
+	RouteManager_Status_FullMethodName        = "/routemanager.RouteManager/Status"
+	RouteManager_TraceRoute_FullMethodName    = "/routemanager.RouteManager/TraceRoute"
+	RouteManager_ExportRoutes_FullMethodName  = "/routemanager.RouteManager/ExportRoutes"
+	RouteManager_ImportRoutes_FullMethodName  = "/routemanager.RouteManager/ImportRoutes"
+	RouteManager_CreateGroup_FullMethodName   = "/routemanager.RouteManager/CreateGroup"
+	RouteManager_AddToGroup_FullMethodName    = "/routemanager.RouteManager/AddToGroup"
+	RouteManager_EnableGroup_FullMethodName   = "/routemanager.RouteManager/EnableGroup"
+	RouteManager_DisableGroup_FullMethodName  = "/routemanager.RouteManager/DisableGroup"
+	RouteManager_ListGroups_FullMethodName    = "/routemanager.RouteManager/ListGroups"
+	RouteManager_ListPresets_FullMethodName   = "/routemanager.RouteManager/ListPresets"
+	RouteManager_EnablePreset_FullMethodName  = "/routemanager.RouteManager/EnablePreset"
+	RouteManager_DisablePreset_FullMethodName = "/routemanager.RouteManager/DisablePreset"
+	RouteManager_WatchRoutes_FullMethodName   = "/routemanager.RouteManager/WatchRoutes"
 )
 
 // RouteManagerClient is the client API for RouteManager service.
@@ -32,6 +48,34 @@ type RouteManagerClient interface {
 	AddRoute(ctx context.Context, in *AddRouteRequest, opts ...grpc.CallOption) (*AddRouteResponse, error)
 	RemoveRoute(ctx context.Context, in *RemoveRouteRequest, opts ...grpc.CallOption) (*RemoveRouteResponse, error)
 	ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error)
+	// GetRoute returns a single destination with the statuses of the routes of its IPs.
+	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error)
+	// UpdateRoute changes the expiry and the accumulate mode of a destination without resolving it again.
+	UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error)
+	// Purge removes every destination and its routes.
+	Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error)
+	// Status returns the version and the runtime status of the daemon.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// TraceRoute resolves a host and explains whether the traffic to its IPs bypasses VPN, according to the routing
+	// decisions of the kernel.
+	TraceRoute(ctx context.Context, in *TraceRouteRequest, opts ...grpc.CallOption) (*TraceRouteResponse, error)
+	// ExportRoutes returns the destinations as a list in the requested format.
+	ExportRoutes(ctx context.Context, in *ExportRoutesRequest, opts ...grpc.CallOption) (*ExportRoutesResponse, error)
+	// ImportRoutes adds the destinations of a list, the invalid and the duplicate lines are reported in the results.
+	ImportRoutes(ctx context.Context, in *ImportRoutesRequest, opts ...grpc.CallOption) (*ImportRoutesResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	AddToGroup(ctx context.Context, in *AddToGroupRequest, opts ...grpc.CallOption) (*AddToGroupResponse, error)
+	EnableGroup(ctx context.Context, in *EnableGroupRequest, opts ...grpc.CallOption) (*EnableGroupResponse, error)
+	DisableGroup(ctx context.Context, in *DisableGroupRequest, opts ...grpc.CallOption) (*DisableGroupResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	// ListPresets returns the presets of the catalog and whether they are enabled.
+	ListPresets(ctx context.Context, in *ListPresetsRequest, opts ...grpc.CallOption) (*ListPresetsResponse, error)
+	// EnablePreset expands a preset into its managed group, enabling an enabled preset syncs it to the catalog again.
+	EnablePreset(ctx context.Context, in *EnablePresetRequest, opts ...grpc.CallOption) (*EnablePresetResponse, error)
+	// DisablePreset removes the destinations of a preset and its managed group.
+	DisablePreset(ctx context.Context, in *DisablePresetRequest, opts ...grpc.CallOption) (*DisablePresetResponse, error)
+	// WatchRoutes streams the changes on the routes and the state of the daemon until the client cancels it.
+	WatchRoutes(ctx context.Context, in *WatchRoutesRequest, opts ...grpc.CallOption) (RouteManager_WatchRoutesClient, error)
 }
 
 type routeManagerClient struct {
@@ -69,6 +113,173 @@ func (c *routeManagerClient) ListRoutes(ctx context.Context, in *ListRoutesReque
 	return out, nil
 }
 
+func (c *routeManagerClient) GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error) {
+	out := new(GetRouteResponse)
+	err := c.cc.Invoke(ctx, RouteManager_GetRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error) {
+	out := new(UpdateRouteResponse)
+	err := c.cc.Invoke(ctx, RouteManager_UpdateRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error) {
+	out := new(PurgeResponse)
+	err := c.cc.Invoke(ctx, RouteManager_Purge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, RouteManager_Status_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) TraceRoute(ctx context.Context, in *TraceRouteRequest, opts ...grpc.CallOption) (*TraceRouteResponse, error) {
+	out := new(TraceRouteResponse)
+	err := c.cc.Invoke(ctx, RouteManager_TraceRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) ExportRoutes(ctx context.Context, in *ExportRoutesRequest, opts ...grpc.CallOption) (*ExportRoutesResponse, error) {
+	out := new(ExportRoutesResponse)
+	err := c.cc.Invoke(ctx, RouteManager_ExportRoutes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) ImportRoutes(ctx context.Context, in *ImportRoutesRequest, opts ...grpc.CallOption) (*ImportRoutesResponse, error) {
+	out := new(ImportRoutesResponse)
+	err := c.cc.Invoke(ctx, RouteManager_ImportRoutes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, RouteManager_CreateGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) AddToGroup(ctx context.Context, in *AddToGroupRequest, opts ...grpc.CallOption) (*AddToGroupResponse, error) {
+	out := new(AddToGroupResponse)
+	err := c.cc.Invoke(ctx, RouteManager_AddToGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) EnableGroup(ctx context.Context, in *EnableGroupRequest, opts ...grpc.CallOption) (*EnableGroupResponse, error) {
+	out := new(EnableGroupResponse)
+	err := c.cc.Invoke(ctx, RouteManager_EnableGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) DisableGroup(ctx context.Context, in *DisableGroupRequest, opts ...grpc.CallOption) (*DisableGroupResponse, error) {
+	out := new(DisableGroupResponse)
+	err := c.cc.Invoke(ctx, RouteManager_DisableGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, RouteManager_ListGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) ListPresets(ctx context.Context, in *ListPresetsRequest, opts ...grpc.CallOption) (*ListPresetsResponse, error) {
+	out := new(ListPresetsResponse)
+	err := c.cc.Invoke(ctx, RouteManager_ListPresets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) EnablePreset(ctx context.Context, in *EnablePresetRequest, opts ...grpc.CallOption) (*EnablePresetResponse, error) {
+	out := new(EnablePresetResponse)
+	err := c.cc.Invoke(ctx, RouteManager_EnablePreset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) DisablePreset(ctx context.Context, in *DisablePresetRequest, opts ...grpc.CallOption) (*DisablePresetResponse, error) {
+	out := new(DisablePresetResponse)
+	err := c.cc.Invoke(ctx, RouteManager_DisablePreset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeManagerClient) WatchRoutes(ctx context.Context, in *WatchRoutesRequest, opts ...grpc.CallOption) (RouteManager_WatchRoutesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RouteManager_ServiceDesc.Streams[0], RouteManager_WatchRoutes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routeManagerWatchRoutesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RouteManager_WatchRoutesClient interface {
+	Recv() (*RouteEvent, error)
+	grpc.ClientStream
+}
+
+type routeManagerWatchRoutesClient struct {
+	grpc.ClientStream
+}
+
+func (x *routeManagerWatchRoutesClient) Recv() (*RouteEvent, error) {
+	m := new(RouteEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RouteManagerServer is the server API for RouteManager service.
 // All implementations must embed UnimplementedRouteManagerServer
 // for forward compatibility
@@ -76,6 +287,34 @@ type RouteManagerServer interface {
 	AddRoute(context.Context, *AddRouteRequest) (*AddRouteResponse, error)
 	RemoveRoute(context.Context, *RemoveRouteRequest) (*RemoveRouteResponse, error)
 	ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error)
+	// GetRoute returns a single destination with the statuses of the routes of its IPs.
+	GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error)
+	// UpdateRoute changes the expiry and the accumulate mode of a destination without resolving it again.
+	UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error)
+	// Purge removes every destination and its routes.
+	Purge(context.Context, *PurgeRequest) (*PurgeResponse, error)
+	// Status returns the version and the runtime status of the daemon.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// TraceRoute resolves a host and explains whether the traffic to its IPs bypasses VPN, according to the routing
+	// decisions of the kernel.
+	TraceRoute(context.Context, *TraceRouteRequest) (*TraceRouteResponse, error)
+	// ExportRoutes returns the destinations as a list in the requested format.
+	ExportRoutes(context.Context, *ExportRoutesRequest) (*ExportRoutesResponse, error)
+	// ImportRoutes adds the destinations of a list, the invalid and the duplicate lines are reported in the results.
+	ImportRoutes(context.Context, *ImportRoutesRequest) (*ImportRoutesResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	AddToGroup(context.Context, *AddToGroupRequest) (*AddToGroupResponse, error)
+	EnableGroup(context.Context, *EnableGroupRequest) (*EnableGroupResponse, error)
+	DisableGroup(context.Context, *DisableGroupRequest) (*DisableGroupResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	// ListPresets returns the presets of the catalog and whether they are enabled.
+	ListPresets(context.Context, *ListPresetsRequest) (*ListPresetsResponse, error)
+	// EnablePreset expands a preset into its managed group, enabling an enabled preset syncs it to the catalog again.
+	EnablePreset(context.Context, *EnablePresetRequest) (*EnablePresetResponse, error)
+	// DisablePreset removes the destinations of a preset and its managed group.
+	DisablePreset(context.Context, *DisablePresetRequest) (*DisablePresetResponse, error)
+	// WatchRoutes streams the changes on the routes and the state of the daemon until the client cancels it.
+	WatchRoutes(*WatchRoutesRequest, RouteManager_WatchRoutesServer) error
 	mustEmbedUnimplementedRouteManagerServer()
 }
 
@@ -92,6 +331,54 @@ func (UnimplementedRouteManagerServer) RemoveRoute(context.Context, *RemoveRoute
 func (UnimplementedRouteManagerServer) ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRoutes not implemented")
 }
+func (UnimplementedRouteManagerServer) GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoute not implemented")
+}
+func (UnimplementedRouteManagerServer) UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoute not implemented")
+}
+func (UnimplementedRouteManagerServer) Purge(context.Context, *PurgeRequest) (*PurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedRouteManagerServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedRouteManagerServer) TraceRoute(context.Context, *TraceRouteRequest) (*TraceRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TraceRoute not implemented")
+}
+func (UnimplementedRouteManagerServer) ExportRoutes(context.Context, *ExportRoutesRequest) (*ExportRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRoutes not implemented")
+}
+func (UnimplementedRouteManagerServer) ImportRoutes(context.Context, *ImportRoutesRequest) (*ImportRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRoutes not implemented")
+}
+func (UnimplementedRouteManagerServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedRouteManagerServer) AddToGroup(context.Context, *AddToGroupRequest) (*AddToGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToGroup not implemented")
+}
+func (UnimplementedRouteManagerServer) EnableGroup(context.Context, *EnableGroupRequest) (*EnableGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableGroup not implemented")
+}
+func (UnimplementedRouteManagerServer) DisableGroup(context.Context, *DisableGroupRequest) (*DisableGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableGroup not implemented")
+}
+func (UnimplementedRouteManagerServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedRouteManagerServer) ListPresets(context.Context, *ListPresetsRequest) (*ListPresetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPresets not implemented")
+}
+func (UnimplementedRouteManagerServer) EnablePreset(context.Context, *EnablePresetRequest) (*EnablePresetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnablePreset not implemented")
+}
+func (UnimplementedRouteManagerServer) DisablePreset(context.Context, *DisablePresetRequest) (*DisablePresetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisablePreset not implemented")
+}
+func (UnimplementedRouteManagerServer) WatchRoutes(*WatchRoutesRequest, RouteManager_WatchRoutesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRoutes not implemented")
+}
 func (UnimplementedRouteManagerServer) mustEmbedUnimplementedRouteManagerServer() {}
 
 // UnsafeRouteManagerServer may be embedded to opt out of forward compatibility for this service.
@@ -159,6 +446,297 @@ func _RouteManager_ListRoutes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RouteManager_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).GetRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_GetRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).GetRoute(ctx, req.(*GetRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_UpdateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).UpdateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_UpdateRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).UpdateRoute(ctx, req.(*UpdateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).Purge(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_TraceRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).TraceRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_TraceRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).TraceRoute(ctx, req.(*TraceRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_ExportRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).ExportRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_ExportRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).ExportRoutes(ctx, req.(*ExportRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_ImportRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).ImportRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_ImportRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).ImportRoutes(ctx, req.(*ImportRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_AddToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).AddToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_AddToGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).AddToGroup(ctx, req.(*AddToGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_EnableGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).EnableGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_EnableGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).EnableGroup(ctx, req.(*EnableGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_DisableGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).DisableGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_DisableGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).DisableGroup(ctx, req.(*DisableGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_ListPresets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPresetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).ListPresets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_ListPresets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).ListPresets(ctx, req.(*ListPresetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_EnablePreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnablePresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).EnablePreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_EnablePreset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).EnablePreset(ctx, req.(*EnablePresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_DisablePreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisablePresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteManagerServer).DisablePreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteManager_DisablePreset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteManagerServer).DisablePreset(ctx, req.(*DisablePresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteManager_WatchRoutes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRoutesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouteManagerServer).WatchRoutes(m, &routeManagerWatchRoutesServer{stream})
+}
+
+type RouteManager_WatchRoutesServer interface {
+	Send(*RouteEvent) error
+	grpc.ServerStream
+}
+
+type routeManagerWatchRoutesServer struct {
+	grpc.ServerStream
+}
+
+func (x *routeManagerWatchRoutesServer) Send(m *RouteEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RouteManager_ServiceDesc is the grpc.ServiceDesc for RouteManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -178,7 +756,73 @@ var RouteManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRoutes",
 			Handler:    _RouteManager_ListRoutes_Handler,
 		},
+		{
+			MethodName: "GetRoute",
+			Handler:    _RouteManager_GetRoute_Handler,
+		},
+		{
+			MethodName: "UpdateRoute",
+			Handler:    _RouteManager_UpdateRoute_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _RouteManager_Purge_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _RouteManager_Status_Handler,
+		},
+		{
+			MethodName: "TraceRoute",
+			Handler:    _RouteManager_TraceRoute_Handler,
+		},
+		{
+			MethodName: "ExportRoutes",
+			Handler:    _RouteManager_ExportRoutes_Handler,
+		},
+		{
+			MethodName: "ImportRoutes",
+			Handler:    _RouteManager_ImportRoutes_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _RouteManager_CreateGroup_Handler,
+		},
+		{
+			MethodName: "AddToGroup",
+			Handler:    _RouteManager_AddToGroup_Handler,
+		},
+		{
+			MethodName: "EnableGroup",
+			Handler:    _RouteManager_EnableGroup_Handler,
+		},
+		{
+			MethodName: "DisableGroup",
+			Handler:    _RouteManager_DisableGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _RouteManager_ListGroups_Handler,
+		},
+		{
+			MethodName: "ListPresets",
+			Handler:    _RouteManager_ListPresets_Handler,
+		},
+		{
+			MethodName: "EnablePreset",
+			Handler:    _RouteManager_EnablePreset_Handler,
+		},
+		{
+			MethodName: "DisablePreset",
+			Handler:    _RouteManager_DisablePreset_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRoutes",
+			Handler:       _RouteManager_WatchRoutes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "routemanager.proto",
 }